@@ -0,0 +1,50 @@
+package main
+
+import (
+	"io"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/diff"
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+type diffCommand struct {
+	Args struct {
+		Old string `positional-arg-name:"old" description:"Original theme file"`
+		New string `positional-arg-name:"new" description:"Changed theme file"`
+	} `positional-args:"yes" required:"yes"`
+
+	out io.Writer
+}
+
+func (s *diffCommand) Execute(args []string) error {
+	a, err := loadTheme(s.Args.Old)
+	if err != nil {
+		return err
+	}
+	b, err := loadTheme(s.Args.New)
+	if err != nil {
+		return err
+	}
+
+	diffs := diff.Themes(a, b)
+	if err := diff.Write(s.out, diffs); err != nil {
+		return err
+	}
+	if len(diffs) > 0 {
+		return exitCode(1)
+	}
+
+	return nil
+}
+
+func loadTheme(filename string) (*theme.Theme, error) {
+	t := theme.New()
+	if err := t.Load(filename); err != nil {
+		return nil, err
+	}
+	if err := t.Execute(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffCommand(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &diffCommand{out: &buf}
+	cmd.Args.Old = "testdata/old.tmuxtheme"
+	cmd.Args.New = "testdata/new.tmuxtheme"
+
+	err := cmd.Execute(nil)
+
+	assert.Equal(t, exitCode(1), err)
+	assert.Equal(t, `global-session:
+  + @bg "colour160"
+  ~ status-style "bg=red,fg=white" -> "bg=colour160,fg=white"
+      bg: red -> colour160 (distance 3.9)
+`, buf.String())
+}
+
+func TestDiffCommandSame(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &diffCommand{out: &buf}
+	cmd.Args.Old = "testdata/old.tmuxtheme"
+	cmd.Args.New = "testdata/old.tmuxtheme"
+
+	err := cmd.Execute(nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "", buf.String())
+}
+
+func TestDiffCommandMissingFile(t *testing.T) {
+	cmd := &diffCommand{out: &bytes.Buffer{}}
+	cmd.Args.Old = "testdata/old.tmuxtheme"
+	cmd.Args.New = "testdata/missing.tmuxtheme"
+
+	err := cmd.Execute(nil)
+
+	assert.Error(t, err)
+}
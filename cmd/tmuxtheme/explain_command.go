@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+type explainCommand struct {
+	Target string `short:"t" long:"target" value-name:"session[:window]" description:"Session or window to look options up for"`
+
+	Args struct {
+		Theme   string   `positional-arg-name:"theme" description:"Theme file"`
+		Options []string `positional-arg-name:"option" description:"Options to explain" required:"1"`
+	} `positional-args:"yes" required:"yes"`
+
+	out io.Writer
+}
+
+func (s *explainCommand) Execute(args []string) error {
+	t, err := loadTheme(s.Args.Theme)
+	if err != nil {
+		return err
+	}
+
+	session, window := s.Target, ""
+	if strings.Contains(s.Target, ":") {
+		session, window = strings.SplitN(s.Target, ":", 2)[0], s.Target
+	}
+
+	for i, name := range s.Args.Options {
+		if i > 0 {
+			fmt.Fprintln(s.out)
+		}
+		_, err := fmt.Fprint(s.out, t.Explain(name, session, window))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainCommand(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &explainCommand{out: &buf}
+	cmd.Args.Theme = "testdata/explain.tmuxtheme"
+	cmd.Args.Options = []string{"status-style", "@accent"}
+	cmd.Target = "main:1"
+
+	err := cmd.Execute(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, `#{status-style} (built-in option)
+  session main: not set
+  global-session: found "bg=blue"
+  => "bg=blue"
+
+status-style (global-session)
+  testdata/explain.tmuxtheme:2: set "bg=blue"
+    #{@accent} = "blue"
+
+#{@accent} (user option)
+  server: not set
+  window main:1: found "red"
+  => "red"
+
+@accent (window main:1)
+  testdata/explain.tmuxtheme:3: set "red"
+`, buf.String())
+}
+
+func TestExplainCommandMissingFile(t *testing.T) {
+	cmd := &explainCommand{out: &bytes.Buffer{}}
+	cmd.Args.Theme = "testdata/missing.tmuxtheme"
+	cmd.Args.Options = []string{"status-style"}
+
+	assert.Error(t, cmd.Execute(nil))
+}
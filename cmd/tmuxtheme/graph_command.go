@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/graph"
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+type graphCommand struct {
+	Format string `short:"f" long:"format" choice:"dot" choice:"mermaid" default:"dot" description:"Graph output format"`
+	Check  bool   `short:"c" long:"check" description:"Report cycles, undefined references and unused options instead"`
+	Prefix string `long:"prefix" default:"@theme-" description:"Name prefix of options checked for being unused"`
+
+	Args struct {
+		Theme string `positional-arg-name:"theme" description:"Theme file"`
+	} `positional-args:"yes" required:"yes"`
+
+	out io.Writer
+}
+
+func (s *graphCommand) Execute(args []string) error {
+	t := theme.New()
+	if err := t.Load(s.Args.Theme); err != nil {
+		return err
+	}
+	g := graph.New(t)
+
+	if s.Check {
+		return s.check(g)
+	}
+	if s.Format == "mermaid" {
+		return g.WriteMermaid(s.out)
+	}
+
+	return g.WriteDOT(s.out)
+}
+
+func (s *graphCommand) check(g *graph.Graph) error {
+	problems := []string{}
+
+	for _, cycle := range g.Cycles() {
+		problems = append(problems, fmt.Sprintf(
+			"Reference cycle: %s", strings.Join(cycle, ", ")))
+	}
+	for _, e := range g.Undefined() {
+		problems = append(problems, fmt.Sprintf(
+			"%s: %s references %s, which is never set",
+			e.Position, e.From, e.To))
+	}
+	for _, n := range g.Unused(s.Prefix) {
+		problems = append(problems, fmt.Sprintf(
+			"%s: %s is set but never referenced", n.Set[0], n.Name))
+	}
+
+	for _, p := range problems {
+		if _, err := fmt.Fprintln(s.out, p); err != nil {
+			return err
+		}
+	}
+	if len(problems) > 0 {
+		return exitCode(1)
+	}
+
+	return nil
+}
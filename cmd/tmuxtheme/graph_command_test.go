@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphCommand(t *testing.T) {
+	var tests = []struct {
+		format string
+		first  string
+	}{
+		{"dot", "digraph theme {"},
+		{"mermaid", "graph LR"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		cmd := &graphCommand{Format: tt.format, out: &buf}
+		cmd.Args.Theme = "testdata/graph.tmuxtheme"
+
+		err := cmd.Execute(nil)
+		require.NoError(t, err, tt.format)
+
+		assert.Equal(t, tt.first, strings.SplitN(buf.String(), "\n", 2)[0])
+	}
+}
+
+func TestGraphCommandCheck(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &graphCommand{Check: true, Prefix: "@theme-", out: &buf}
+	cmd.Args.Theme = "testdata/graph.tmuxtheme"
+
+	err := cmd.Execute(nil)
+
+	assert.Equal(t, exitCode(1), err)
+	assert.Equal(t, `Reference cycle: @a
+testdata/graph.tmuxtheme:4: status-left references @theme-left, which is never set
+testdata/graph.tmuxtheme:2: @theme-unused is set but never referenced
+`, buf.String())
+}
+
+func TestGraphCommandCheckClean(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &graphCommand{Check: true, Prefix: "@theme-", out: &buf}
+	cmd.Args.Theme = "testdata/old.tmuxtheme"
+
+	err := cmd.Execute(nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "", buf.String())
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/lint"
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+type lintCommand struct {
+	Config string `short:"c" long:"config" description:"Lint config file, defaults to .tmuxtheme-lint.yml in the current directory if it exists"`
+	Format string `short:"f" long:"format" choice:"text" choice:"json" choice:"sarif" default:"text" description:"Output format"`
+	Fix    bool   `long:"fix" description:"Apply the fixes of fixable findings to the theme files, and report the findings left"`
+
+	Args struct {
+		Themes []string `positional-arg-name:"theme" description:"Theme files" required:"1"`
+	} `positional-args:"yes" required:"yes"`
+
+	out io.Writer
+}
+
+func (s *lintCommand) Execute(args []string) error {
+	config, err := s.loadConfig()
+	if err != nil {
+		return err
+	}
+	linter := lint.New(config)
+
+	diags := []*lint.Diagnostic{}
+	for _, filename := range s.Args.Themes {
+		t := theme.New()
+		if err := t.Load(filename); err != nil {
+			return err
+		}
+
+		d, err := linter.Lint(t)
+		if err != nil {
+			return err
+		}
+		if s.Fix {
+			d, err = fixTheme(linter, t, d)
+			if err != nil {
+				return err
+			}
+		}
+		diags = append(diags, d...)
+	}
+
+	switch s.Format {
+	case "json":
+		err = lint.WriteJSON(s.out, diags)
+	case "sarif":
+		err = lint.WriteSARIF(s.out, diags, linter.Rules)
+	default:
+		err = lint.WriteText(s.out, diags)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, d := range diags {
+		if d.Severity == lint.Error {
+			return exitCode(1)
+		}
+	}
+
+	return nil
+}
+
+// maxFixPasses limits how many times a file is fixed and linted again, to
+// apply fixes skipped for overlapping others.
+const maxFixPasses = 5
+
+// fixTheme applies the fixes of diags to the theme's file, and returns the
+// diagnostics left once it is linted again.
+func fixTheme(
+	linter *lint.Linter,
+	t *theme.Theme,
+	diags []*lint.Diagnostic,
+) ([]*lint.Diagnostic, error) {
+	filename := t.Filename
+
+	for pass := 0; pass < maxFixPasses; pass++ {
+		lines, applied := lint.ApplyFixes(t.Lines, diags)
+		if applied == 0 {
+			break
+		}
+
+		err := rewriteFile(filename, lines)
+		if err != nil {
+			return nil, err
+		}
+
+		t = theme.New()
+		if err := t.Load(filename); err != nil {
+			return nil, err
+		}
+		diags, err = linter.Lint(t)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return diags, nil
+}
+
+// rewriteFile replaces the lines of filename, keeping its line endings,
+// trailing newline and permissions.
+func rewriteFile(filename string, lines []string) error {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	newline := "\n"
+	if bytes.Contains(data, []byte("\r\n")) {
+		newline = "\r\n"
+	}
+
+	out := strings.Join(lines, newline)
+	if len(lines) > 0 && bytes.HasSuffix(data, []byte("\n")) {
+		out += newline
+	}
+
+	return ioutil.WriteFile(filename, []byte(out), info.Mode())
+}
+
+func (s *lintCommand) loadConfig() (*lint.Config, error) {
+	if s.Config != "" {
+		return lint.LoadConfig(s.Config)
+	}
+
+	if _, err := os.Stat(lint.ConfigFilename); err == nil {
+		return lint.LoadConfig(lint.ConfigFilename)
+	}
+
+	return lint.DefaultConfig(), nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintCommand(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &lintCommand{Format: "text", out: &buf}
+	cmd.Args.Themes = []string{"testdata/lint/theme.tmuxtheme"}
+
+	err := cmd.Execute(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, `testdata/lint/theme.tmuxtheme:2: warning: status-style is set again on line 3 before this value is used [dead-set] (fixable)
+testdata/lint/theme.tmuxtheme:2: info: status-style hard-codes red instead of using the @theme- palette [hardcoded-colour]
+testdata/lint/theme.tmuxtheme:3: info: status-style hard-codes blue instead of using the @theme- palette [hardcoded-colour]
+`, buf.String())
+}
+
+func TestLintCommandConfig(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &lintCommand{
+		Config: "testdata/lint/config.yml",
+		Format: "json",
+		out:    &buf,
+	}
+	cmd.Args.Themes = []string{"testdata/lint/theme.tmuxtheme"}
+
+	err := cmd.Execute(nil)
+
+	assert.Equal(t, exitCode(1), err)
+	assert.Equal(t, 1, strings.Count(buf.String(), `"rule"`))
+	assert.Contains(t, buf.String(), `"severity": "error"`)
+}
+
+func TestLintCommandSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &lintCommand{Format: "sarif", out: &buf}
+	cmd.Args.Themes = []string{"testdata/lint/theme.tmuxtheme"}
+
+	err := cmd.Execute(nil)
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), `"version": "2.1.0"`)
+}
+
+func TestLintCommandFix(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tmuxtheme")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "theme.tmuxtheme")
+	err = ioutil.WriteFile(filename, []byte("# Theme\r\n"+
+		"set -gF @theme-fg \"white\"\r\n"+
+		"set -g status-style \"fg=red\" # old\r\n"+
+		"set -g status-style \"fg=#{@theme-fg}\"\r\n"+
+		"set-window-option -g window-status-current-bg blue\r\n"), 0644)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	cmd := &lintCommand{Format: "text", Fix: true, out: &buf}
+	cmd.Args.Themes = []string{filename}
+
+	err = cmd.Execute(nil)
+	require.NoError(t, err)
+
+	fixed, err := ioutil.ReadFile(filename)
+	require.NoError(t, err)
+	assert.Equal(t, "# Theme\r\n"+
+		"set -g @theme-fg \"white\"\r\n"+
+		"set -g status-style \"fg=#{@theme-fg}\"\r\n"+
+		"set -aw -g window-status-current-style ,bg=blue\r\n",
+		string(fixed))
+	assert.Equal(t, filename+":4: info: window-status-current-style "+
+		"hard-codes blue instead of using the @theme- palette "+
+		"[hardcoded-colour]\n", buf.String())
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"io"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/lsp"
+)
+
+type lspCommand struct {
+	Stdio bool `long:"stdio" description:"Communicate over stdin and stdout, the default"`
+
+	in  io.Reader
+	out io.Writer
+}
+
+func (s *lspCommand) Execute(args []string) error {
+	err := lsp.NewServer(s.in, s.out).Run()
+	if _, ok := err.(*lsp.ExitError); ok {
+		return exitCode(1)
+	}
+
+	return err
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lspMessages(messages ...string) string {
+	out := ""
+	for _, m := range messages {
+		out += "Content-Length: " + strconv.Itoa(len(m)) + "\r\n\r\n" + m
+	}
+
+	return out
+}
+
+func TestLSPCommand(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &lspCommand{
+		in: strings.NewReader(lspMessages(
+			`{"jsonrpc":"2.0","id":1,"method":"shutdown"}`,
+			`{"jsonrpc":"2.0","method":"exit"}`,
+		)),
+		out: &buf,
+	}
+
+	err := cmd.Execute(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Content-Length: 38\r\n\r\n"+
+		`{"jsonrpc":"2.0","id":1,"result":null}`, buf.String())
+}
+
+func TestLSPCommandExitWithoutShutdown(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &lspCommand{
+		in:  strings.NewReader(lspMessages(`{"jsonrpc":"2.0","method":"exit"}`)),
+		out: &buf,
+	}
+
+	err := cmd.Execute(nil)
+
+	assert.Equal(t, exitCode(1), err)
+}
@@ -0,0 +1,121 @@
+// Command tmuxtheme works with tmux theme files.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jessevdk/go-flags"
+)
+
+// exitCode, when returned from a command, exits with the given status
+// without printing an error.
+type exitCode int
+
+func (s exitCode) Error() string {
+	return fmt.Sprintf("Exit status %d", int(s))
+}
+
+type command struct {
+	name  string
+	short string
+	long  string
+	data  interface{}
+}
+
+func commands() []*command {
+	return []*command{
+		{
+			name:  "diff",
+			short: "Compare two themes",
+			long: "Executes both themes and reports the options added, " +
+				"removed and changed in each scope. Exits with status 1 " +
+				"when the themes differ.",
+			data: &diffCommand{out: os.Stdout},
+		},
+		{
+			name:  "explain",
+			short: "Explain where option values come from",
+			long: "Executes the theme and shows how each option is looked " +
+				"up, and every statement which set, appended to, unset or " +
+				"was skipped for it, along with the options referenced by " +
+				"-F values.",
+			data: &explainCommand{out: os.Stdout},
+		},
+		{
+			name:  "graph",
+			short: "Show how options reference each other",
+			long: "Writes the graph of #{...} references between the " +
+				"theme's options as DOT or Mermaid. With --check, reports " +
+				"reference cycles, references to options which are never " +
+				"set, and options which are set but never referenced, and " +
+				"exits with status 1 if there are any.",
+			data: &graphCommand{out: os.Stdout},
+		},
+		{
+			name:  "lint",
+			short: "Check themes for likely mistakes",
+			long: "Checks theme files for dead or redundant statements and " +
+				"likely mistakes. Findings for a statement can be silenced " +
+				"with a \"# tmuxtheme:ignore rule\" comment above it. Exits " +
+				"with status 1 if any finding has error severity.",
+			data: &lintCommand{out: os.Stdout},
+		},
+		{
+			name:  "lsp",
+			short: "Run the language server",
+			long: "Speaks the Language Server Protocol over stdin and " +
+				"stdout, giving editors diagnostics from the parser and " +
+				"linter, completion, hover, go to definition, document " +
+				"symbols and colour decorations for theme files.",
+			data: &lspCommand{in: os.Stdin, out: os.Stdout},
+		},
+		{
+			name:  "merge",
+			short: "Three-way merge themes",
+			long: "Merges the changes made between base and upstream into " +
+				"local, keeping the comments and layout of local. Options " +
+				"changed on both sides are reported as conflicts, and the " +
+				"merge exits with status 1.",
+			data: &mergeCommand{out: os.Stdout, errOut: os.Stderr},
+		},
+		{
+			name:  "tokens",
+			short: "Show syntax highlighting tokens",
+			long: "Splits the theme source into classified tokens, like " +
+				"commands, flags, options, strings, formats, styles and " +
+				"colours, with their byte offsets, for syntax highlighters.",
+			data: &tokensCommand{out: os.Stdout},
+		},
+	}
+}
+
+func main() {
+	parser := flags.NewNamedParser(
+		"tmuxtheme", flags.HelpFlag|flags.PassDoubleDash,
+	)
+
+	for _, c := range commands() {
+		_, err := parser.AddCommand(c.name, c.short, c.long, c.data)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	}
+
+	_, err := parser.Parse()
+	if err == nil {
+		return
+	}
+
+	if code, ok := err.(exitCode); ok {
+		os.Exit(int(code))
+	}
+	if ferr, ok := err.(*flags.Error); ok && ferr.Type == flags.ErrHelp {
+		fmt.Fprintln(os.Stdout, err)
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
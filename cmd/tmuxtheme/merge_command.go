@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/merge"
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+type mergeCommand struct {
+	Output  string `short:"o" long:"output" description:"Write the merged theme to file instead of stdout"`
+	Markers bool   `short:"m" long:"markers" description:"Write conflicting options between conflict markers"`
+
+	Args struct {
+		Base     string `positional-arg-name:"base" description:"Common ancestor theme file"`
+		Upstream string `positional-arg-name:"upstream" description:"Upstream theme file"`
+		Local    string `positional-arg-name:"local" description:"Locally modified theme file"`
+	} `positional-args:"yes" required:"yes"`
+
+	out    io.Writer
+	errOut io.Writer
+}
+
+func (s *mergeCommand) Execute(args []string) error {
+	themes := []*theme.Theme{}
+	for _, filename := range []string{
+		s.Args.Base, s.Args.Upstream, s.Args.Local,
+	} {
+		t := theme.New()
+		if err := t.Load(filename); err != nil {
+			return err
+		}
+		themes = append(themes, t)
+	}
+
+	result, err := merge.Merge(themes[0], themes[1], themes[2],
+		&merge.Options{Markers: s.Markers})
+	if _, ok := err.(*merge.ConflictError); err != nil && !ok {
+		return err
+	}
+
+	for _, c := range result.Conflicts {
+		fmt.Fprint(s.errOut, c)
+	}
+
+	out := s.out
+	if s.Output != "" {
+		f, err := os.Create(s.Output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := result.Write(out); err != nil {
+		return err
+	}
+	if len(result.Conflicts) > 0 {
+		return exitCode(1)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMergeCommand(out, errOut *bytes.Buffer) *mergeCommand {
+	cmd := &mergeCommand{out: out, errOut: errOut}
+	cmd.Args.Base = "testdata/merge/base.tmuxtheme"
+	cmd.Args.Upstream = "testdata/merge/upstream.tmuxtheme"
+	cmd.Args.Local = "testdata/merge/local.tmuxtheme"
+
+	return cmd
+}
+
+func TestMergeCommand(t *testing.T) {
+	var out, errOut bytes.Buffer
+	cmd := newMergeCommand(&out, &errOut)
+
+	err := cmd.Execute(nil)
+
+	assert.Equal(t, exitCode(1), err)
+	assert.Equal(t, `# Local tweaks.
+set -g status-style "bg=blue"
+set -g status-left "[#S]"
+set -g @accent red
+`, out.String())
+	assert.Equal(t, `Conflict: global-session @accent
+  base:     set -g @accent blue
+  upstream: set -g @accent cyan
+  local:    set -g @accent red
+`, errOut.String())
+}
+
+func TestMergeCommandOutput(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tmuxtheme")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var out, errOut bytes.Buffer
+	cmd := newMergeCommand(&out, &errOut)
+	cmd.Args.Local = "testdata/merge/base.tmuxtheme"
+	cmd.Output = filepath.Join(dir, "merged.tmuxtheme")
+
+	err = cmd.Execute(nil)
+	require.NoError(t, err)
+
+	merged, err := ioutil.ReadFile(cmd.Output)
+	require.NoError(t, err)
+	assert.Equal(t, "", out.String())
+	assert.Equal(t, "", errOut.String())
+	assert.Equal(t, `set -g status-style "bg=blue"
+set -g status-left "#S"
+set -g @accent cyan
+`, string(merged))
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+type tokensCommand struct {
+	Format string `short:"f" long:"format" choice:"text" choice:"json" default:"text" description:"Output format"`
+
+	Args struct {
+		Theme string `positional-arg-name:"theme" description:"Theme file"`
+	} `positional-args:"yes" required:"yes"`
+
+	out io.Writer
+}
+
+func (s *tokensCommand) Execute(args []string) error {
+	src, err := ioutil.ReadFile(s.Args.Theme)
+	if err != nil {
+		return err
+	}
+	tokens := theme.Tokenize(string(src))
+
+	if s.Format == "json" {
+		enc := json.NewEncoder(s.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(tokens)
+	}
+
+	for _, t := range tokens {
+		_, err := fmt.Fprintf(s.out, "%d-%d %s %q\n",
+			t.Start, t.End, t.Kind, t.Text)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokensCommand(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &tokensCommand{Format: "text", out: &buf}
+	cmd.Args.Theme = "testdata/tokens.tmuxtheme"
+
+	err := cmd.Execute(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, `0-8 comment "# Status"
+9-12 command "set"
+13-15 flag "-g"
+16-28 option "status-style"
+29-30 string "\""
+30-33 string "bg="
+33-39 format "#{@bg}"
+39-40 string "\""
+`, buf.String())
+}
+
+func TestTokensCommandJSON(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &tokensCommand{Format: "json", out: &buf}
+	cmd.Args.Theme = "testdata/tokens.tmuxtheme"
+
+	err := cmd.Execute(nil)
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), `{
+    "kind": "comment",
+    "start": 0,
+    "end": 8,
+    "text": "# Status"
+  },`)
+}
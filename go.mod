@@ -3,7 +3,9 @@ module github.com/jimeh/go-tmuxtheme
 go 1.13
 
 require (
+	github.com/BurntSushi/toml v1.3.2
 	github.com/jessevdk/go-flags v1.4.0
 	github.com/kballard/go-shellquote v0.0.0-20180428030007-95032a82bc51
 	github.com/stretchr/testify v1.4.0
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -0,0 +1,102 @@
+// Package diff compares executed themes option by option, so themes setting
+// the same options in different places or through -F indirection can be
+// compared by their effect rather than their text.
+package diff
+
+import (
+	"sort"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// Themes returns the options added, removed and changed going from a to b.
+// Both themes must already be executed. Options are ordered by scope, then
+// target and name.
+func Themes(a, b *theme.Theme) []*Option {
+	diffs := []*Option{}
+
+	add := func(scope theme.Scope, target string, old, new map[string]string) {
+		diffs = append(diffs, Options(scope, target, old, new)...)
+	}
+
+	add(theme.ServerScope, "", a.ServerOptions, b.ServerOptions)
+	add(theme.GlobalSessionScope, "", a.GlobalSessionOptions,
+		b.GlobalSessionOptions)
+	add(theme.SessionScope, "", a.SessionOptions, b.SessionOptions)
+	for _, target := range targets(a.TargetSessionOptions,
+		b.TargetSessionOptions) {
+		add(theme.SessionScope, target, a.TargetSessionOptions[target],
+			b.TargetSessionOptions[target])
+	}
+	add(theme.GlobalWindowScope, "", a.GlobalWindowOptions,
+		b.GlobalWindowOptions)
+	add(theme.WindowScope, "", a.WindowOptions, b.WindowOptions)
+	for _, target := range targets(a.TargetWindowOptions,
+		b.TargetWindowOptions) {
+		add(theme.WindowScope, target, a.TargetWindowOptions[target],
+			b.TargetWindowOptions[target])
+	}
+
+	return diffs
+}
+
+// Options compares two sets of options in one scope, sorted by name.
+func Options(
+	scope theme.Scope,
+	target string,
+	old, new map[string]string,
+) []*Option {
+	names := []string{}
+	for name := range old {
+		names = append(names, name)
+	}
+	for name := range new {
+		if _, ok := old[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	diffs := []*Option{}
+	for _, name := range names {
+		o, inOld := old[name]
+		n, inNew := new[name]
+
+		d := &Option{Scope: scope, Target: target, Name: name, Old: o, New: n}
+		switch {
+		case !inOld:
+			d.Kind = Added
+		case !inNew:
+			d.Kind = Removed
+		case o != n:
+			attrs, ok := attributes(name, o, n)
+			if ok && len(attrs) == 0 {
+				// Equivalent styles written differently.
+				continue
+			}
+			d.Kind = Changed
+			d.Attributes = attrs
+		default:
+			continue
+		}
+
+		diffs = append(diffs, d)
+	}
+
+	return diffs
+}
+
+func targets(a, b map[string]map[string]string) []string {
+	targets := []string{}
+	for target := range a {
+		targets = append(targets, target)
+	}
+	for target := range b {
+		if _, ok := a[target]; !ok {
+			targets = append(targets, target)
+		}
+	}
+	sort.Strings(targets)
+
+	return targets
+}
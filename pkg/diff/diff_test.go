@@ -0,0 +1,120 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThemes(t *testing.T) {
+	a := themetest.Execute(t, "", `
+set -g status-style "bg=red,fg=white"
+set -g status-left "#S"
+set -g @accent blue
+set -s escape-time 10
+set -w -t main:1 window-status-format "#I"
+`)
+	b := themetest.Execute(t, "", `
+set -g @colour-bg colour160
+set -gF status-style "bg=#{@colour-bg},fg=white,bold"
+set -g @accent blue
+set -g status-right "%H:%M"
+set -s escape-time 0
+set -t main status-left "#S"
+set -w -t main:2 window-status-format "#I"
+`)
+
+	diffs := Themes(a, b)
+
+	type entry struct {
+		scope  theme.Scope
+		target string
+		name   string
+		kind   Kind
+	}
+	got := []entry{}
+	for _, d := range diffs {
+		got = append(got, entry{d.Scope, d.Target, d.Name, d.Kind})
+	}
+
+	assert.Equal(t, []entry{
+		{theme.ServerScope, "", "escape-time", Changed},
+		{theme.GlobalSessionScope, "", "@colour-bg", Added},
+		{theme.GlobalSessionScope, "", "status-left", Removed},
+		{theme.GlobalSessionScope, "", "status-right", Added},
+		{theme.GlobalSessionScope, "", "status-style", Changed},
+		{theme.SessionScope, "main", "status-left", Added},
+		{theme.WindowScope, "main:1", "window-status-format", Removed},
+		{theme.WindowScope, "main:2", "window-status-format", Added},
+	}, got)
+
+	style := diffs[4]
+	assert.Equal(t, "bg=red,fg=white", style.Old)
+	assert.Equal(t, "bg=colour160,fg=white,bold", style.New)
+	require.Len(t, style.Attributes, 2)
+	assert.Equal(t, "bg", style.Attributes[0].Name)
+	assert.Equal(t, "red", style.Attributes[0].Old)
+	assert.Equal(t, "colour160", style.Attributes[0].New)
+	assert.True(t, style.Attributes[0].HasDistance)
+	assert.Equal(t, &Attribute{Name: "attrs", Old: "none", New: "bright"},
+		style.Attributes[1])
+
+	assert.Nil(t, diffs[0].Attributes)
+}
+
+func TestThemesEquivalentStyles(t *testing.T) {
+	a := themetest.Execute(t, "", `set -g status-style "bg=red,fg=white"`)
+	b := themetest.Execute(t, "", `set -g status-style "fg=white bg=red"`)
+
+	assert.Empty(t, Themes(a, b))
+}
+
+func TestOptionsAttributes(t *testing.T) {
+	var tests = []struct {
+		name  string
+		old   string
+		new   string
+		attrs []*Attribute
+	}{
+		{
+			name: "pane-border-style",
+			old:  "fg=default,align=left",
+			new:  "fg=default,align=right,us=red",
+			attrs: []*Attribute{
+				{Name: "us", Old: "none", New: "red"},
+				{Name: "align", Old: "left", New: "right"},
+			},
+		},
+		{
+			name: "@theme-status-bg",
+			old:  "default",
+			new:  "blue",
+			attrs: []*Attribute{
+				{Name: "colour", Old: "default", New: "blue"},
+			},
+		},
+		{
+			name: "@theme-status-style",
+			old:  "bold",
+			new:  "bold,italics",
+			attrs: []*Attribute{
+				{Name: "attrs", Old: "bright", New: "bright,italics"},
+			},
+		},
+		{name: "status-left", old: "#S", new: "#H"},
+		{name: "status-style", old: "fg=red", new: "fg=#{@missing}"},
+	}
+
+	for _, tt := range tests {
+		diffs := Options(theme.GlobalSessionScope, "",
+			map[string]string{tt.name: tt.old},
+			map[string]string{tt.name: tt.new})
+
+		require.Len(t, diffs, 1, tt.name)
+		assert.Equal(t, Changed, diffs[0].Kind, tt.name)
+		assert.Equal(t, tt.attrs, diffs[0].Attributes, tt.name)
+	}
+}
@@ -0,0 +1,63 @@
+package diff
+
+import (
+	"math"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// Distance returns the CIE76 colour difference (delta E) between a and b,
+// computed in the CIELAB colour space. A distance around 2.3 is the
+// smallest difference most people notice. False is returned if either
+// colour depends on the terminal.
+func Distance(a, b theme.Colour) (float64, bool) {
+	l1, a1, b1, ok := lab(a)
+	if !ok {
+		return 0, false
+	}
+	l2, a2, b2, ok := lab(b)
+	if !ok {
+		return 0, false
+	}
+
+	return math.Sqrt(sq(l1-l2) + sq(a1-a2) + sq(b1-b2)), true
+}
+
+// lab converts a colour to CIELAB using the D65 white point.
+func lab(c theme.Colour) (float64, float64, float64, bool) {
+	r8, g8, b8, ok := c.RGB()
+	if !ok {
+		return 0, 0, 0, false
+	}
+
+	r, g, b := linear(r8), linear(g8), linear(b8)
+	x := (0.4124*r + 0.3576*g + 0.1805*b) / 0.95047
+	y := 0.2126*r + 0.7152*g + 0.0722*b
+	z := (0.0193*r + 0.1192*g + 0.9505*b) / 1.08883
+
+	fx, fy, fz := labF(x), labF(y), labF(z)
+
+	return 116*fy - 16, 500 * (fx - fy), 200 * (fy - fz), true
+}
+
+// linear converts an sRGB component to linear light.
+func linear(v uint8) float64 {
+	c := float64(v) / 255
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+func labF(t float64) float64 {
+	if t > 216.0/24389 {
+		return math.Cbrt(t)
+	}
+
+	return (24389.0/27*t + 16) / 116
+}
+
+func sq(v float64) float64 {
+	return v * v
+}
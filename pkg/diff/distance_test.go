@@ -0,0 +1,36 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistance(t *testing.T) {
+	var tests = []struct {
+		a, b string
+		want float64
+		ok   bool
+	}{
+		{"red", "red", 0, true},
+		{"#000000", "#ffffff", 100, true},
+		{"red", "colour160", 3.9, true},
+		{"#2e3440", "#3b4252", 6.7, true},
+		{"default", "red", 0, false},
+		{"red", "terminal", 0, false},
+	}
+
+	for _, tt := range tests {
+		a, err := theme.ParseColour(tt.a)
+		require.NoError(t, err)
+		b, err := theme.ParseColour(tt.b)
+		require.NoError(t, err)
+
+		got, ok := Distance(a, b)
+
+		assert.Equal(t, tt.ok, ok, "%s %s", tt.a, tt.b)
+		assert.InDelta(t, tt.want, got, 0.1, "%s %s", tt.a, tt.b)
+	}
+}
@@ -0,0 +1,34 @@
+package diff
+
+type Kind int
+
+const (
+	Added Kind = iota
+	Removed
+	Changed
+)
+
+func (s Kind) String() string {
+	switch s {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	}
+
+	return "unknown"
+}
+
+// Symbol returns the marker used for the kind in diff output.
+func (s Kind) Symbol() string {
+	switch s {
+	case Added:
+		return "+"
+	case Removed:
+		return "-"
+	}
+
+	return "~"
+}
@@ -0,0 +1,25 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKind(t *testing.T) {
+	var tests = []struct {
+		kind   Kind
+		str    string
+		symbol string
+	}{
+		{Added, "added", "+"},
+		{Removed, "removed", "-"},
+		{Changed, "changed", "~"},
+		{Kind(42), "unknown", "~"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.str, tt.kind.String())
+		assert.Equal(t, tt.symbol, tt.kind.Symbol())
+	}
+}
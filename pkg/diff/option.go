@@ -0,0 +1,150 @@
+package diff
+
+import (
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// Option is the difference of one option between two themes.
+type Option struct {
+	Scope  theme.Scope
+	Target string
+	Name   string
+	Kind   Kind
+	Old    string
+	New    string
+
+	// Attributes breaks down a changed style or colour option into the
+	// parts which changed.
+	Attributes []*Attribute
+}
+
+// Attribute is a changed part of a style or colour: a colour such as "fg",
+// "attrs" for the text attributes, or "align", "list" or "range".
+type Attribute struct {
+	Name string
+	Old  string
+	New  string
+
+	// Distance is the colour difference between Old and New, if
+	// HasDistance is set.
+	Distance    float64
+	HasDistance bool
+}
+
+// valueType returns how values of the named option are compared. Built-in
+// options use their declared type, user options are treated as styles or
+// colours when their name ends in "-style", or "-fg", "-bg" and "-colour".
+func valueType(name string) theme.OptionType {
+	if def, ok := theme.LookupOptionDefinition(name); ok {
+		return def.Type
+	}
+
+	if strings.HasPrefix(name, "@") {
+		switch {
+		case strings.HasSuffix(name, "-style"):
+			return theme.StyleOption
+		case strings.HasSuffix(name, "-fg"), strings.HasSuffix(name, "-bg"),
+			strings.HasSuffix(name, "-colour"), strings.HasSuffix(name, "-color"):
+			return theme.ColourOption
+		}
+	}
+
+	return theme.StringOption
+}
+
+// attributes compares two values of an option, returning false if they are
+// not styles or colours, or fail to parse.
+func attributes(name, old, new string) ([]*Attribute, bool) {
+	switch valueType(name) {
+	case theme.ColourOption:
+		a, err := theme.ParseColour(old)
+		if err != nil {
+			return nil, false
+		}
+		b, err := theme.ParseColour(new)
+		if err != nil {
+			return nil, false
+		}
+		return colourAttributes(nil, "colour", a, b), true
+	case theme.StyleOption:
+		a, err := theme.ParseStyle(old)
+		if err != nil {
+			return nil, false
+		}
+		b, err := theme.ParseStyle(new)
+		if err != nil {
+			return nil, false
+		}
+		return styleAttributes(a, b), true
+	}
+
+	return nil, false
+}
+
+func styleAttributes(a, b theme.Style) []*Attribute {
+	var attrs []*Attribute
+
+	attrs = colourAttributes(attrs, "fg", a.Fg, b.Fg)
+	attrs = colourAttributes(attrs, "bg", a.Bg, b.Bg)
+	attrs = colourAttributes(attrs, "us", a.Us, b.Us)
+	attrs = colourAttributes(attrs, "fill", a.Fill, b.Fill)
+
+	if a.Attrs != b.Attrs {
+		attrs = append(attrs, &Attribute{
+			Name: "attrs",
+			Old:  attrNames(a.Attrs),
+			New:  attrNames(b.Attrs),
+		})
+	}
+
+	strs := []struct {
+		name     string
+		old, new string
+	}{
+		{"align", a.Align, b.Align},
+		{"list", a.List, b.List},
+		{"range", a.Range, b.Range},
+	}
+	for _, s := range strs {
+		if s.old != s.new {
+			attrs = append(attrs, &Attribute{
+				Name: s.name, Old: orNone(s.old), New: orNone(s.new),
+			})
+		}
+	}
+
+	return attrs
+}
+
+func colourAttributes(
+	attrs []*Attribute,
+	name string,
+	a, b theme.Colour,
+) []*Attribute {
+	if a == b {
+		return attrs
+	}
+
+	attr := &Attribute{
+		Name: name,
+		Old:  orNone(a.String()),
+		New:  orNone(b.String()),
+	}
+	attr.Distance, attr.HasDistance = Distance(a, b)
+
+	return append(attrs, attr)
+}
+
+func attrNames(attrs theme.Attributes) string {
+	return orNone(strings.Join(attrs.Names(), ","))
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+
+	return s
+}
@@ -0,0 +1,29 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueType(t *testing.T) {
+	var tests = []struct {
+		name string
+		want theme.OptionType
+	}{
+		{"status-style", theme.StyleOption},
+		{"display-panes-colour", theme.ColourOption},
+		{"status-left", theme.StringOption},
+		{"@theme-status-style", theme.StyleOption},
+		{"@theme-status-bg", theme.ColourOption},
+		{"@theme-accent-colour", theme.ColourOption},
+		{"@theme-accent-color", theme.ColourOption},
+		{"@theme-separator", theme.StringOption},
+		{"unknown-fg", theme.StringOption},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, valueType(tt.name), tt.name)
+	}
+}
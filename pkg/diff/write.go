@@ -0,0 +1,65 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Write writes diffs in a human readable form, grouped under a heading for
+// each scope and target. Changed styles and colours are followed by the
+// attributes which changed, with colour distances where known.
+func Write(w io.Writer, diffs []*Option) error {
+	heading := ""
+	for _, d := range diffs {
+		h := d.Scope.String()
+		if d.Target != "" {
+			h += " " + d.Target
+		}
+		if h != heading {
+			if heading != "" {
+				if _, err := fmt.Fprintln(w); err != nil {
+					return err
+				}
+			}
+			if _, err := fmt.Fprintf(w, "%s:\n", h); err != nil {
+				return err
+			}
+			heading = h
+		}
+
+		if err := writeOption(w, d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeOption(w io.Writer, d *Option) error {
+	var err error
+	switch d.Kind {
+	case Added:
+		_, err = fmt.Fprintf(w, "  + %s %s\n", d.Name, strconv.Quote(d.New))
+	case Removed:
+		_, err = fmt.Fprintf(w, "  - %s %s\n", d.Name, strconv.Quote(d.Old))
+	default:
+		_, err = fmt.Fprintf(w, "  ~ %s %s -> %s\n", d.Name,
+			strconv.Quote(d.Old), strconv.Quote(d.New))
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, a := range d.Attributes {
+		line := fmt.Sprintf("      %s: %s -> %s", a.Name, a.Old, a.New)
+		if a.HasDistance {
+			line += fmt.Sprintf(" (distance %.1f)", a.Distance)
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
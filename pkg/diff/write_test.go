@@ -0,0 +1,45 @@
+package diff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	a := themetest.Execute(t, "", `
+set -g status-style "bg=red,fg=white"
+set -g status-left "#S"
+set -w -t main:1 window-status-format "#I"
+`)
+	b := themetest.Execute(t, "", `
+set -g status-style "bg=colour160,fg=white"
+set -g status-right "%H:%M"
+set -w -t main:1 window-status-format "#I:#W"
+`)
+
+	var buf bytes.Buffer
+	err := Write(&buf, Themes(a, b))
+	require.NoError(t, err)
+
+	assert.Equal(t, `global-session:
+  - status-left "#S"
+  + status-right "%H:%M"
+  ~ status-style "bg=red,fg=white" -> "bg=colour160,fg=white"
+      bg: red -> colour160 (distance 3.9)
+
+window main:1:
+  ~ window-status-format "#I" -> "#I:#W"
+`, buf.String())
+}
+
+func TestWriteEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "", buf.String())
+}
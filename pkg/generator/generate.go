@@ -0,0 +1,197 @@
+// Package generator builds .tmuxtheme files from declarative palette specs,
+// following the themepack layout of format options, theme options and an
+// apply block.
+package generator
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// applyStatements set tmux's options from the @theme-* options.
+var applyStatements = []struct {
+	option string
+	value  string
+}{
+	{"clock-mode-colour", "#{@theme-clock-mode-colour}"},
+	{"clock-mode-style", "#{@theme-clock-mode-style}"},
+	{"display-panes-active-colour", "#{@theme-display-panes-active-colour}"},
+	{"display-panes-colour", "#{@theme-display-panes-colour}"},
+	{"message-command-style", "bg=#{@theme-message-command-bg},fg=#{@theme-message-command-fg}"},
+	{"message-style", "bg=#{@theme-message-bg},fg=#{@theme-message-fg}"},
+	{"mode-style", "bg=#{@theme-mode-bg},fg=#{@theme-mode-fg}"},
+	{"pane-active-border-style", "bg=#{@theme-pane-active-border-bg},fg=#{@theme-pane-active-border-fg}"},
+	{"pane-border-style", "bg=#{@theme-pane-border-bg},fg=#{@theme-pane-border-fg}"},
+	{"status-interval", "#{@theme-status-interval}"},
+	{"status-justify", "#{@theme-status-justify}"},
+	{"status-left", "#{@theme-status-left}"},
+	{"status-left-length", "#{@theme-status-left-length}"},
+	{"status-left-style", "bg=#{@theme-status-left-bg},fg=#{@theme-status-left-fg}"},
+	{"status-right", "#{@theme-status-right}"},
+	{"status-right-length", "#{@theme-status-right-length}"},
+	{"status-right-style", "bg=#{@theme-status-right-bg},fg=#{@theme-status-right-fg}"},
+	{"status-style", "bg=#{@theme-status-bg},fg=#{@theme-status-fg}"},
+	{"window-status-activity-style", "bg=#{@theme-window-status-activity-bg},fg=#{@theme-window-status-activity-fg}"},
+	{"window-status-current-format", "#{@theme-window-status-current-format}"},
+	{"window-status-current-style", "bg=#{@theme-window-status-current-bg},fg=#{@theme-window-status-current-fg}"},
+	{"window-status-format", "#{@theme-window-status-format}"},
+	{"window-status-separator", "#{@theme-window-status-separator}"},
+}
+
+type option struct {
+	name   string
+	value  string
+	format bool
+}
+
+// Generate writes the .tmuxtheme text for spec.
+func Generate(w io.Writer, spec *Spec) error {
+	err := spec.Validate()
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+
+	writeHeader(&b, spec)
+
+	b.WriteString("# Themepack format options\n")
+	for _, o := range formatOptions(spec) {
+		flags := &theme.SetOptionFlags{
+			Global: true, OnlyIfUnset: true, Quiet: true,
+		}
+		fmt.Fprintf(&b, "set %s %s %s\n", flags, o.name, theme.Quote(o.value))
+	}
+
+	b.WriteString("\n# Theme options\n")
+	for _, o := range themeOptions(spec) {
+		flags := &theme.SetOptionFlags{
+			Global: true, OnlyIfUnset: true, Quiet: true, Format: o.format,
+		}
+		// Pad -goq to line up with -goqF.
+		fmt.Fprintf(&b, "set %-5s %s %s\n", flags, o.name, theme.Quote(o.value))
+	}
+
+	b.WriteString("\n# Apply theme options\n")
+	flags := &theme.SetOptionFlags{Global: true, Format: true}
+	for _, s := range applyStatements {
+		fmt.Fprintf(&b, "set %s %s %s\n", flags, s.option, theme.Quote(s.value))
+	}
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// Theme generates the theme for spec, and parses and executes it.
+func Theme(spec *Spec) (*theme.Theme, error) {
+	var b strings.Builder
+	err := Generate(&b, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	t := theme.New()
+	err = t.Parse(strings.NewReader(b.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func writeHeader(b *strings.Builder, spec *Spec) {
+	name := spec.Name
+	if name == "" {
+		name = "Generated"
+	}
+
+	fmt.Fprintf(b, "#\n# %s theme\n#\n\n", name)
+	if spec.Description != "" {
+		for _, line := range strings.Split(strings.TrimSpace(spec.Description), "\n") {
+			fmt.Fprintf(b, "# %s\n", strings.TrimSpace(line))
+		}
+		b.WriteString("\n")
+	}
+}
+
+func formatOptions(spec *Spec) []option {
+	f := spec.Layout.Formats
+
+	return []option{
+		{name: "@themepack-status-left-area-left-format", value: f.LeftAreaLeft},
+		{name: "@themepack-status-left-area-middle-format", value: f.LeftAreaMiddle},
+		{name: "@themepack-status-left-area-right-format", value: f.LeftAreaRight},
+		{name: "@themepack-status-right-area-left-format", value: f.RightAreaLeft},
+		{name: "@themepack-status-right-area-middle-format", value: f.RightAreaMiddle},
+		{name: "@themepack-status-right-area-right-format", value: f.RightAreaRight},
+		{name: "@themepack-window-status-current-format", value: f.WindowStatusCurrent},
+		{name: "@themepack-window-status-format", value: f.WindowStatus},
+	}
+}
+
+func themeOptions(spec *Spec) []option {
+	p := spec.colours()
+	l := spec.Layout
+
+	statusLeft := "#{@themepack-status-left-area-left-format}" +
+		" #[fg=" + p.Muted + "]" + l.LeftSeparator +
+		" #[fg=" + p.Accent + "]#{@themepack-status-left-area-middle-format}" +
+		" #[fg=" + p.Text + "]#{@themepack-status-left-area-right-format}"
+	statusRight := "#{@themepack-status-right-area-left-format}" +
+		" #[fg=" + p.Muted + "]" + l.RightSeparator +
+		" #[fg=" + p.Accent + "]#{@themepack-status-right-area-middle-format}" +
+		" #[fg=" + p.Text + "]#{@themepack-status-right-area-right-format}"
+
+	return []option{
+		{name: "@theme-clock-mode-colour", value: p.Accent},
+		{name: "@theme-clock-mode-style", value: l.ClockStyle},
+		{name: "@theme-display-panes-active-colour", value: p.Accent},
+		{name: "@theme-display-panes-colour", value: p.Muted},
+		{name: "@theme-message-bg", value: p.Surface},
+		{name: "@theme-message-command-bg", value: p.Surface},
+		{name: "@theme-message-command-fg", value: p.Accent},
+		{name: "@theme-message-fg", value: p.Text},
+		{name: "@theme-mode-bg", value: p.Accent},
+		{name: "@theme-mode-fg", value: p.Base},
+		{name: "@theme-pane-active-border-bg", value: "default"},
+		{name: "@theme-pane-active-border-fg", value: p.Accent},
+		{name: "@theme-pane-border-bg", value: "default"},
+		{name: "@theme-pane-border-fg", value: p.Muted},
+		{name: "@theme-status-bg", value: p.Base},
+		{name: "@theme-status-fg", value: p.Text},
+		{name: "@theme-status-interval", value: strconv.Itoa(l.StatusInterval)},
+		{name: "@theme-status-justify", value: l.Justify},
+		{name: "@theme-status-left", value: statusLeft, format: true},
+		{name: "@theme-status-left-bg", value: p.Surface},
+		{name: "@theme-status-left-fg", value: p.Accent},
+		{name: "@theme-status-left-length", value: strconv.Itoa(l.StatusLeftLength)},
+		{name: "@theme-status-right", value: statusRight, format: true},
+		{name: "@theme-status-right-bg", value: p.Surface},
+		{name: "@theme-status-right-fg", value: p.Text},
+		{name: "@theme-status-right-length", value: strconv.Itoa(l.StatusRightLength)},
+		{name: "@theme-window-status-activity-bg", value: p.Base},
+		{name: "@theme-window-status-activity-fg", value: p.Warning},
+		{name: "@theme-window-status-current-bg", value: p.Accent},
+		{name: "@theme-window-status-current-fg", value: p.Base},
+		{
+			name:   "@theme-window-status-current-format",
+			value:  " #{@themepack-window-status-current-format} ",
+			format: true,
+		},
+		{
+			name:   "@theme-window-status-format",
+			value:  " #{@themepack-window-status-format} ",
+			format: true,
+		},
+		{name: "@theme-window-status-separator", value: l.WindowSeparator},
+	}
+}
@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	spec, err := LoadSpec("testdata/nord.yml")
+	require.NoError(t, err)
+
+	var b strings.Builder
+	err = Generate(&b, spec)
+	require.NoError(t, err)
+
+	themetest.AssertGolden(t, "testdata/nord.tmuxtheme", b.String())
+}
+
+func TestGenerateInvalidSpec(t *testing.T) {
+	spec := NewSpec()
+	spec.Palette.Accent = "purplish"
+
+	var b strings.Builder
+	err := Generate(&b, spec)
+
+	assert.Equal(
+		t, &InvalidSpecError{Field: "palette.accent", Value: "purplish"}, err,
+	)
+	assert.Equal(t, "", b.String())
+}
+
+func TestTheme(t *testing.T) {
+	spec, err := LoadSpec("testdata/nord.yml")
+	require.NoError(t, err)
+
+	th, err := Theme(spec)
+	require.NoError(t, err)
+
+	var tests = []struct {
+		option string
+		value  string
+	}{
+		{"status-style", "bg=#2e3440,fg=#d8dee9"},
+		{"status-left-style", "bg=#3b4252,fg=#88c0d0"},
+		{
+			"status-left",
+			"#S #[fg=#4c566a]| #[fg=#88c0d0]#I #[fg=#d8dee9]#P",
+		},
+		{
+			"status-right",
+			"#H #[fg=#4c566a]| #[fg=#88c0d0]%H:%M:%S #[fg=#d8dee9]%Y-%m-%d",
+		},
+		{"status-justify", "left"},
+		{"window-status-current-style", "bg=#88c0d0,fg=#2e3440"},
+		{"window-status-activity-style", "bg=#2e3440,fg=#ebcb8b"},
+		{"window-status-current-format", " #I:#W#F "},
+		{"window-status-separator", ""},
+		{"pane-border-style", "bg=default,fg=#4c566a"},
+	}
+
+	for _, tt := range tests {
+		value, ok := th.GlobalSessionOptions[tt.option]
+		if !ok {
+			value = th.GlobalWindowOptions[tt.option]
+		}
+		assert.Equal(t, tt.value, value, tt.option)
+	}
+}
+
+func TestThemeDefaults(t *testing.T) {
+	th, err := Theme(NewSpec())
+	require.NoError(t, err)
+
+	assert.Equal(t, "bg=black,fg=white", th.GlobalSessionOptions["status-style"])
+	assert.Equal(
+		t, "bg=black,fg=blue", th.GlobalSessionOptions["status-left-style"],
+	)
+	assert.Equal(
+		t, "bg=default,fg=white", th.GlobalWindowOptions["pane-border-style"],
+	)
+	assert.Equal(t, "centre", th.GlobalSessionOptions["status-justify"])
+}
@@ -0,0 +1,12 @@
+package generator
+
+import "fmt"
+
+type InvalidSpecError struct {
+	Field string
+	Value string
+}
+
+func (s *InvalidSpecError) Error() string {
+	return fmt.Sprintf("Invalid spec value for %s: %q", s.Field, s.Value)
+}
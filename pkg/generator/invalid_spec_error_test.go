@@ -0,0 +1,19 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidSpecErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &InvalidSpecError{})
+}
+
+func TestInvalidSpecError(t *testing.T) {
+	err := &InvalidSpecError{Field: "palette.accent", Value: "purplish"}
+
+	assert.Equal(
+		t, `Invalid spec value for palette.accent: "purplish"`, err.Error(),
+	)
+}
@@ -0,0 +1,49 @@
+package generator
+
+// Layout holds the non-colour choices of a generated theme.
+type Layout struct {
+	Justify           string  `yaml:"justify" json:"justify"`
+	LeftSeparator     string  `yaml:"left-separator" json:"left-separator"`
+	RightSeparator    string  `yaml:"right-separator" json:"right-separator"`
+	WindowSeparator   string  `yaml:"window-separator" json:"window-separator"`
+	StatusLeftLength  int     `yaml:"status-left-length" json:"status-left-length"`
+	StatusRightLength int     `yaml:"status-right-length" json:"status-right-length"`
+	StatusInterval    int     `yaml:"status-interval" json:"status-interval"`
+	ClockStyle        string  `yaml:"clock-style" json:"clock-style"`
+	Formats           Formats `yaml:"formats" json:"formats"`
+}
+
+// Formats are the themepack format options, the content shown in each area
+// of the status line.
+type Formats struct {
+	LeftAreaLeft        string `yaml:"left-area-left" json:"left-area-left"`
+	LeftAreaMiddle      string `yaml:"left-area-middle" json:"left-area-middle"`
+	LeftAreaRight       string `yaml:"left-area-right" json:"left-area-right"`
+	RightAreaLeft       string `yaml:"right-area-left" json:"right-area-left"`
+	RightAreaMiddle     string `yaml:"right-area-middle" json:"right-area-middle"`
+	RightAreaRight      string `yaml:"right-area-right" json:"right-area-right"`
+	WindowStatusCurrent string `yaml:"window-status-current" json:"window-status-current"`
+	WindowStatus        string `yaml:"window-status" json:"window-status"`
+}
+
+func DefaultLayout() Layout {
+	return Layout{
+		Justify:           "centre",
+		LeftSeparator:     "»",
+		RightSeparator:    "«",
+		StatusLeftLength:  40,
+		StatusRightLength: 40,
+		StatusInterval:    1,
+		ClockStyle:        "24",
+		Formats: Formats{
+			LeftAreaLeft:        "#S",
+			LeftAreaMiddle:      "#I",
+			LeftAreaRight:       "#P",
+			RightAreaLeft:       "#H",
+			RightAreaMiddle:     "%H:%M:%S",
+			RightAreaRight:      "%d-%b-%y",
+			WindowStatusCurrent: "#I:#W#F",
+			WindowStatus:        "#I:#W#F",
+		},
+	}
+}
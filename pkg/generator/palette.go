@@ -0,0 +1,51 @@
+package generator
+
+import "github.com/jimeh/go-tmuxtheme/pkg/palette"
+
+// Palette holds the named colours a generated theme is built from. Colours
+// are any value tmux accepts, such as "colour39", "#1e1e2e" or "brightred".
+type Palette struct {
+	// Base is the status line background.
+	Base string `yaml:"base" json:"base"`
+	// Surface is the background of the status-left and status-right areas
+	// and of messages. Defaults to Base.
+	Surface string `yaml:"surface" json:"surface"`
+	// Text is the default foreground.
+	Text string `yaml:"text" json:"text"`
+	// Muted is used for separators, inactive borders and secondary text.
+	// Defaults to Text.
+	Muted string `yaml:"muted" json:"muted"`
+	// Accent highlights the current window, active border, clock and
+	// selections.
+	Accent string `yaml:"accent" json:"accent"`
+	// Warning is the activity style colour, which palette.Extract reports
+	// as the Warning role of the generated theme.
+	Warning string `yaml:"warning" json:"warning"`
+}
+
+func DefaultPalette() Palette {
+	return Palette{
+		Base:    palette.DefaultBackground.String(),
+		Text:    palette.DefaultForeground.String(),
+		Accent:  "blue",
+		Warning: palette.DefaultWarning.String(),
+	}
+}
+
+// colours returns the palette's fields by name, in declaration order.
+func (s *Palette) colours() []struct {
+	name  string
+	value *string
+} {
+	return []struct {
+		name  string
+		value *string
+	}{
+		{"base", &s.Base},
+		{"surface", &s.Surface},
+		{"text", &s.Text},
+		{"muted", &s.Muted},
+		{"accent", &s.Accent},
+		{"warning", &s.Warning},
+	}
+}
@@ -0,0 +1,140 @@
+package generator
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"gopkg.in/yaml.v2"
+)
+
+// Spec is a declarative description of a theme: a palette of named colours
+// and layout choices. Specs are written in YAML, or JSON.
+type Spec struct {
+	Name        string  `yaml:"name" json:"name"`
+	Description string  `yaml:"description" json:"description"`
+	Palette     Palette `yaml:"palette" json:"palette"`
+	Layout      Layout  `yaml:"layout" json:"layout"`
+}
+
+func NewSpec() *Spec {
+	return &Spec{
+		Palette: DefaultPalette(),
+		Layout:  DefaultLayout(),
+	}
+}
+
+// ParseSpec reads a spec on top of the default palette and layout, and
+// validates it.
+func ParseSpec(r io.Reader) (*Spec, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := NewSpec()
+	err = yaml.Unmarshal(b, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	err = spec.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
+
+func LoadSpec(filename string) (*Spec, error) {
+	r, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return ParseSpec(r)
+}
+
+// Validate checks that all colours and layout choices are values tmux
+// accepts.
+func (s *Spec) Validate() error {
+	for _, c := range s.Palette.colours() {
+		if *c.value == "" {
+			continue
+		}
+		if _, err := theme.ParseColour(*c.value); err != nil {
+			return &InvalidSpecError{Field: "palette." + c.name, Value: *c.value}
+		}
+	}
+
+	if !validChoice("status-justify", s.Layout.Justify) {
+		return &InvalidSpecError{Field: "layout.justify", Value: s.Layout.Justify}
+	}
+	if !validChoice("clock-mode-style", s.Layout.ClockStyle) {
+		return &InvalidSpecError{
+			Field: "layout.clock-style", Value: s.Layout.ClockStyle,
+		}
+	}
+
+	lengths := []struct {
+		field string
+		value int
+	}{
+		{"layout.status-left-length", s.Layout.StatusLeftLength},
+		{"layout.status-right-length", s.Layout.StatusRightLength},
+		{"layout.status-interval", s.Layout.StatusInterval},
+	}
+	for _, l := range lengths {
+		if l.value < 0 {
+			return &InvalidSpecError{Field: l.field, Value: strconv.Itoa(l.value)}
+		}
+	}
+
+	return nil
+}
+
+func validChoice(option, value string) bool {
+	def, ok := theme.LookupOptionDefinition(option)
+	if !ok {
+		return false
+	}
+
+	for _, choice := range def.Choices {
+		if choice == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// colours returns the palette with unset colours filled in from the colours
+// they default to.
+func (s *Spec) colours() Palette {
+	p := s.Palette
+	defaults := DefaultPalette()
+
+	if p.Base == "" {
+		p.Base = defaults.Base
+	}
+	if p.Text == "" {
+		p.Text = defaults.Text
+	}
+	if p.Accent == "" {
+		p.Accent = defaults.Accent
+	}
+	if p.Warning == "" {
+		p.Warning = defaults.Warning
+	}
+	if p.Surface == "" {
+		p.Surface = p.Base
+	}
+	if p.Muted == "" {
+		p.Muted = p.Text
+	}
+
+	return p
+}
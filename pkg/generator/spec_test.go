@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpec(t *testing.T) {
+	spec, err := ParseSpec(strings.NewReader(`
+name: Minimal
+palette:
+  accent: colour39
+layout:
+  status-left-length: 20
+  formats:
+    left-area-left: "#{session_name}"
+`))
+	require.NoError(t, err)
+
+	expected := NewSpec()
+	expected.Name = "Minimal"
+	expected.Palette.Accent = "colour39"
+	expected.Layout.StatusLeftLength = 20
+	expected.Layout.Formats.LeftAreaLeft = "#{session_name}"
+
+	assert.Equal(t, expected, spec)
+}
+
+func TestParseSpecJSON(t *testing.T) {
+	spec, err := ParseSpec(strings.NewReader(
+		`{"palette": {"base": "#000000"}, "layout": {"justify": "right"}}`,
+	))
+	require.NoError(t, err)
+
+	assert.Equal(t, "#000000", spec.Palette.Base)
+	assert.Equal(t, "right", spec.Layout.Justify)
+	assert.Equal(t, "white", spec.Palette.Text)
+}
+
+func TestParseSpecErrors(t *testing.T) {
+	var tests = []struct {
+		body string
+		err  error
+	}{
+		{
+			body: "palette: {warning: amber}",
+			err:  &InvalidSpecError{Field: "palette.warning", Value: "amber"},
+		},
+		{
+			body: "layout: {justify: middle}",
+			err:  &InvalidSpecError{Field: "layout.justify", Value: "middle"},
+		},
+		{
+			body: "layout: {clock-style: '13'}",
+			err:  &InvalidSpecError{Field: "layout.clock-style", Value: "13"},
+		},
+		{
+			body: "layout: {status-interval: -1}",
+			err: &InvalidSpecError{
+				Field: "layout.status-interval", Value: "-1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseSpec(strings.NewReader(tt.body))
+
+		assert.Equal(t, tt.err, err, tt.body)
+	}
+
+	_, err := ParseSpec(strings.NewReader("palette: ["))
+	assert.Error(t, err)
+}
+
+func TestLoadSpecMissingFile(t *testing.T) {
+	_, err := LoadSpec("testdata/missing.yml")
+
+	assert.Error(t, err)
+}
+
+func TestSpecColours(t *testing.T) {
+	spec := NewSpec()
+	spec.Palette = Palette{Base: "colour235", Text: "colour250"}
+
+	assert.Equal(t, Palette{
+		Base:    "colour235",
+		Surface: "colour235",
+		Text:    "colour250",
+		Muted:   "colour250",
+		Accent:  "blue",
+		Warning: "yellow",
+	}, spec.colours())
+}
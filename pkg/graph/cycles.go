@@ -0,0 +1,88 @@
+package graph
+
+import "sort"
+
+// Cycles returns the groups of options which reference each other in a
+// loop, each sorted by name. An option referencing itself is a cycle of
+// one. Draw-time references in a cycle never finish expanding, tmux gives
+// up after a fixed depth.
+func (s *Graph) Cycles() [][]string {
+	t := &tarjan{
+		graph: s,
+		index: map[string]int{},
+		low:   map[string]int{},
+		on:    map[string]bool{},
+	}
+
+	for _, name := range s.Names() {
+		if _, ok := t.index[name]; !ok {
+			t.connect(name)
+		}
+	}
+
+	sort.Slice(t.cycles, func(i, j int) bool {
+		return t.cycles[i][0] < t.cycles[j][0]
+	})
+
+	return t.cycles
+}
+
+// tarjan finds strongly connected components using Tarjan's algorithm.
+type tarjan struct {
+	graph  *Graph
+	next   int
+	index  map[string]int
+	low    map[string]int
+	on     map[string]bool
+	stack  []string
+	cycles [][]string
+}
+
+func (s *tarjan) connect(name string) {
+	s.index[name] = s.next
+	s.low[name] = s.next
+	s.next++
+	s.stack = append(s.stack, name)
+	s.on[name] = true
+
+	self := false
+	for _, ref := range s.graph.References(name) {
+		if ref == name {
+			self = true
+		}
+		if _, ok := s.index[ref]; !ok {
+			s.connect(ref)
+			s.low[name] = min(s.low[name], s.low[ref])
+		} else if s.on[ref] {
+			s.low[name] = min(s.low[name], s.index[ref])
+		}
+	}
+
+	if s.low[name] != s.index[name] {
+		return
+	}
+
+	component := []string{}
+	for {
+		n := s.stack[len(s.stack)-1]
+		s.stack = s.stack[:len(s.stack)-1]
+		s.on[n] = false
+		component = append(component, n)
+		if n == name {
+			break
+		}
+	}
+
+	if len(component) > 1 || self {
+		sort.Strings(component)
+		s.cycles = append(s.cycles, component)
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,44 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphCycles(t *testing.T) {
+	var tests = []struct {
+		name string
+		body string
+		want [][]string
+	}{
+		{
+			name: "none",
+			body: testTheme,
+		},
+		{
+			name: "self reference",
+			body: `set -g @a "#{@a}"`,
+			want: [][]string{{"@a"}},
+		},
+		{
+			name: "loops",
+			body: `
+set -g @a "#{@b}"
+set -g @b "#{@c}"
+set -g @c "#{@a} #{@d}"
+set -g @d "x"
+set -gF @x "#{@y}"
+set -g @y "#{?@z,#{@x},}"
+`,
+			want: [][]string{{"@a", "@b", "@c"}, {"@x", "@y"}},
+		},
+	}
+
+	for _, tt := range tests {
+		cycles := New(themetest.Parse(t, "test.tmuxtheme", tt.body)).Cycles()
+
+		assert.Equal(t, tt.want, cycles, tt.name)
+	}
+}
@@ -0,0 +1,46 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// WriteDOT writes the graph in Graphviz DOT format. References expanded when
+// tmux draws the option are dashed, options referenced but never set are
+// drawn dotted.
+func (s *Graph) WriteDOT(w io.Writer) error {
+	lines := []string{"digraph theme {", "  rankdir=LR;"}
+
+	for _, name := range s.Names() {
+		n := s.Nodes[name]
+		attrs := ""
+		if len(n.Set) == 0 {
+			attrs = " [style=dotted]"
+		}
+		lines = append(lines, fmt.Sprintf("  %s%s;", strconv.Quote(name), attrs))
+	}
+
+	for _, e := range s.Edges {
+		attrs := ""
+		if !e.Format {
+			attrs = " [style=dashed]"
+		}
+		lines = append(lines, fmt.Sprintf("  %s -> %s%s;",
+			strconv.Quote(e.From), strconv.Quote(e.To), attrs))
+	}
+
+	lines = append(lines, "}")
+
+	return writeLines(w, lines)
+}
+
+func writeLines(w io.Writer, lines []string) error {
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package graph
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphWriteDOT(t *testing.T) {
+	g := New(themetest.Parse(t, "test.tmuxtheme", `
+set -g @fg white
+set -gF status-style "fg=#{@fg}"
+set -g status-left "#{@missing}"
+`))
+
+	var buf bytes.Buffer
+	err := g.WriteDOT(&buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, `digraph theme {
+  rankdir=LR;
+  "@fg";
+  "@missing" [style=dotted];
+  "status-left";
+  "status-style";
+  "status-style" -> "@fg";
+  "status-left" -> "@missing" [style=dashed];
+}
+`, buf.String())
+}
@@ -0,0 +1,157 @@
+// Package graph analyzes how theme options reference each other through
+// #{...} formats.
+package graph
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// Graph is the reference graph of a theme's options. Options are identified
+// by name alone, since tmux resolves #{name} through whichever scope sets
+// it.
+type Graph struct {
+	Nodes map[string]*Node
+	Edges []*Edge
+}
+
+// Node is an option which is set by the theme, references other options, or
+// is referenced.
+type Node struct {
+	Name string
+
+	// Set holds the positions of statements setting the option.
+	Set []theme.Position
+}
+
+// IsBuiltIn reports whether the node is one of tmux's own options, which
+// always have a value.
+func (s *Node) IsBuiltIn() bool {
+	_, ok := theme.LookupOptionDefinition(s.Name)
+
+	return ok
+}
+
+// Edge is a reference from the value of option From to option To.
+type Edge struct {
+	From     string
+	To       string
+	Position theme.Position
+
+	// Format is set for references in -F values, which are expanded when
+	// the option is set rather than when tmux draws it.
+	Format bool
+}
+
+// New builds the reference graph from the set-option statements of t. The
+// theme does not need to be executed.
+func New(t *theme.Theme) *Graph {
+	g := &Graph{Nodes: map[string]*Node{}, Edges: []*Edge{}}
+	seen := map[Edge]bool{}
+
+	for i, st := range t.Statements {
+		set, ok := st.(*theme.SetOptionStatement)
+		if !ok || set.Flags.Unset {
+			continue
+		}
+
+		pos := t.Position(i)
+		from := g.node(set.Option)
+		from.Set = append(from.Set, pos)
+
+		for _, name := range theme.FormatReferences(set.Value) {
+			g.node(name)
+
+			key := Edge{From: set.Option, To: name, Format: set.Flags.Format}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			e := key
+			e.Position = pos
+			g.Edges = append(g.Edges, &e)
+		}
+	}
+
+	return g
+}
+
+func (s *Graph) node(name string) *Node {
+	n, ok := s.Nodes[name]
+	if !ok {
+		n = &Node{Name: name, Set: []theme.Position{}}
+		s.Nodes[name] = n
+	}
+
+	return n
+}
+
+// Names returns the names of all nodes, sorted.
+func (s *Graph) Names() []string {
+	names := make([]string, 0, len(s.Nodes))
+	for name := range s.Nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// References returns the names of the options name references, sorted.
+func (s *Graph) References(name string) []string {
+	names := []string{}
+	for _, e := range s.Edges {
+		if e.From == name && !contains(names, e.To) {
+			names = append(names, e.To)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Undefined returns the references to user options which are never set, and
+// so expand to an empty string.
+func (s *Graph) Undefined() []*Edge {
+	edges := []*Edge{}
+	for _, e := range s.Edges {
+		n := s.Nodes[e.To]
+		if len(n.Set) == 0 && !n.IsBuiltIn() {
+			edges = append(edges, e)
+		}
+	}
+
+	return edges
+}
+
+// Unused returns the nodes for options with the given name prefix, like
+// "@theme-", which are set but never referenced.
+func (s *Graph) Unused(prefix string) []*Node {
+	used := map[string]bool{}
+	for _, e := range s.Edges {
+		used[e.To] = true
+	}
+
+	nodes := []*Node{}
+	for _, name := range s.Names() {
+		n := s.Nodes[name]
+		if strings.HasPrefix(name, prefix) && len(n.Set) > 0 && !used[name] {
+			nodes = append(nodes, n)
+		}
+	}
+
+	return nodes
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,89 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTheme = `
+set -g @theme-fg white
+set -g @theme-bg black
+set -g @theme-unused red
+set -gF status-style "fg=#{@theme-fg},bg=#{@theme-bg}"
+set -gF status-style "fg=#{@theme-fg},bg=#{@theme-bg},bold"
+set -g status-left "#{?client_prefix,#{@theme-prefix},#S}"
+set -g status-right "#{status-left} #{session_name}"
+set -gu @theme-removed
+`
+
+func TestNew(t *testing.T) {
+	g := New(themetest.Parse(t, "test.tmuxtheme", testTheme))
+
+	assert.Equal(t, []string{
+		"@theme-bg", "@theme-fg", "@theme-prefix", "@theme-unused",
+		"status-left", "status-right", "status-style",
+	}, g.Names())
+
+	assert.Equal(t, []theme.Position{
+		{Filename: "test.tmuxtheme", Line: 4, EndLine: 4},
+		{Filename: "test.tmuxtheme", Line: 5, EndLine: 5},
+	}, g.Nodes["status-style"].Set)
+	assert.Empty(t, g.Nodes["@theme-prefix"].Set)
+
+	assert.Equal(t, []*Edge{
+		{
+			From: "status-style", To: "@theme-bg", Format: true,
+			Position: theme.Position{Filename: "test.tmuxtheme", Line: 4,
+				EndLine: 4},
+		},
+		{
+			From: "status-style", To: "@theme-fg", Format: true,
+			Position: theme.Position{Filename: "test.tmuxtheme", Line: 4,
+				EndLine: 4},
+		},
+		{
+			From: "status-left", To: "@theme-prefix",
+			Position: theme.Position{Filename: "test.tmuxtheme", Line: 6,
+				EndLine: 6},
+		},
+		{
+			From: "status-right", To: "status-left",
+			Position: theme.Position{Filename: "test.tmuxtheme", Line: 7,
+				EndLine: 7},
+		},
+	}, g.Edges)
+
+	assert.Equal(t, []string{"@theme-bg", "@theme-fg"},
+		g.References("status-style"))
+}
+
+func TestGraphUndefined(t *testing.T) {
+	g := New(themetest.Parse(t, "test.tmuxtheme", testTheme+`
+set -g status-left-style "#{status-left-length}"
+`))
+
+	undefined := g.Undefined()
+
+	require.Len(t, undefined, 1)
+	assert.Equal(t, "status-left", undefined[0].From)
+	assert.Equal(t, "@theme-prefix", undefined[0].To)
+}
+
+func TestGraphUnused(t *testing.T) {
+	g := New(themetest.Parse(t, "test.tmuxtheme", testTheme))
+
+	unused := g.Unused("@theme-")
+
+	require.Len(t, unused, 1)
+	assert.Equal(t, "@theme-unused", unused[0].Name)
+	assert.Len(t, g.Unused(""), 3)
+}
+
+func TestNodeIsBuiltIn(t *testing.T) {
+	assert.True(t, (&Node{Name: "status-left"}).IsBuiltIn())
+	assert.False(t, (&Node{Name: "@theme-fg"}).IsBuiltIn())
+}
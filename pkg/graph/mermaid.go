@@ -0,0 +1,36 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteMermaid writes the graph as a Mermaid flowchart. References expanded
+// when tmux draws the option are dotted arrows, options referenced but never
+// set have rounded ends.
+func (s *Graph) WriteMermaid(w io.Writer) error {
+	lines := []string{"graph LR"}
+	ids := map[string]string{}
+
+	for i, name := range s.Names() {
+		ids[name] = fmt.Sprintf("n%d", i)
+		shape := `%s["%s"]`
+		if len(s.Nodes[name].Set) == 0 {
+			shape = `%s(["%s"])`
+		}
+		lines = append(lines, "  "+fmt.Sprintf(shape, ids[name],
+			strings.Replace(name, `"`, "#quot;", -1)))
+	}
+
+	for _, e := range s.Edges {
+		arrow := "-.->"
+		if e.Format {
+			arrow = "-->"
+		}
+		lines = append(lines,
+			fmt.Sprintf("  %s %s %s", ids[e.From], arrow, ids[e.To]))
+	}
+
+	return writeLines(w, lines)
+}
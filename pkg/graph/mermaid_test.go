@@ -0,0 +1,31 @@
+package graph
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphWriteMermaid(t *testing.T) {
+	g := New(themetest.Parse(t, "test.tmuxtheme", `
+set -g @fg white
+set -gF status-style "fg=#{@fg}"
+set -g status-left "#{@missing}"
+`))
+
+	var buf bytes.Buffer
+	err := g.WriteMermaid(&buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, `graph LR
+  n0["@fg"]
+  n1(["@missing"])
+  n2["status-left"]
+  n3["status-style"]
+  n3 --> n0
+  n2 -.-> n1
+`, buf.String())
+}
@@ -0,0 +1,93 @@
+package lint
+
+import (
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// CommandSpellingRule finds set-option statements spelling the command
+// differently from the rest of the file, like set among set-option.
+type CommandSpellingRule struct{}
+
+func (s *CommandSpellingRule) Name() string { return "command-spelling" }
+
+func (s *CommandSpellingRule) Description() string {
+	return "Command is spelled differently from the rest of the file"
+}
+
+func (s *CommandSpellingRule) Severity() Severity { return Info }
+
+func (s *CommandSpellingRule) Check(f *File) []*Diagnostic {
+	counts := map[string]int{}
+	order := []string{}
+	spellings := map[int]string{}
+
+	f.SetOptions(func(i int, st *theme.SetOptionStatement) {
+		cmd := commandName(f.Theme, i)
+		if cmd == "" {
+			return
+		}
+		if counts[cmd] == 0 {
+			order = append(order, cmd)
+		}
+		counts[cmd]++
+		spellings[i] = cmd
+	})
+
+	common := ""
+	for _, cmd := range order {
+		if counts[cmd] > counts[common] {
+			common = cmd
+		}
+	}
+
+	diags := []*Diagnostic{}
+	for i := range f.Theme.Statements {
+		if cmd, ok := spellings[i]; ok && cmd != common {
+			d := f.Diagnostic(i,
+				"%s is used here, while the rest of the file uses %s",
+				cmd, common)
+			d.Fix = f.rewriteFix(i, "Use "+common,
+				func(c *commandLine) (string, bool) {
+					return respell(c, common)
+				})
+			diags = append(diags, d)
+		}
+	}
+
+	return diags
+}
+
+// respell returns the command line using cmd, moving the window scope
+// between set-window-option and the -w flag.
+func respell(c *commandLine, cmd string) (string, bool) {
+	switch {
+	case c.word(0) == "set-window-option":
+		return c.replaceWord(0, cmd+" -w"), true
+	case cmd == "set-window-option":
+		line, ok := c.removeFlag('w')
+		if !ok {
+			return "", false
+		}
+		return parseCommandLine(line).replaceWord(0, cmd), true
+	}
+
+	return c.replaceWord(0, cmd), true
+}
+
+// commandName returns the command as written in the source of the
+// statement at index i.
+func commandName(t *theme.Theme, i int) string {
+	text := t.Text(i)
+	if len(text) == 0 {
+		return ""
+	}
+
+	fields := strings.Fields(text[0])
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return fields[0]
+}
@@ -0,0 +1,61 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandSpellingRule(t *testing.T) {
+	got := check(t, &CommandSpellingRule{}, `
+set-option -g @a 1
+set -g @b 2
+set-option -g @c 3
+set-window-option -g @d 4
+`)
+
+	assert.Equal(t, []string{
+		"2: set is used here, while the rest of the file uses set-option",
+		"4: set-window-option is used here, while the rest of the file " +
+			"uses set-option",
+	}, got)
+}
+
+func TestCommandSpellingRuleConsistent(t *testing.T) {
+	got := check(t, &CommandSpellingRule{}, `
+  set -g @a 1
+set -g @b 2
+`)
+
+	assert.Empty(t, got)
+}
+
+func TestCommandSpellingRuleFix(t *testing.T) {
+	got := fix(t, &CommandSpellingRule{}, `
+set -g @a 1
+set -g @b 2
+set-option -g @c 3
+set-window-option -g @d 4
+`)
+
+	assert.Equal(t, `set -g @a 1
+set -g @b 2
+set -g @c 3
+set -w -g @d 4`, got)
+
+	got = fix(t, &CommandSpellingRule{}, `
+set-window-option -g @a 1
+set-window-option -g @b 2
+set-window-option -g @f 6
+set -wg @c 3
+set -w -g @d 4
+set -g @e 5
+`)
+
+	assert.Equal(t, `set-window-option -g @a 1
+set-window-option -g @b 2
+set-window-option -g @f 6
+set-window-option -g @c 3
+set-window-option -g @d 4
+set -g @e 5`, got)
+}
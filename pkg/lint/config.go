@@ -0,0 +1,76 @@
+package lint
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigFilename is the name of the config file looked for next to themes.
+const ConfigFilename = ".tmuxtheme-lint.yml"
+
+// Config adjusts which rules run and how severe their findings are.
+//
+//	palette-prefix: "@theme-"
+//	rules:
+//	  useless-format: off
+//	  missing-global: error
+type Config struct {
+	// Rules overrides the default severity of rules by name, off disables
+	// a rule.
+	Rules map[string]Severity `yaml:"rules"`
+
+	// PalettePrefix is the name prefix of the user options holding the
+	// theme's colours.
+	PalettePrefix string `yaml:"palette-prefix"`
+}
+
+func DefaultConfig() *Config {
+	return &Config{Rules: map[string]Severity{}, PalettePrefix: "@theme-"}
+}
+
+// ParseConfig reads a YAML config, filling in defaults for anything not
+// set.
+func ParseConfig(r io.Reader) (*Config, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	config := DefaultConfig()
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, err
+	}
+	if config.Rules == nil {
+		config.Rules = map[string]Severity{}
+	}
+
+	for name := range config.Rules {
+		if _, ok := LookupRule(name); !ok {
+			return nil, &UnknownRuleError{Rule: name}
+		}
+	}
+
+	return config, nil
+}
+
+func LoadConfig(filename string) (*Config, error) {
+	r, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return ParseConfig(r)
+}
+
+// Severity returns the severity findings of rule are reported with.
+func (s *Config) Severity(rule Rule) Severity {
+	if severity, ok := s.Rules[rule.Name()]; ok {
+		return severity
+	}
+
+	return rule.Severity()
+}
@@ -0,0 +1,66 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfig(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(`
+palette-prefix: "@themepack-"
+rules:
+  useless-format: off
+  missing-global: error
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, &Config{
+		PalettePrefix: "@themepack-",
+		Rules: map[string]Severity{
+			"useless-format": Off,
+			"missing-global": Error,
+		},
+	}, config)
+
+	assert.Equal(t, Off, config.Severity(&UselessFormatRule{}))
+	assert.Equal(t, Error, config.Severity(&MissingGlobalRule{}))
+	assert.Equal(t, Warning, config.Severity(&DeadSetRule{}))
+}
+
+func TestParseConfigDefaults(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(""))
+	require.NoError(t, err)
+
+	assert.Equal(t, DefaultConfig(), config)
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	var tests = []struct {
+		body string
+		err  error
+	}{
+		{"rules:\n  nope: error\n", &UnknownRuleError{Rule: "nope"}},
+		{
+			"rules:\n  dead-set: fatal\n",
+			&InvalidSeverityError{Value: "fatal"},
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseConfig(strings.NewReader(tt.body))
+
+		assert.Equal(t, tt.err, err, tt.body)
+	}
+
+	_, err := ParseConfig(strings.NewReader("unknown-key: 1\n"))
+	assert.Error(t, err)
+}
+
+func TestLoadConfigMissing(t *testing.T) {
+	_, err := LoadConfig("testdata/missing.yml")
+
+	assert.Error(t, err)
+}
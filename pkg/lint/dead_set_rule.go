@@ -0,0 +1,73 @@
+package lint
+
+import "github.com/jimeh/go-tmuxtheme/pkg/theme"
+
+// DeadSetRule finds options set and then set again before anything reads
+// the first value.
+type DeadSetRule struct{}
+
+func (s *DeadSetRule) Name() string { return "dead-set" }
+
+func (s *DeadSetRule) Description() string {
+	return "Option is set again before its value is used"
+}
+
+func (s *DeadSetRule) Severity() Severity { return Warning }
+
+func (s *DeadSetRule) Check(f *File) []*Diagnostic {
+	diags := []*Diagnostic{}
+
+	f.SetOptions(func(i int, st *theme.SetOptionStatement) {
+		if st.Flags.Unset {
+			return
+		}
+		if j := overwrittenBy(f.Theme, i, st); j >= 0 {
+			d := f.Diagnostic(i,
+				"%s is set again on line %d before this value is used",
+				st.Option, f.Theme.Position(j).Line)
+			d.Fix = f.deleteFix(i, "Remove the dead statement")
+			diags = append(diags, d)
+		}
+	})
+
+	return diags
+}
+
+// overwrittenBy returns the index of the statement replacing or unsetting
+// the value set by the statement at index i, or -1 if the value is appended
+// to or read by a -F value first, or never replaced.
+func overwrittenBy(t *theme.Theme, i int, st *theme.SetOptionStatement) int {
+	for j := i + 1; j < len(t.Statements); j++ {
+		next, ok := t.Statements[j].(*theme.SetOptionStatement)
+		if !ok {
+			continue
+		}
+
+		if next.Flags.Format && !next.Flags.Unset {
+			for _, ref := range theme.FormatReferences(next.Value) {
+				if ref == st.Option {
+					return -1
+				}
+			}
+		}
+
+		if !sameOption(st, next) {
+			continue
+		}
+		switch {
+		case next.Flags.Append:
+			return -1
+		case next.Flags.OnlyIfUnset && !next.Flags.Unset:
+			continue
+		}
+
+		return j
+	}
+
+	return -1
+}
+
+func sameOption(a, b *theme.SetOptionStatement) bool {
+	return a.Option == b.Option && a.Scope() == b.Scope() &&
+		a.Target() == b.Target()
+}
@@ -0,0 +1,44 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadSetRule(t *testing.T) {
+	got := check(t, &DeadSetRule{}, `
+set -g status-style "bg=red"
+set -g status-style "bg=blue"
+set -g @fg white
+set -gF status-left-style "fg=#{@fg}"
+set -g @fg black
+set -g @sep "|"
+set -ga @sep " "
+set -g @sep ">"
+set -g @kept x
+set -go @kept y
+set -w -t main:1 @kept z
+set -g @gone x
+set -gu @gone
+`)
+
+	assert.Equal(t, []string{
+		"1: status-style is set again on line 2 before this value is used",
+		"7: @sep is set again on line 8 before this value is used",
+		"12: @gone is set again on line 13 before this value is used",
+	}, got)
+}
+
+func TestDeadSetRuleFix(t *testing.T) {
+	got := fix(t, &DeadSetRule{}, `
+# Status bar
+set -g status-style "bg=red"
+set -g status-style \\
+  "bg=blue"
+set -g status-style "bg=green" # final
+`)
+
+	assert.Equal(t, "# Status bar\n"+
+		`set -g status-style "bg=green" # final`, got)
+}
@@ -0,0 +1,105 @@
+package lint
+
+import (
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/kballard/go-shellquote"
+)
+
+// deprecatedStyleOptions are the options whose -fg, -bg and -attr variants
+// tmux 2.9 replaced with a single -style option.
+var deprecatedStyleOptions = []string{
+	"message", "message-command", "mode", "pane-active-border",
+	"pane-border", "status", "status-left", "status-right",
+	"window-status", "window-status-activity", "window-status-bell",
+	"window-status-current", "window-status-last",
+}
+
+// DeprecatedOptionRule finds the -fg, -bg and -attr options removed in tmux
+// 2.9, which later versions reject.
+type DeprecatedOptionRule struct{}
+
+func (s *DeprecatedOptionRule) Name() string { return "deprecated-option" }
+
+func (s *DeprecatedOptionRule) Description() string {
+	return "Option was removed from tmux in favour of a style option"
+}
+
+func (s *DeprecatedOptionRule) Severity() Severity { return Warning }
+
+func (s *DeprecatedOptionRule) Check(f *File) []*Diagnostic {
+	diags := []*Diagnostic{}
+
+	f.SetOptions(func(i int, st *theme.SetOptionStatement) {
+		style, part, ok := deprecatedOption(st.Option)
+		if !ok {
+			return
+		}
+
+		d := f.Diagnostic(i, "%s was removed in tmux 2.9, use %s instead",
+			st.Option, style)
+		if !st.Flags.Unset && len(f.Theme.Text(i)) == 1 {
+			d.Fix = f.rewriteFix(i, "Append to "+style,
+				func(c *commandLine) (string, bool) {
+					return migrateStyle(c, st, style, part+st.Value)
+				})
+		}
+		diags = append(diags, d)
+	})
+
+	return diags
+}
+
+// deprecatedOption returns the style option replacing name and the style
+// prefix its value maps to.
+func deprecatedOption(name string) (string, string, bool) {
+	for _, base := range deprecatedStyleOptions {
+		switch {
+		case name == base+"-fg" && base != "status":
+			return base + "-style", "fg=", true
+		case name == base+"-bg" && base != "status":
+			return base + "-style", "bg=", true
+		case name == base+"-attr":
+			return base + "-style", "", true
+		}
+	}
+
+	return "", "", false
+}
+
+// migrateStyle rewrites a single line statement to append value to the
+// style option instead. Lines with anything after the value, like a
+// comment, are left alone.
+func migrateStyle(
+	c *commandLine,
+	st *theme.SetOptionStatement,
+	style, value string,
+) (string, bool) {
+	option := -1
+	for i := 1; i < len(c.words); i++ {
+		if c.word(i) == st.Option {
+			option = i
+			break
+		}
+	}
+	if option < 0 {
+		return "", false
+	}
+
+	w := c.words[option]
+	if rest, err := shellquote.Split(c.line[w.end:]); err != nil ||
+		len(rest) > 1 {
+		return "", false
+	}
+
+	line := c.line[:w.start] + style + " " + theme.Quote(","+value)
+
+	if len(c.flags) == 0 {
+		return parseCommandLine(line).insertAfter(0, " -a"), true
+	}
+	if !st.Flags.Append {
+		first := c.words[c.flags[0]].start
+		line = line[:first+1] + "a" + line[first+1:]
+	}
+
+	return line, true
+}
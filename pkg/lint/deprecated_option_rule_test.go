@@ -0,0 +1,43 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecatedOptionRule(t *testing.T) {
+	got := check(t, &DeprecatedOptionRule{}, `
+set -g status-fg red
+set -g status-left-fg red
+set -g window-status-current-bg blue
+set -g message-attr bold
+set -g status-attr bright
+set -g status-style "bg=red"
+`)
+
+	assert.Equal(t, []string{
+		"2: status-left-fg was removed in tmux 2.9, use status-left-style " +
+			"instead",
+		"3: window-status-current-bg was removed in tmux 2.9, use " +
+			"window-status-current-style instead",
+		"4: message-attr was removed in tmux 2.9, use message-style instead",
+		"5: status-attr was removed in tmux 2.9, use status-style instead",
+	}, got)
+}
+
+func TestDeprecatedOptionRuleFix(t *testing.T) {
+	got := fix(t, &DeprecatedOptionRule{}, `
+set -g window-status-current-bg blue
+set-option -ga message-attr "bold,italics"
+set pane-border-fg red
+set -g status-left-fg red # keep
+set -gu mode-fg
+`)
+
+	assert.Equal(t, `set -ag window-status-current-style ,bg=blue
+set-option -ga message-style ,bold,italics
+set -a pane-border-style ,fg=red
+set -g status-left-fg red # keep
+set -gu mode-fg`, got)
+}
@@ -0,0 +1,31 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// Diagnostic is a problem found by a rule in one statement.
+type Diagnostic struct {
+	Rule     string
+	Severity Severity
+	Message  string
+	Position theme.Position
+
+	// Statement is the index of the statement in the linted theme.
+	Statement int
+
+	// Fix, when set, resolves the diagnostic.
+	Fix *Fix
+}
+
+func (s *Diagnostic) String() string {
+	fixable := ""
+	if s.Fix != nil {
+		fixable = " (fixable)"
+	}
+
+	return fmt.Sprintf("%s: %s: %s [%s]%s",
+		s.Position, s.Severity, s.Message, s.Rule, fixable)
+}
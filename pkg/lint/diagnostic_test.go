@@ -0,0 +1,25 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagnosticString(t *testing.T) {
+	d := &Diagnostic{
+		Rule:     "dead-set",
+		Severity: Warning,
+		Message:  "@a is set again",
+		Position: theme.Position{Filename: "a.tmuxtheme", Line: 3, EndLine: 3},
+	}
+
+	assert.Equal(t, "a.tmuxtheme:3: warning: @a is set again [dead-set]",
+		d.String())
+
+	d.Fix = &Fix{Description: "Remove the dead statement"}
+	assert.Equal(t,
+		"a.tmuxtheme:3: warning: @a is set again [dead-set] (fixable)",
+		d.String())
+}
@@ -0,0 +1,55 @@
+package lint
+
+import (
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+const ignoreDirective = "tmuxtheme:ignore"
+
+// Ignored reports whether findings of rule for the statement at index i are
+// suppressed by a directive in the comments directly above it:
+//
+//	# tmuxtheme:ignore dead-set useless-format
+//
+// Rules can be separated by spaces or commas, a directive naming no rules
+// ignores all of them.
+func Ignored(t *theme.Theme, i int, rule string) bool {
+	for j := i - 1; j >= 0; j-- {
+		c, ok := t.Statements[j].(*theme.CommentStatement)
+		if !ok {
+			return false
+		}
+
+		rules, ok := parseIgnore(c.Msg)
+		if !ok {
+			continue
+		}
+		if len(rules) == 0 {
+			return true
+		}
+		for _, r := range rules {
+			if r == rule {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func parseIgnore(msg string) ([]string, bool) {
+	if !strings.HasPrefix(msg, ignoreDirective) {
+		return nil, false
+	}
+
+	rest := msg[len(ignoreDirective):]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return nil, false
+	}
+
+	return strings.FieldsFunc(rest, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == ','
+	}), true
+}
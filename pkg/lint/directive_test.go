@@ -0,0 +1,43 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIgnored(t *testing.T) {
+	th := themetest.Parse(t, "test.tmuxtheme", `
+# tmuxtheme:ignore dead-set, useless-format
+# Some explanation.
+set -g @a 1
+# tmuxtheme:ignore
+set -g @b 2
+# tmuxtheme:ignored dead-set
+set -g @c 3
+# tmuxtheme:ignore dead-set
+
+set -g @d 4
+set -g @e 5
+`)
+
+	var tests = []struct {
+		index int
+		rule  string
+		want  bool
+	}{
+		{2, "dead-set", true},
+		{2, "useless-format", true},
+		{2, "missing-global", false},
+		{4, "anything", true},
+		{6, "dead-set", false},
+		{9, "dead-set", false},
+		{10, "dead-set", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Ignored(th, tt.index, tt.rule),
+			"%d %s", tt.index, tt.rule)
+	}
+}
@@ -0,0 +1,194 @@
+package lint
+
+import (
+	"sort"
+	"strings"
+)
+
+// Fix is a machine-applicable change resolving a diagnostic.
+type Fix struct {
+	Description string  `json:"description"`
+	Edits       []*Edit `json:"edits"`
+}
+
+// Edit replaces source lines Line through EndLine with Lines, which may be
+// empty to delete them.
+type Edit struct {
+	Line    int      `json:"line"`
+	EndLine int      `json:"end_line"`
+	Lines   []string `json:"lines"`
+}
+
+// ApplyFixes returns lines with the fixes of diags applied, and how many
+// were applied. Fixes overlapping one applied before them are skipped, so
+// linting and fixing again may find more to do.
+//
+// Fixes are edits of the source lines rather than of statements written
+// back with Theme.Write, which would also rewrite every line the fixes
+// leave alone: joining continued lines, dropping the padding after flags
+// and requoting values. Only the lines a fix touches change.
+func ApplyFixes(lines []string, diags []*Diagnostic) ([]string, int) {
+	edits := []*Edit{}
+	applied := 0
+
+	for _, d := range diags {
+		if d.Fix == nil || overlaps(edits, d.Fix.Edits) {
+			continue
+		}
+		edits = append(edits, d.Fix.Edits...)
+		applied++
+	}
+
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].Line > edits[j].Line
+	})
+
+	out := append([]string{}, lines...)
+	for _, e := range edits {
+		if e.Line < 1 || e.EndLine > len(out) {
+			continue
+		}
+		rest := append(append([]string{}, e.Lines...), out[e.EndLine:]...)
+		out = append(out[:e.Line-1], rest...)
+	}
+
+	return out, applied
+}
+
+func overlaps(edits []*Edit, others []*Edit) bool {
+	for _, a := range edits {
+		for _, b := range others {
+			if a.Line <= b.EndLine && b.Line <= a.EndLine {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// deleteFix returns a fix removing the statement at index i.
+func (s *File) deleteFix(i int, description string) *Fix {
+	pos := s.Theme.Position(i)
+	if s.Theme.Text(i) == nil {
+		return nil
+	}
+
+	return &Fix{
+		Description: description,
+		Edits: []*Edit{
+			{Line: pos.Line, EndLine: pos.EndLine, Lines: []string{}},
+		},
+	}
+}
+
+// rewriteFix returns a fix replacing the first line of the statement at
+// index i with the result of fn, or nil if fn cannot rewrite it.
+func (s *File) rewriteFix(
+	i int,
+	description string,
+	fn func(c *commandLine) (string, bool),
+) *Fix {
+	pos := s.Theme.Position(i)
+	text := s.Theme.Text(i)
+	if len(text) == 0 {
+		return nil
+	}
+
+	line, ok := fn(parseCommandLine(text[0]))
+	if !ok {
+		return nil
+	}
+
+	return &Fix{
+		Description: description,
+		Edits: []*Edit{
+			{Line: pos.Line, EndLine: pos.Line, Lines: []string{line}},
+		},
+	}
+}
+
+// commandLine splits the first line of a set-option statement into words,
+// keeping their offsets so the command and flags can be edited in place.
+type commandLine struct {
+	line  string
+	words []lineWord
+
+	// flags holds the indexes of words which are flags, excluding the
+	// argument to -t.
+	flags []int
+}
+
+type lineWord struct {
+	start int
+	end   int
+}
+
+func parseCommandLine(line string) *commandLine {
+	c := &commandLine{line: line}
+
+	start := -1
+	for i := 0; i <= len(line); i++ {
+		space := i == len(line) || line[i] == ' ' || line[i] == '\t'
+		if !space && start < 0 {
+			start = i
+		} else if space && start >= 0 {
+			c.words = append(c.words, lineWord{start, i})
+			start = -1
+		}
+	}
+
+	for i := 1; i < len(c.words); i++ {
+		w := c.word(i)
+		if w == "--" || len(w) < 2 || w[0] != '-' {
+			break
+		}
+		c.flags = append(c.flags, i)
+		if strings.IndexByte(w, 't') == len(w)-1 {
+			i++
+		}
+	}
+
+	return c
+}
+
+func (s *commandLine) word(i int) string {
+	return s.line[s.words[i].start:s.words[i].end]
+}
+
+// replaceWord returns the line with word i replaced.
+func (s *commandLine) replaceWord(i int, word string) string {
+	w := s.words[i]
+
+	return s.line[:w.start] + word + s.line[w.end:]
+}
+
+// insertAfter returns the line with text inserted after word i.
+func (s *commandLine) insertAfter(i int, text string) string {
+	end := s.words[i].end
+
+	return s.line[:end] + text + s.line[end:]
+}
+
+// removeFlag returns the line without the flag letter, or false if the
+// line does not have it.
+func (s *commandLine) removeFlag(letter byte) (string, bool) {
+	for _, i := range s.flags {
+		w := s.word(i)
+		n := strings.IndexByte(w, letter)
+		t := strings.IndexByte(w, 't')
+		if n < 1 || (t >= 0 && n > t) {
+			continue
+		}
+
+		word := w[:n] + w[n+1:]
+		if word != "-" {
+			return s.replaceWord(i, word), true
+		}
+
+		// Drop the whole word along with the space before it.
+		return s.line[:s.words[i-1].end] + s.line[s.words[i].end:], true
+	}
+
+	return "", false
+}
@@ -0,0 +1,59 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyFixes(t *testing.T) {
+	lines := []string{"a", "b", "c", "d", "e"}
+	diags := []*Diagnostic{
+		{Fix: &Fix{Edits: []*Edit{{Line: 4, EndLine: 5, Lines: []string{}}}}},
+		{},
+		{Fix: &Fix{Edits: []*Edit{{Line: 1, EndLine: 1, Lines: []string{"A"}}}}},
+		{Fix: &Fix{Edits: []*Edit{{Line: 5, EndLine: 5, Lines: []string{"E"}}}}},
+		{Fix: &Fix{Edits: []*Edit{
+			{Line: 2, EndLine: 2, Lines: []string{"B1", "B2"}},
+		}}},
+	}
+
+	got, applied := ApplyFixes(lines, diags)
+
+	assert.Equal(t, []string{"A", "B1", "B2", "c"}, got)
+	assert.Equal(t, 3, applied)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, lines)
+}
+
+func TestCommandLineRemoveFlag(t *testing.T) {
+	tests := []struct {
+		line   string
+		letter byte
+		want   string
+		ok     bool
+	}{
+		{line: "set -gF @a x", letter: 'F', want: "set -g @a x", ok: true},
+		{line: "set -F @a x", letter: 'F', want: "set @a x", ok: true},
+		{line: "set\t-g -F  @a x", letter: 'F', want: "set\t-g  @a x", ok: true},
+		{line: "set -Ft main @a x", letter: 'F', want: "set -t main @a x",
+			ok: true},
+		{line: "set -t -F @a x", letter: 'F', ok: false},
+		{line: "set -g @a -F", letter: 'F', ok: false},
+		{line: "set -- -F x", letter: 'F', ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, ok := parseCommandLine(tt.line).removeFlag(tt.letter)
+
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCommandLineInsertAfter(t *testing.T) {
+	c := parseCommandLine("  set -g @a x")
+
+	assert.Equal(t, "  set -g -q @a x", c.insertAfter(1, " -q"))
+	assert.Equal(t, "  setw -g @a x", c.replaceWord(0, "setw"))
+}
@@ -0,0 +1,84 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+var styleColourMatcher = regexp.MustCompile(
+	`(?:^|[\[,\s])(?:fg|bg|us|fill)=([^,\]\s]+)`,
+)
+
+// HardcodedColourRule finds literal colours in themes which keep a palette
+// in user options, like @theme-fg, since they will not follow changes to
+// the palette. Themes without a palette are not checked.
+type HardcodedColourRule struct{}
+
+func (s *HardcodedColourRule) Name() string { return "hardcoded-colour" }
+
+func (s *HardcodedColourRule) Description() string {
+	return "Colour is hard-coded instead of using the theme palette"
+}
+
+func (s *HardcodedColourRule) Severity() Severity { return Info }
+
+func (s *HardcodedColourRule) Check(f *File) []*Diagnostic {
+	prefix := f.Config.PalettePrefix
+	diags := []*Diagnostic{}
+
+	palette := false
+	f.SetOptions(func(i int, st *theme.SetOptionStatement) {
+		palette = palette || strings.HasPrefix(st.Option, prefix)
+	})
+	if !palette || prefix == "" {
+		return diags
+	}
+
+	f.SetOptions(func(i int, st *theme.SetOptionStatement) {
+		if st.Flags.Unset || strings.HasPrefix(st.Option, "@") {
+			return
+		}
+
+		colours := hardcodedColours(st.Option, st.Value)
+		if len(colours) > 0 {
+			diags = append(diags, f.Diagnostic(i,
+				"%s hard-codes %s instead of using the %s palette",
+				st.Option, strings.Join(colours, ", "), prefix))
+		}
+	})
+
+	return diags
+}
+
+// hardcodedColours returns the literal colours in the value of a colour or
+// style option, or in the #[...] style blocks of any other option.
+func hardcodedColours(name, value string) []string {
+	candidates := []string{}
+
+	def, ok := theme.LookupOptionDefinition(name)
+	switch {
+	case ok && def.Type == theme.ColourOption:
+		candidates = append(candidates, value)
+	case ok && def.Type == theme.StyleOption, strings.Contains(value, "#["):
+		for _, m := range styleColourMatcher.FindAllStringSubmatch(value, -1) {
+			candidates = append(candidates, m[1])
+		}
+	}
+
+	colours := []string{}
+	for _, c := range candidates {
+		if strings.Contains(c, "#{") {
+			continue
+		}
+		colour, err := theme.ParseColour(c)
+		if err != nil || colour.Type == theme.DefaultColour ||
+			colour.Type == theme.TerminalColour {
+			continue
+		}
+		colours = append(colours, c)
+	}
+
+	return colours
+}
@@ -0,0 +1,38 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHardcodedColourRule(t *testing.T) {
+	got := check(t, &HardcodedColourRule{}, `
+set -g @theme-fg white
+set -g @theme-bg black
+set -gF status-style "fg=#{@theme-fg},bg=#{@theme-bg}"
+set -g pane-border-style "fg=colour238 bg=default"
+set -g display-panes-colour blue
+set -g display-panes-active-colour default
+set -g status-left "#[fg=red,bold]#S#[fg=#{@theme-fg}] #[bg=#ff0000]x"
+set -g status-right "#H"
+set -g @other-fg red
+`)
+
+	assert.Equal(t, []string{
+		"4: pane-border-style hard-codes colour238 instead of using " +
+			"the @theme- palette",
+		"5: display-panes-colour hard-codes blue instead of using " +
+			"the @theme- palette",
+		"7: status-left hard-codes red, #ff0000 instead of using " +
+			"the @theme- palette",
+	}, got)
+}
+
+func TestHardcodedColourRuleNoPalette(t *testing.T) {
+	got := check(t, &HardcodedColourRule{}, `
+set -g status-style "fg=white,bg=black"
+`)
+
+	assert.Empty(t, got)
+}
@@ -0,0 +1,13 @@
+package lint
+
+import "fmt"
+
+type InvalidSeverityError struct {
+	Value string
+}
+
+func (s *InvalidSeverityError) Error() string {
+	return fmt.Sprintf(
+		"Invalid severity %q, expected off, info, warning or error", s.Value,
+	)
+}
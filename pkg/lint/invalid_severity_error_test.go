@@ -0,0 +1,19 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidSeverityErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &InvalidSeverityError{})
+}
+
+func TestInvalidSeverityError(t *testing.T) {
+	err := &InvalidSeverityError{Value: "fatal"}
+
+	assert.Equal(t,
+		`Invalid severity "fatal", expected off, info, warning or error`,
+		err.Error())
+}
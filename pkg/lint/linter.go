@@ -0,0 +1,59 @@
+// Package lint checks theme files for statements which are dead, redundant
+// or likely to be mistakes.
+package lint
+
+import (
+	"sort"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+type Linter struct {
+	Rules  []Rule
+	Config *Config
+}
+
+// New returns a linter running every built-in rule with config, or the
+// default config if nil.
+func New(config *Config) *Linter {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	return &Linter{Rules: Rules(), Config: config}
+}
+
+// Lint checks a parsed theme, returning diagnostics ordered by position.
+// Findings for statements preceded by an ignore directive comment are
+// dropped, see Ignored.
+func (s *Linter) Lint(t *theme.Theme) ([]*Diagnostic, error) {
+	executed, err := t.Evaluate(t.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &File{Theme: t, Executed: executed, Config: s.Config}
+	diags := []*Diagnostic{}
+
+	for _, rule := range s.Rules {
+		severity := s.Config.Severity(rule)
+		if severity == Off {
+			continue
+		}
+
+		for _, d := range rule.Check(f) {
+			if Ignored(t, d.Statement, rule.Name()) {
+				continue
+			}
+			d.Rule = rule.Name()
+			d.Severity = severity
+			diags = append(diags, d)
+		}
+	}
+
+	sort.SliceStable(diags, func(i, j int) bool {
+		return diags[i].Statement < diags[j].Statement
+	})
+
+	return diags, nil
+}
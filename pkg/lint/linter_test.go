@@ -0,0 +1,54 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lintTheme = `
+set -g status-style "bg=red"
+# tmuxtheme:ignore useless-format
+set -gF status-style "bg=blue"
+set-option status-left "#S"
+set -gF @plain "x"
+`
+
+func TestLinterLint(t *testing.T) {
+	diags, err := New(nil).Lint(themetest.Parse(t, "test.tmuxtheme", lintTheme))
+	require.NoError(t, err)
+
+	got := []string{}
+	for _, d := range diags {
+		got = append(got, d.String())
+	}
+
+	assert.Equal(t, []string{
+		"test.tmuxtheme:1: warning: status-style is set again on line 3 " +
+			"before this value is used [dead-set] (fixable)",
+		"test.tmuxtheme:4: info: set-option is used here, while the rest " +
+			"of the file uses set [command-spelling] (fixable)",
+		"test.tmuxtheme:4: warning: status-left is set for the current " +
+			"session only, use -g to set it for all sessions " +
+			"[missing-global]",
+		"test.tmuxtheme:5: info: -F is not needed, the value of @plain has " +
+			"no formats [useless-format] (fixable)",
+	}, got)
+}
+
+func TestLinterLintConfig(t *testing.T) {
+	config := DefaultConfig()
+	config.Rules["missing-global"] = Error
+	config.Rules["command-spelling"] = Off
+	config.Rules["useless-format"] = Off
+
+	diags, err := New(config).Lint(themetest.Parse(t, "test.tmuxtheme", lintTheme))
+	require.NoError(t, err)
+
+	require.Len(t, diags, 2)
+	assert.Equal(t, "dead-set", diags[0].Rule)
+	assert.Equal(t, "missing-global", diags[1].Rule)
+	assert.Equal(t, Error, diags[1].Severity)
+}
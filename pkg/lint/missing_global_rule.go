@@ -0,0 +1,39 @@
+package lint
+
+import "github.com/jimeh/go-tmuxtheme/pkg/theme"
+
+// MissingGlobalRule finds session and window options set without -g or a
+// target, which only affect whichever session or window is current when
+// the theme is sourced.
+type MissingGlobalRule struct{}
+
+func (s *MissingGlobalRule) Name() string { return "missing-global" }
+
+func (s *MissingGlobalRule) Description() string {
+	return "Option is set for the current session or window instead of globally"
+}
+
+func (s *MissingGlobalRule) Severity() Severity { return Warning }
+
+func (s *MissingGlobalRule) Check(f *File) []*Diagnostic {
+	diags := []*Diagnostic{}
+
+	f.SetOptions(func(i int, st *theme.SetOptionStatement) {
+		if st.Target() != "" {
+			return
+		}
+
+		switch st.Scope() {
+		case theme.SessionScope:
+			diags = append(diags, f.Diagnostic(i,
+				"%s is set for the current session only, "+
+					"use -g to set it for all sessions", st.Option))
+		case theme.WindowScope:
+			diags = append(diags, f.Diagnostic(i,
+				"%s is set for the current window only, "+
+					"use -g to set it for all windows", st.Option))
+		}
+	})
+
+	return diags
+}
@@ -0,0 +1,28 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingGlobalRule(t *testing.T) {
+	got := check(t, &MissingGlobalRule{}, `
+set status-style "bg=red"
+set -w window-status-style "bg=red"
+set @mine x
+set -g status-left ""
+set -s escape-time 0
+set escape-time 0
+set -t main status-right ""
+`)
+
+	assert.Equal(t, []string{
+		"1: status-style is set for the current session only, " +
+			"use -g to set it for all sessions",
+		"2: window-status-style is set for the current window only, " +
+			"use -g to set it for all windows",
+		"3: @mine is set for the current session only, " +
+			"use -g to set it for all sessions",
+	}, got)
+}
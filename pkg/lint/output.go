@@ -0,0 +1,50 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// WriteText writes one diagnostic per line, as "file:line: severity:
+// message [rule]".
+func WriteText(w io.Writer, diags []*Diagnostic) error {
+	for _, d := range diags {
+		if _, err := fmt.Fprintln(w, d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type jsonDiagnostic struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Filename string   `json:"filename,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	EndLine  int      `json:"end_line,omitempty"`
+	Fix      *Fix     `json:"fix,omitempty"`
+}
+
+// WriteJSON writes the diagnostics as a JSON array.
+func WriteJSON(w io.Writer, diags []*Diagnostic) error {
+	out := make([]*jsonDiagnostic, 0, len(diags))
+	for _, d := range diags {
+		out = append(out, &jsonDiagnostic{
+			Rule:     d.Rule,
+			Severity: d.Severity,
+			Message:  d.Message,
+			Filename: d.Position.Filename,
+			Line:     d.Position.Line,
+			EndLine:  d.Position.EndLine,
+			Fix:      d.Fix,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(out)
+}
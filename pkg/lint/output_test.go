@@ -0,0 +1,92 @@
+package lint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testDiagnostics = []*Diagnostic{
+	{
+		Rule:     "dead-set",
+		Severity: Warning,
+		Message:  "@a is set again on line 2 before this value is used",
+		Position: theme.Position{Filename: "a.tmuxtheme", Line: 1, EndLine: 1},
+	},
+	{
+		Rule:     "missing-global",
+		Severity: Error,
+		Message:  "@b is set for the current session only",
+		Position: theme.Position{Filename: "a.tmuxtheme", Line: 3, EndLine: 4},
+	},
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteText(&buf, testDiagnostics)
+	require.NoError(t, err)
+
+	assert.Equal(t, `a.tmuxtheme:1: warning: @a is set again on line 2 before this value is used [dead-set]
+a.tmuxtheme:3-4: error: @b is set for the current session only [missing-global]
+`, buf.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteJSON(&buf, testDiagnostics[1:])
+	require.NoError(t, err)
+
+	assert.Equal(t, `[
+  {
+    "rule": "missing-global",
+    "severity": "error",
+    "message": "@b is set for the current session only",
+    "filename": "a.tmuxtheme",
+    "line": 3,
+    "end_line": 4
+  }
+]
+`, buf.String())
+
+	buf.Reset()
+	err = WriteJSON(&buf, []*Diagnostic{{
+		Rule:     "dead-set",
+		Severity: Warning,
+		Message:  "@a is set again",
+		Position: theme.Position{Filename: "a.tmuxtheme", Line: 1, EndLine: 1},
+		Fix: &Fix{
+			Description: "Remove the dead statement",
+			Edits:       []*Edit{{Line: 1, EndLine: 1, Lines: []string{}}},
+		},
+	}})
+	require.NoError(t, err)
+
+	assert.Equal(t, `[
+  {
+    "rule": "dead-set",
+    "severity": "warning",
+    "message": "@a is set again",
+    "filename": "a.tmuxtheme",
+    "line": 1,
+    "end_line": 1,
+    "fix": {
+      "description": "Remove the dead statement",
+      "edits": [
+        {
+          "line": 1,
+          "end_line": 1,
+          "lines": []
+        }
+      ]
+    }
+  }
+]
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteJSON(&buf, nil))
+	assert.Equal(t, "[]\n", buf.String())
+}
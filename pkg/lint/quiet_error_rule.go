@@ -0,0 +1,77 @@
+package lint
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// QuietErrorRule finds -q statements hiding errors tmux would otherwise
+// report: unknown options and invalid values.
+type QuietErrorRule struct{}
+
+func (s *QuietErrorRule) Name() string { return "quiet-error" }
+
+func (s *QuietErrorRule) Description() string {
+	return "-q hides an error in the statement"
+}
+
+func (s *QuietErrorRule) Severity() Severity { return Warning }
+
+func (s *QuietErrorRule) Check(f *File) []*Diagnostic {
+	diags := []*Diagnostic{}
+
+	f.SetOptions(func(i int, st *theme.SetOptionStatement) {
+		if !st.Flags.Quiet || strings.HasPrefix(st.Option, "@") {
+			return
+		}
+
+		def, ok := theme.LookupOptionDefinition(st.Option)
+		if !ok {
+			diags = append(diags, f.Diagnostic(i,
+				"-q hides that %s is not a tmux option", st.Option))
+			return
+		}
+
+		if st.Flags.Unset || strings.Contains(st.Value, "#{") {
+			return
+		}
+		if !validValue(def, st.Value) {
+			diags = append(diags, f.Diagnostic(i,
+				"-q hides that %q is not a valid value for %s",
+				st.Value, st.Option))
+		}
+	})
+
+	return diags
+}
+
+func validValue(def *theme.OptionDefinition, value string) bool {
+	switch def.Type {
+	case theme.NumberOption:
+		_, err := strconv.Atoi(value)
+		return err == nil
+	case theme.FlagOption:
+		switch value {
+		case "", "on", "off", "yes", "no", "1", "0":
+			return true
+		}
+		return false
+	case theme.ChoiceOption:
+		for _, c := range def.Choices {
+			if c == value {
+				return true
+			}
+		}
+		return len(def.Choices) == 0
+	case theme.ColourOption:
+		_, err := theme.ParseColour(value)
+		return err == nil
+	case theme.StyleOption:
+		_, err := theme.ParseStyle(value)
+		return err == nil
+	}
+
+	return true
+}
@@ -0,0 +1,31 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuietErrorRule(t *testing.T) {
+	got := check(t, &QuietErrorRule{}, `
+set -gq status-colour red
+set -gq status-style "fg=nope"
+set -gq status-style "fg=red"
+set -gq status-interval soon
+set -gq status-justify middle
+set -gq status-justify centre
+set -gq status on
+set -gq status maybe
+set -gq @anything whatever
+set -gqF status-style "fg=#{@fg}"
+set -g status-interval soon
+`)
+
+	assert.Equal(t, []string{
+		"1: -q hides that status-colour is not a tmux option",
+		`2: -q hides that "fg=nope" is not a valid value for status-style`,
+		`4: -q hides that "soon" is not a valid value for status-interval`,
+		`5: -q hides that "middle" is not a valid value for status-justify`,
+		`8: -q hides that "maybe" is not a valid value for status`,
+	}, got)
+}
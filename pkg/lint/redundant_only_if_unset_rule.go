@@ -0,0 +1,49 @@
+package lint
+
+import "github.com/jimeh/go-tmuxtheme/pkg/theme"
+
+// RedundantOnlyIfUnsetRule finds -o statements for options the theme has
+// already set, which therefore do nothing.
+type RedundantOnlyIfUnsetRule struct{}
+
+func (s *RedundantOnlyIfUnsetRule) Name() string {
+	return "redundant-only-if-unset"
+}
+
+func (s *RedundantOnlyIfUnsetRule) Description() string {
+	return "-o has no effect because the option is already set"
+}
+
+func (s *RedundantOnlyIfUnsetRule) Severity() Severity { return Warning }
+
+func (s *RedundantOnlyIfUnsetRule) Check(f *File) []*Diagnostic {
+	diags := []*Diagnostic{}
+
+	f.SetOptions(func(i int, st *theme.SetOptionStatement) {
+		if !st.Flags.OnlyIfUnset {
+			return
+		}
+
+		p := f.Executed.Provenance(st.Scope(), st.Target(), st.Option)
+		if p == nil {
+			return
+		}
+
+		var previous *theme.Assignment
+		for _, a := range p.Assignments {
+			if a.Statement == st {
+				if a.Action == theme.SkippedAction && previous != nil {
+					diags = append(diags, f.Diagnostic(i,
+						"-o has no effect, %s is already set on line %d",
+						st.Option, previous.Position.Line))
+				}
+				return
+			}
+			if a.Action != theme.SkippedAction {
+				previous = a
+			}
+		}
+	})
+
+	return diags
+}
@@ -0,0 +1,23 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedundantOnlyIfUnsetRule(t *testing.T) {
+	got := check(t, &RedundantOnlyIfUnsetRule{}, `
+set -g @fg white
+set -go @fg black
+set -go @bg black
+set -go @bg blue
+set -gu @fg
+set -go @fg red
+`)
+
+	assert.Equal(t, []string{
+		"2: -o has no effect, @fg is already set on line 1",
+		"4: -o has no effect, @bg is already set on line 3",
+	}, got)
+}
@@ -0,0 +1,85 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// Rule checks a theme file for one kind of problem.
+type Rule interface {
+	// Name identifies the rule in configs, ignore directives and output.
+	Name() string
+	Description() string
+
+	// Severity is the default severity of the rule's findings.
+	Severity() Severity
+
+	Check(f *File) []*Diagnostic
+}
+
+// Rules returns every built-in rule.
+func Rules() []Rule {
+	return []Rule{
+		&DeadSetRule{},
+		&RedundantOnlyIfUnsetRule{},
+		&UselessFormatRule{},
+		&QuietErrorRule{},
+		&CommandSpellingRule{},
+		&MissingGlobalRule{},
+		&HardcodedColourRule{},
+		&DeprecatedOptionRule{},
+	}
+}
+
+func LookupRule(name string) (Rule, bool) {
+	for _, r := range Rules() {
+		if r.Name() == name {
+			return r, true
+		}
+	}
+
+	return nil, false
+}
+
+// File is the theme being linted, shared by all rules.
+type File struct {
+	// Theme is the parsed theme, it is not executed.
+	Theme *theme.Theme
+
+	// Executed is a copy of Theme after executing its statements, for
+	// rules which need option values or provenance.
+	Executed *theme.Theme
+
+	Config *Config
+}
+
+// SetOptions calls fn with every set-option statement and its index.
+func (s *File) SetOptions(fn func(i int, st *theme.SetOptionStatement)) {
+	for i, st := range s.Theme.Statements {
+		if set, ok := st.(*theme.SetOptionStatement); ok {
+			fn(i, set)
+		}
+	}
+}
+
+// Index returns the index of st in Theme's statements, or -1.
+func (s *File) Index(st theme.Statement) int {
+	for i, other := range s.Theme.Statements {
+		if other == st {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Diagnostic returns a diagnostic for the statement at index i. The rule
+// and severity are filled in by the linter.
+func (s *File) Diagnostic(i int, format string, args ...interface{}) *Diagnostic {
+	return &Diagnostic{
+		Message:   fmt.Sprintf(format, args...),
+		Position:  s.Theme.Position(i),
+		Statement: i,
+	}
+}
@@ -0,0 +1,78 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFile(t *testing.T, body string) *File {
+	th := themetest.Parse(t, "test.tmuxtheme", body)
+	executed, err := th.Evaluate(nil)
+	require.NoError(t, err)
+
+	return &File{Theme: th, Executed: executed, Config: DefaultConfig()}
+}
+
+// check runs rule against body, returning "line: message" for each
+// diagnostic.
+func check(t *testing.T, rule Rule, body string) []string {
+	out := []string{}
+	for _, d := range rule.Check(newFile(t, body)) {
+		out = append(out, fmt.Sprintf("%d: %s", d.Position.Line, d.Message))
+	}
+
+	return out
+}
+
+// fix runs rule against body and returns it with the fixes applied.
+func fix(t *testing.T, rule Rule, body string) string {
+	f := newFile(t, body)
+	lines, _ := ApplyFixes(f.Theme.Lines, rule.Check(f))
+
+	return strings.Join(lines, "\n")
+}
+
+func TestRules(t *testing.T) {
+	names := map[string]bool{}
+	for _, r := range Rules() {
+		assert.NotEmpty(t, r.Name())
+		assert.NotEmpty(t, r.Description(), r.Name())
+		assert.NotEqual(t, Off, r.Severity(), r.Name())
+		assert.False(t, names[r.Name()], "duplicate rule %s", r.Name())
+		names[r.Name()] = true
+	}
+}
+
+func TestLookupRule(t *testing.T) {
+	r, ok := LookupRule("dead-set")
+	assert.True(t, ok)
+	assert.Equal(t, &DeadSetRule{}, r)
+
+	_, ok = LookupRule("nope")
+	assert.False(t, ok)
+}
+
+func TestFileIndex(t *testing.T) {
+	f := newFile(t, "# comment\nset -g @a b\n")
+
+	assert.Equal(t, 1, f.Index(f.Theme.Statements[1]))
+	assert.Equal(t, -1, f.Index(&theme.EmptyStatement{}))
+}
+
+func TestFileDiagnostic(t *testing.T) {
+	f := newFile(t, "# comment\nset -g @a b\n")
+
+	d := f.Diagnostic(1, "%s is %d", "@a", 1)
+
+	assert.Equal(t, &Diagnostic{
+		Message:   "@a is 1",
+		Position:  theme.Position{Filename: "test.tmuxtheme", Line: 2, EndLine: 2},
+		Statement: 1,
+	}, d)
+}
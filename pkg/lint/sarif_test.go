@@ -0,0 +1,63 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteSARIF(&buf, testDiagnostics, []Rule{&DeadSetRule{}})
+	require.NoError(t, err)
+
+	var log map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log["version"])
+
+	run := log["runs"].([]interface{})[0].(map[string]interface{})
+	driver := run["tool"].(map[string]interface{})["driver"].(map[string]interface{})
+	assert.Equal(t, "tmuxtheme", driver["name"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"id": "dead-set",
+			"shortDescription": map[string]interface{}{
+				"text": "Option is set again before its value is used",
+			},
+			"defaultConfiguration": map[string]interface{}{
+				"level": "warning",
+			},
+		},
+	}, driver["rules"])
+
+	results := run["results"].([]interface{})
+	require.Len(t, results, 2)
+	assert.Equal(t, map[string]interface{}{
+		"ruleId":  "missing-global",
+		"level":   "error",
+		"message": map[string]interface{}{"text": "@b is set for the current session only"},
+		"locations": []interface{}{
+			map[string]interface{}{
+				"physicalLocation": map[string]interface{}{
+					"artifactLocation": map[string]interface{}{
+						"uri": "a.tmuxtheme",
+					},
+					"region": map[string]interface{}{
+						"startLine": float64(3),
+						"endLine":   float64(4),
+					},
+				},
+			},
+		},
+	}, results[1])
+}
+
+func TestSarifLevel(t *testing.T) {
+	assert.Equal(t, "error", sarifLevel(Error))
+	assert.Equal(t, "warning", sarifLevel(Warning))
+	assert.Equal(t, "note", sarifLevel(Info))
+	assert.Equal(t, "none", sarifLevel(Off))
+}
@@ -0,0 +1,40 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeverity(t *testing.T) {
+	var tests = []struct {
+		severity Severity
+		name     string
+	}{
+		{Off, "off"},
+		{Info, "info"},
+		{Warning, "warning"},
+		{Error, "error"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.name, tt.severity.String())
+
+		s, err := ParseSeverity(tt.name)
+		require.NoError(t, err)
+		assert.Equal(t, tt.severity, s)
+
+		text, err := tt.severity.MarshalText()
+		require.NoError(t, err)
+		assert.Equal(t, tt.name, string(text))
+	}
+
+	assert.Equal(t, "unknown", Severity(42).String())
+}
+
+func TestParseSeverityInvalid(t *testing.T) {
+	_, err := ParseSeverity("fatal")
+
+	assert.Equal(t, &InvalidSeverityError{Value: "fatal"}, err)
+}
@@ -0,0 +1,11 @@
+package lint
+
+import "fmt"
+
+type UnknownRuleError struct {
+	Rule string
+}
+
+func (s *UnknownRuleError) Error() string {
+	return fmt.Sprintf("Unknown lint rule: %s", s.Rule)
+}
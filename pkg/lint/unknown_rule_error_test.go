@@ -0,0 +1,17 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnknownRuleErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &UnknownRuleError{})
+}
+
+func TestUnknownRuleError(t *testing.T) {
+	err := &UnknownRuleError{Rule: "nope"}
+
+	assert.Equal(t, "Unknown lint rule: nope", err.Error())
+}
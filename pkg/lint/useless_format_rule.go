@@ -0,0 +1,33 @@
+package lint
+
+import "github.com/jimeh/go-tmuxtheme/pkg/theme"
+
+// UselessFormatRule finds -F on values without any formats to expand.
+type UselessFormatRule struct{}
+
+func (s *UselessFormatRule) Name() string { return "useless-format" }
+
+func (s *UselessFormatRule) Description() string {
+	return "-F is used on a value with nothing to expand"
+}
+
+func (s *UselessFormatRule) Severity() Severity { return Info }
+
+func (s *UselessFormatRule) Check(f *File) []*Diagnostic {
+	diags := []*Diagnostic{}
+
+	f.SetOptions(func(i int, st *theme.SetOptionStatement) {
+		if st.Flags.Format && !st.Flags.Unset &&
+			theme.ParseTemplate(st.Value).IsStatic() {
+			d := f.Diagnostic(i,
+				"-F is not needed, the value of %s has no formats", st.Option)
+			d.Fix = f.rewriteFix(i, "Remove -F",
+				func(c *commandLine) (string, bool) {
+					return c.removeFlag('F')
+				})
+			diags = append(diags, d)
+		}
+	})
+
+	return diags
+}
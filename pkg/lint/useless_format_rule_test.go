@@ -0,0 +1,35 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUselessFormatRule(t *testing.T) {
+	got := check(t, &UselessFormatRule{}, `
+set -gF @a "plain"
+set -gF @b "#{@a}"
+set -gF @c "#S"
+set -g @d "plain"
+set -guF @a
+`)
+
+	assert.Equal(t, []string{
+		"1: -F is not needed, the value of @a has no formats",
+	}, got)
+}
+
+func TestUselessFormatRuleFix(t *testing.T) {
+	got := fix(t, &UselessFormatRule{}, `
+set -gF @a "plain"
+set -F -g @b "plain"
+set -gFq  @c plain # comment
+set -gF @d "#{@a}"
+`)
+
+	assert.Equal(t, `set -g @a "plain"
+set -g @b "plain"
+set -gq  @c plain # comment
+set -gF @d "#{@a}"`, got)
+}
@@ -0,0 +1,100 @@
+package lsp
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+var styleColourMatcher = regexp.MustCompile(
+	`(?:^|[\[,\s"'])(?:fg|bg|us|fill)=([^,\]\s"']+)`,
+)
+
+// Colours returns the colours in set-option statements: the values of
+// colour options, and the fg, bg, us and fill colours of styles, including
+// #[...] blocks in formats. Default and terminal colours have no fixed RGB
+// value and are left out.
+func (s *Document) Colours() []ColorInformation {
+	colours := []ColorInformation{}
+	add := func(line, start, end int) {
+		text := s.Lines[line][start:end]
+		c, err := theme.ParseColour(text)
+		if err != nil {
+			return
+		}
+		if r, g, b, ok := c.RGB(); ok {
+			colours = append(colours, ColorInformation{
+				Range: s.span(line, start, end),
+				Color: Color{
+					Red:   float64(r) / 255,
+					Green: float64(g) / 255,
+					Blue:  float64(b) / 255,
+					Alpha: 1,
+				},
+			})
+		}
+	}
+
+	for i := range s.Theme.Statements {
+		st, ok := s.setStatement(i)
+		if !ok || st.Flags.Unset {
+			continue
+		}
+		pos := s.Theme.Position(i)
+
+		for line := pos.Line - 1; line < pos.EndLine; line++ {
+			text := s.Lines[line]
+			for _, m := range styleColourMatcher.FindAllStringSubmatchIndex(
+				text, -1) {
+				add(line, m[2], m[3])
+			}
+		}
+
+		if isColourOption(st.Option) && pos.Line == pos.EndLine {
+			words := splitWords(s.Lines[pos.Line-1])
+			if w := words[len(words)-1]; w.text != st.Option {
+				start, end := w.start, w.end
+				if strings.ContainsAny(w.text[:1], `"'`) && end-start >= 2 {
+					start, end = start+1, end-1
+				}
+				add(pos.Line-1, start, end)
+			}
+		}
+	}
+
+	return colours
+}
+
+// isColourOption reports whether the option holds a single colour, going
+// by its definition or, for user options, a -fg, -bg or -colour suffix.
+func isColourOption(name string) bool {
+	if def, ok := theme.LookupOptionDefinition(name); ok {
+		return def.Type == theme.ColourOption
+	}
+
+	for _, suffix := range []string{"-fg", "-bg", "-colour", "-color"} {
+		if strings.HasPrefix(name, "@") && strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ColourPresentations returns the ways the colour picked for r can be
+// written, as a hex colour.
+func ColourPresentations(c Color, r Range) []ColorPresentation {
+	label := fmt.Sprintf("#%02x%02x%02x",
+		component(c.Red), component(c.Green), component(c.Blue))
+
+	return []ColorPresentation{
+		{Label: label, TextEdit: &TextEdit{Range: r, NewText: label}},
+	}
+}
+
+func component(v float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(1, v)) * 255))
+}
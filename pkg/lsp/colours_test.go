@@ -0,0 +1,78 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentColours(t *testing.T) {
+	d := newTestDocument(`
+set -g @theme-bg "#2e3440"
+set -g @theme-fg white
+set -g status-style "fg=colour160,bg=#{@theme-bg}"
+set -g status-left "#[fg=red,bg=default] #S #[us=brightblue]"
+set -g @theme-name red
+set -gu @gone-fg
+set -g display-panes-colour brightred # comment
+set -g @x-bg \
+  blue
+set -g clock-mode-colour colour39
+`)
+
+	rng := func(line, start, end int) Range {
+		return Range{
+			Start: Position{Line: line, Character: start},
+			End:   Position{Line: line, Character: end},
+		}
+	}
+
+	assert.Equal(t, []ColorInformation{
+		{
+			Range: rng(0, 18, 25),
+			Color: Color{Red: 0x2e / 255.0, Green: 0x34 / 255.0,
+				Blue: 0x40 / 255.0, Alpha: 1},
+		},
+		{
+			Range: rng(1, 17, 22),
+			Color: Color{Red: 0xe5 / 255.0, Green: 0xe5 / 255.0,
+				Blue: 0xe5 / 255.0, Alpha: 1},
+		},
+		{
+			Range: rng(2, 24, 33),
+			Color: Color{Red: 0xd7 / 255.0, Alpha: 1},
+		},
+		{
+			Range: rng(3, 25, 28),
+			Color: Color{Red: 0xcd / 255.0, Alpha: 1},
+		},
+		{
+			Range: rng(3, 49, 59),
+			Color: Color{Red: 0x5c / 255.0, Green: 0x5c / 255.0, Blue: 1,
+				Alpha: 1},
+		},
+		{
+			Range: rng(9, 25, 33),
+			Color: Color{Green: 0xaf / 255.0, Blue: 1, Alpha: 1},
+		},
+	}, d.Colours())
+}
+
+func TestColourPresentations(t *testing.T) {
+	r := Range{End: Position{Character: 3}}
+
+	got := ColourPresentations(Color{Red: 1, Green: 0.5, Blue: -1}, r)
+
+	assert.Equal(t, []ColorPresentation{
+		{Label: "#ff8000", TextEdit: &TextEdit{Range: r, NewText: "#ff8000"}},
+	}, got)
+}
+
+func TestIsColourOption(t *testing.T) {
+	assert.True(t, isColourOption("display-panes-colour"))
+	assert.True(t, isColourOption("@theme-fg"))
+	assert.True(t, isColourOption("@accent-color"))
+	assert.False(t, isColourOption("status-style"))
+	assert.False(t, isColourOption("status-fg-x"))
+	assert.False(t, isColourOption("@theme-name"))
+}
@@ -0,0 +1,255 @@
+package lsp
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+var commands = []string{"set", "set-option", "set-window-option"}
+
+var flagDescriptions = []struct {
+	flag        string
+	description string
+}{
+	{"-a", "Append to the current value"},
+	{"-F", "Expand formats in the value"},
+	{"-g", "Set the global option"},
+	{"-o", "Only set the option if it is unset"},
+	{"-q", "Ignore errors about unknown options"},
+	{"-s", "Set a server option"},
+	{"-t", "Set the option of the target session or window"},
+	{"-u", "Unset the option"},
+	{"-w", "Set a window option"},
+}
+
+var scopeNames = map[theme.Scope]string{
+	theme.ServerScope:  "server option",
+	theme.SessionScope: "session option",
+	theme.WindowScope:  "window option",
+}
+
+var colourValues = []string{
+	"default", "terminal",
+	"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white",
+	"brightblack", "brightred", "brightgreen", "brightyellow", "brightblue",
+	"brightmagenta", "brightcyan", "brightwhite",
+}
+
+// commandContext describes the set-option command line being completed.
+type commandContext struct {
+	line    int
+	words   []word
+	current int
+	flags   string
+	window  bool
+	option  int
+	target  int
+}
+
+// Completion returns completions at p for the command, flags, option name
+// or value of the set-option statement being typed.
+//
+// Built-in options are only offered for the scope chosen by -s and -w, and
+// when the command has no flags yet the -g flag their scope needs is added
+// along with them.
+func (s *Document) Completion(p Position) []CompletionItem {
+	line, offset, ok := s.offset(p)
+	if !ok {
+		return []CompletionItem{}
+	}
+
+	c := parseCommandContext(line[:offset])
+	c.line = p.Line
+	start := offset
+	if c.current < len(c.words) {
+		start = c.words[c.current].start
+	}
+	partial := line[start:offset]
+	replace := s.span(p.Line, start, offset)
+
+	items := []CompletionItem{}
+	add := func(label, detail string, kind CompletionItemKind) {
+		if strings.HasPrefix(label, partial) {
+			items = append(items, CompletionItem{
+				Label:    label,
+				Kind:     kind,
+				Detail:   detail,
+				TextEdit: &TextEdit{Range: replace, NewText: label},
+			})
+		}
+	}
+
+	switch {
+	case c.current == 0:
+		for _, cmd := range commands {
+			add(cmd, "", KindKeyword)
+		}
+	case !isCommand(c.words[0].text) || c.current == c.target:
+	case c.option < 0:
+		for _, f := range flagDescriptions {
+			add(f.flag, f.description, KindKeyword)
+		}
+	case c.current == c.option:
+		items = append(items, s.optionItems(c, partial, replace)...)
+	case c.current == c.option+1:
+		kind := KindValue
+		def, ok := theme.LookupOptionDefinition(c.words[c.option].text)
+		if ok && def.Type == theme.ColourOption {
+			kind = KindColor
+		}
+		for _, v := range s.valueChoices(c.words[c.option].text) {
+			add(v, "", kind)
+		}
+	}
+
+	return items
+}
+
+func parseCommandContext(prefix string) *commandContext {
+	c := &commandContext{words: splitWords(prefix), option: -1, target: -1}
+
+	c.current = len(c.words)
+	if n := len(c.words); n > 0 && c.words[n-1].end == len(prefix) {
+		c.current = n - 1
+	}
+	c.window = len(c.words) > 0 && c.words[0].text == "set-window-option"
+
+	// Flags come first, up to the option name. The word being completed is
+	// past the end of words when it has not been started.
+	for i := 1; i <= c.current; i++ {
+		if i == c.target {
+			continue
+		}
+
+		w := ""
+		if i < len(c.words) {
+			w = c.words[i].text
+		}
+		switch {
+		case i == c.current:
+			if !strings.HasPrefix(w, "-") {
+				c.option = i
+			}
+		case w == "--":
+			c.option = i + 1
+		case len(w) < 2 || w[0] != '-':
+			c.option = i
+		default:
+			c.flags += w[1:]
+			if strings.HasSuffix(w, "t") {
+				c.target = i + 1
+			}
+			continue
+		}
+		break
+	}
+
+	return c
+}
+
+func isCommand(name string) bool {
+	for _, cmd := range commands {
+		if name == cmd {
+			return true
+		}
+	}
+
+	return false
+}
+
+// optionItems returns the built-in options for the scope picked by the
+// flags typed so far, and the user options set in the document.
+func (s *Document) optionItems(
+	c *commandContext,
+	partial string,
+	replace Range,
+) []CompletionItem {
+	items := []CompletionItem{}
+
+	for _, def := range theme.OptionDefinitions() {
+		if !strings.HasPrefix(def.Name, partial) || !c.allows(def.Scope) {
+			continue
+		}
+
+		item := CompletionItem{
+			Label:    def.Name,
+			Kind:     KindProperty,
+			Detail:   scopeNames[def.Scope],
+			TextEdit: &TextEdit{Range: replace, NewText: def.Name},
+		}
+		if c.flags == "" && !c.window && def.Scope != theme.ServerScope {
+			end := c.words[0].end
+			item.AdditionalTextEdits = []TextEdit{{
+				Range:   s.span(c.line, end, end),
+				NewText: " -g",
+			}}
+		}
+		items = append(items, item)
+	}
+
+	for _, name := range s.userOptions() {
+		if !strings.HasPrefix(name, partial) || name == partial {
+			continue
+		}
+		items = append(items, CompletionItem{
+			Label:    name,
+			Kind:     KindVariable,
+			Detail:   "user option",
+			TextEdit: &TextEdit{Range: replace, NewText: name},
+		})
+	}
+
+	return items
+}
+
+// allows reports whether built-in options of scope can be set with the
+// flags given.
+func (s *commandContext) allows(scope theme.Scope) bool {
+	switch {
+	case strings.Contains(s.flags, "s"):
+		return scope == theme.ServerScope
+	case s.window || strings.Contains(s.flags, "w"):
+		return scope == theme.WindowScope
+	}
+
+	return true
+}
+
+// userOptions returns the names of the user options set in the document.
+func (s *Document) userOptions() []string {
+	seen := map[string]bool{}
+	names := []string{}
+
+	for i := range s.Theme.Statements {
+		st, ok := s.setStatement(i)
+		if ok && strings.HasPrefix(st.Option, "@") && !seen[st.Option] {
+			seen[st.Option] = true
+			names = append(names, st.Option)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// valueChoices returns the values an option accepts, if there is a short
+// list of them.
+func (s *Document) valueChoices(name string) []string {
+	def, ok := theme.LookupOptionDefinition(name)
+	if !ok {
+		return nil
+	}
+
+	switch def.Type {
+	case theme.ChoiceOption:
+		return def.Choices
+	case theme.FlagOption:
+		return []string{"on", "off"}
+	case theme.ColourOption:
+		return colourValues
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func labels(items []CompletionItem) []string {
+	out := []string{}
+	for _, item := range items {
+		out = append(out, item.Label)
+	}
+
+	return out
+}
+
+func TestParseCommandContext(t *testing.T) {
+	tests := []struct {
+		prefix  string
+		current int
+		flags   string
+		window  bool
+		option  int
+		target  int
+	}{
+		{prefix: "", current: 0, option: -1, target: -1},
+		{prefix: "se", current: 0, option: -1, target: -1},
+		{prefix: "set ", current: 1, option: 1, target: -1},
+		{prefix: "set -", current: 1, option: -1, target: -1},
+		{prefix: "set -g ", current: 2, flags: "g", option: 2, target: -1},
+		{prefix: "set -g stat", current: 2, flags: "g", option: 2,
+			target: -1},
+		{prefix: "set -gt ", current: 2, flags: "gt", option: -1,
+			target: 2},
+		{prefix: "set -t main -g @a ", current: 5, flags: "tg", option: 4,
+			target: 2},
+		{prefix: "set -- -a ", current: 3, option: 2, target: -1},
+		{prefix: "set-window-option -g ", current: 2, flags: "g",
+			window: true, option: 2, target: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			c := parseCommandContext(tt.prefix)
+
+			assert.Equal(t, tt.current, c.current, "current")
+			assert.Equal(t, tt.flags, c.flags, "flags")
+			assert.Equal(t, tt.window, c.window, "window")
+			assert.Equal(t, tt.option, c.option, "option")
+			assert.Equal(t, tt.target, c.target, "target")
+		})
+	}
+}
+
+func TestDocumentCompletion(t *testing.T) {
+	d := newTestDocument(`
+se
+set -
+set -s escape-t
+set -w window-status-c
+set status-sty
+set -g @theme-fg white
+set -g @theme-bg black
+set -g @th
+set -g mouse o
+set -g status-position 
+set -g pane-border-lines s
+set -t 
+bind-key x
+set -g status-left-style fg=
+`)
+
+	tests := []struct {
+		pos  Position
+		want []string
+	}{
+		{Position{Line: 0, Character: 2}, []string{"set", "set-option",
+			"set-window-option"}},
+		{Position{Line: 1, Character: 5}, []string{"-a", "-F", "-g", "-o",
+			"-q", "-s", "-t", "-u", "-w"}},
+		{Position{Line: 2, Character: 15}, []string{"escape-time"}},
+		{Position{Line: 3, Character: 22}, []string{
+			"window-status-current-format",
+			"window-status-current-style",
+		}},
+		{Position{Line: 7, Character: 10}, []string{"@theme-bg",
+			"@theme-fg"}},
+		{Position{Line: 8, Character: 14}, []string{"on", "off"}},
+		{Position{Line: 9, Character: 23}, []string{"top", "bottom"}},
+		{Position{Line: 10, Character: 26}, []string{"single", "simple"}},
+		{Position{Line: 11, Character: 7}, []string{}},
+		{Position{Line: 12, Character: 10}, []string{}},
+		{Position{Line: 13, Character: 29}, []string{}},
+		{Position{Line: 99}, []string{}},
+	}
+	for _, tt := range tests {
+		got := d.Completion(tt.pos)
+
+		assert.Equal(t, tt.want, labels(got), "%+v", tt.pos)
+	}
+}
+
+func TestDocumentCompletionEdits(t *testing.T) {
+	d := newTestDocument("set status-sty\nset -g status-sty\n")
+
+	got := d.Completion(Position{Line: 0, Character: 14})
+	require.Len(t, got, 1)
+	assert.Equal(t, CompletionItem{
+		Label:  "status-style",
+		Kind:   KindProperty,
+		Detail: "session option",
+		TextEdit: &TextEdit{
+			Range: Range{
+				Start: Position{Line: 0, Character: 4},
+				End:   Position{Line: 0, Character: 14},
+			},
+			NewText: "status-style",
+		},
+		AdditionalTextEdits: []TextEdit{{
+			Range: Range{
+				Start: Position{Line: 0, Character: 3},
+				End:   Position{Line: 0, Character: 3},
+			},
+			NewText: " -g",
+		}},
+	}, got[0])
+
+	got = d.Completion(Position{Line: 1, Character: 17})
+	require.Len(t, got, 1)
+	assert.Empty(t, got[0].AdditionalTextEdits)
+}
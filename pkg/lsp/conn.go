@@ -0,0 +1,115 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// message is a JSON-RPC request, notification or response. Notifications
+// have no ID.
+type message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Result  interface{}      `json:"result"`
+}
+
+type errorResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Error   *ResponseError   `json:"error"`
+}
+
+// maxContentLength limits the size of message bodies, so a bad header
+// can't make read allocate an arbitrary amount of memory.
+const maxContentLength = 8 << 20
+
+// conn reads and writes messages framed with a Content-Length header, as
+// LSP sends them over stdio.
+type conn struct {
+	r *bufio.Reader
+	w io.Writer
+}
+
+func newConn(r io.Reader, w io.Writer) *conn {
+	return &conn{r: bufio.NewReader(r), w: w}
+}
+
+// read returns the body of the next message, or io.EOF once the input ends
+// between messages.
+func (s *conn) read() ([]byte, error) {
+	length := -1
+
+	for {
+		line, err := s.r.ReadString('\n')
+		if err == io.EOF && line == "" && length < 0 {
+			return nil, io.EOF
+		} else if err != nil {
+			return nil, err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+
+		i := strings.Index(line, ":")
+		if i < 0 {
+			return nil, &InvalidHeaderError{Header: line}
+		}
+		name, value := line[:i], strings.TrimSpace(line[i+1:])
+		if strings.EqualFold(name, "Content-Length") {
+			length, err = strconv.Atoi(value)
+			if err != nil || length < 0 || length > maxContentLength {
+				return nil, &InvalidHeaderError{Header: line}
+			}
+		}
+	}
+
+	if length < 0 {
+		return nil, &InvalidHeaderError{Header: ""}
+	}
+
+	body := make([]byte, length)
+	_, err := io.ReadFull(s.r, body)
+
+	return body, err
+}
+
+func (s *conn) write(v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(s.w, "Content-Length: %d\r\n\r\n%s", len(body), body)
+
+	return err
+}
+
+func (s *conn) reply(id *json.RawMessage, result interface{}) error {
+	return s.write(&response{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func (s *conn) replyError(id *json.RawMessage, err *ResponseError) error {
+	return s.write(&errorResponse{JSONRPC: "2.0", ID: id, Error: err})
+}
+
+func (s *conn) notify(method string, params interface{}) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	return s.write(&message{JSONRPC: "2.0", Method: method, Params: body})
+}
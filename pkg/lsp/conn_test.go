@@ -0,0 +1,105 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnRead(t *testing.T) {
+	c := newConn(strings.NewReader(
+		"Content-Length: 2\r\n\r\n{}"+
+			"content-type: application/vscode-jsonrpc\r\n"+
+			"content-length: 4\r\n\r\nnull",
+	), nil)
+
+	body, err := c.read()
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(body))
+
+	body, err = c.read()
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(body))
+
+	_, err = c.read()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestConnReadInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{
+			name:  "no colon",
+			input: "Content-Length 2\r\n\r\n{}",
+			err:   &InvalidHeaderError{Header: "Content-Length 2"},
+		},
+		{
+			name:  "bad length",
+			input: "Content-Length: x\r\n\r\n{}",
+			err:   &InvalidHeaderError{Header: "Content-Length: x"},
+		},
+		{
+			name:  "huge length",
+			input: "Content-Length: 9000000000000\r\n\r\n{}",
+			err:   &InvalidHeaderError{Header: "Content-Length: 9000000000000"},
+		},
+		{
+			name:  "length over limit",
+			input: "Content-Length: 8388609\r\n\r\n{}",
+			err:   &InvalidHeaderError{Header: "Content-Length: 8388609"},
+		},
+		{
+			name:  "overflowing length",
+			input: "Content-Length: 99999999999999999999\r\n\r\n{}",
+			err: &InvalidHeaderError{
+				Header: "Content-Length: 99999999999999999999",
+			},
+		},
+		{
+			name:  "no length",
+			input: "Content-Type: text\r\n\r\n{}",
+			err:   &InvalidHeaderError{},
+		},
+		{
+			name:  "short body",
+			input: "Content-Length: 10\r\n\r\n{}",
+			err:   io.ErrUnexpectedEOF,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newConn(strings.NewReader(tt.input), nil).read()
+
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestConnWrite(t *testing.T) {
+	var buf bytes.Buffer
+	c := newConn(nil, &buf)
+	id := json.RawMessage("1")
+
+	require.NoError(t, c.reply(&id, nil))
+	require.NoError(t, c.replyError(&id, &ResponseError{
+		Code: MethodNotFound, Message: "nope",
+	}))
+	require.NoError(t, c.notify("exit", nil))
+
+	assert.Equal(t,
+		"Content-Length: 38\r\n\r\n"+
+			`{"jsonrpc":"2.0","id":1,"result":null}`+
+			"Content-Length: 65\r\n\r\n"+
+			`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"nope"}}`+
+			"Content-Length: 47\r\n\r\n"+
+			`{"jsonrpc":"2.0","method":"exit","params":null}`,
+		buf.String())
+}
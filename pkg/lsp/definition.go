@@ -0,0 +1,46 @@
+package lsp
+
+// Definition returns the statements setting the option named at p, like
+// the @theme-fg within #{@theme-fg}.
+func (s *Document) Definition(p Position) []Location {
+	locations := []Location{}
+
+	line, offset, ok := s.offset(p)
+	if !ok {
+		return locations
+	}
+	name, _, _ := nameAt(line, offset)
+	if !isOptionName(name) {
+		return locations
+	}
+
+	for i := range s.Theme.Statements {
+		st, ok := s.setStatement(i)
+		if ok && st.Option == name && !st.Flags.Unset {
+			locations = append(locations, Location{
+				URI: s.URI, Range: s.optionRange(i),
+			})
+		}
+	}
+
+	return locations
+}
+
+// optionRange returns the range of the option name in the set-option
+// statement at index i, or of the whole statement if it is not on the
+// first line.
+func (s *Document) optionRange(i int) Range {
+	pos := s.Theme.Position(i)
+	st, ok := s.setStatement(i)
+	if !ok || pos.Line < 1 || pos.Line > len(s.Lines) {
+		return s.statementRange(pos)
+	}
+
+	for _, w := range splitWords(s.Lines[pos.Line-1])[1:] {
+		if w.text == st.Option {
+			return s.span(pos.Line-1, w.start, w.end)
+		}
+	}
+
+	return s.statementRange(pos)
+}
@@ -0,0 +1,65 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentDefinition(t *testing.T) {
+	d := newTestDocument(`
+set -g @theme-fg white
+set -gF status-style "fg=#{@theme-fg}"
+set -g @theme-fg \
+  "#eceff4"
+set -gu @theme-fg
+set -go  @theme-fg black
+`)
+
+	want := []Location{
+		{
+			URI: testURI,
+			Range: Range{
+				Start: Position{Line: 0, Character: 7},
+				End:   Position{Line: 0, Character: 16},
+			},
+		},
+		{
+			URI: testURI,
+			Range: Range{
+				Start: Position{Line: 2, Character: 7},
+				End:   Position{Line: 2, Character: 16},
+			},
+		},
+		{
+			URI: testURI,
+			Range: Range{
+				Start: Position{Line: 5, Character: 9},
+				End:   Position{Line: 5, Character: 18},
+			},
+		},
+	}
+
+	assert.Equal(t, want, d.Definition(Position{Line: 1, Character: 30}))
+	assert.Equal(t, want, d.Definition(Position{Line: 0, Character: 7}))
+	assert.Equal(t, []Location{},
+		d.Definition(Position{Line: 1, Character: 23}))
+	assert.Equal(t, []Location{}, d.Definition(Position{Line: 9}))
+}
+
+func TestDocumentOptionRange(t *testing.T) {
+	d := newTestDocument(`
+# comment
+set -g \
+  @a x
+`)
+
+	assert.Equal(t, Range{
+		Start: Position{Line: 0},
+		End:   Position{Line: 0, Character: 9},
+	}, d.optionRange(0))
+	assert.Equal(t, Range{
+		Start: Position{Line: 1},
+		End:   Position{Line: 2, Character: 6},
+	}, d.optionRange(1))
+}
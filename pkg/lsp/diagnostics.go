@@ -0,0 +1,55 @@
+package lsp
+
+import (
+	"github.com/jimeh/go-tmuxtheme/pkg/lint"
+)
+
+const diagnosticSource = "tmuxtheme"
+
+var lintSeverities = map[lint.Severity]DiagnosticSeverity{
+	lint.Info:    SeverityInformation,
+	lint.Warning: SeverityWarning,
+	lint.Error:   SeverityError,
+}
+
+// Diagnostics returns the statements which failed to parse, followed by
+// the findings of linter. The theme is only linted when it executes,
+// otherwise the error is reported at the start of the file.
+func (s *Document) Diagnostics(linter *lint.Linter) []Diagnostic {
+	diags := []Diagnostic{}
+
+	for _, e := range s.parseErrors {
+		diags = append(diags, Diagnostic{
+			Range:    s.statementRange(e.pos),
+			Severity: SeverityError,
+			Source:   diagnosticSource,
+			Message:  e.err.Error(),
+		})
+	}
+
+	if s.ExecuteError != nil {
+		diags = append(diags, Diagnostic{
+			Range:    Range{},
+			Severity: SeverityError,
+			Source:   diagnosticSource,
+			Message:  s.ExecuteError.Error(),
+		})
+		return diags
+	}
+
+	found, err := linter.Lint(s.Theme)
+	if err != nil {
+		return diags
+	}
+	for _, d := range found {
+		diags = append(diags, Diagnostic{
+			Range:    s.statementRange(d.Position),
+			Severity: lintSeverities[d.Severity],
+			Code:     d.Rule,
+			Source:   diagnosticSource,
+			Message:  d.Message,
+		})
+	}
+
+	return diags
+}
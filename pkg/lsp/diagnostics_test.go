@@ -0,0 +1,72 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/lint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentDiagnostics(t *testing.T) {
+	d := newTestDocument(`
+set -g status-style "bg=red"
+bind-key x \
+  kill-pane
+set -g status-style "bg=blue"
+set -g @é "ü"
+set -gF @é "x"
+`)
+
+	config := lint.DefaultConfig()
+	config.Rules["useless-format"] = lint.Error
+	got := d.Diagnostics(lint.New(config))
+
+	assert.Equal(t, []Diagnostic{
+		{
+			Range: Range{
+				Start: Position{Line: 1},
+				End:   Position{Line: 2, Character: 11},
+			},
+			Severity: SeverityError,
+			Source:   "tmuxtheme",
+			Message:  "Unsupported statement: bind-key x   kill-pane",
+		},
+		{
+			Range: Range{
+				Start: Position{Line: 0},
+				End:   Position{Line: 0, Character: 28},
+			},
+			Severity: SeverityWarning,
+			Code:     "dead-set",
+			Source:   "tmuxtheme",
+			Message: "status-style is set again on line 4 before this " +
+				"value is used",
+		},
+		{
+			Range: Range{
+				Start: Position{Line: 4},
+				End:   Position{Line: 4, Character: 13},
+			},
+			Severity: SeverityWarning,
+			Code:     "dead-set",
+			Source:   "tmuxtheme",
+			Message:  "@é is set again on line 6 before this value is used",
+		},
+		{
+			Range: Range{
+				Start: Position{Line: 5},
+				End:   Position{Line: 5, Character: 14},
+			},
+			Severity: SeverityError,
+			Code:     "useless-format",
+			Source:   "tmuxtheme",
+			Message:  "-F is not needed, the value of @é has no formats",
+		},
+	}, got)
+}
+
+func TestDocumentDiagnosticsEmpty(t *testing.T) {
+	d := newTestDocument("")
+
+	assert.Equal(t, []Diagnostic{}, d.Diagnostics(lint.New(nil)))
+}
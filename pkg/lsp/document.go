@@ -0,0 +1,271 @@
+package lsp
+
+import (
+	"net/url"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// Document is an open theme file. Statements which fail to parse are kept
+// as parse errors and left out of Theme, so the rest of the file still
+// gets diagnostics, completion and hover.
+type Document struct {
+	URI   string
+	Lines []string
+
+	// Theme holds the parsed statements, with Lines as its source.
+	Theme *theme.Theme
+
+	// Executed is Theme after executing its statements, or nil if that
+	// failed.
+	Executed *theme.Theme
+
+	// ExecuteError is the error executing Theme, if any.
+	ExecuteError error
+
+	parseErrors []*parseError
+}
+
+type parseError struct {
+	pos theme.Position
+	err error
+}
+
+func NewDocument(uri, text string) *Document {
+	d := &Document{URI: uri, Lines: splitLines(text)}
+	d.parse()
+
+	return d
+}
+
+// Filename returns the path of file URIs, or the URI itself.
+func (s *Document) Filename() string {
+	u, err := url.Parse(s.URI)
+	if err != nil || u.Scheme != "file" {
+		return s.URI
+	}
+
+	return u.Path
+}
+
+func (s *Document) parse() {
+	valid := make([]string, len(s.Lines))
+	copy(valid, s.Lines)
+
+	body, start := "", 0
+	for i, line := range s.Lines {
+		if strings.HasSuffix(line, "\\") {
+			body += line[:len(line)-1]
+			continue
+		}
+		body += line
+
+		if _, err := theme.NewStatement(body); err != nil {
+			s.parseErrors = append(s.parseErrors, &parseError{
+				pos: theme.Position{
+					Filename: s.Filename(), Line: start + 1, EndLine: i + 1,
+				},
+				err: err,
+			})
+			for j := start; j <= i; j++ {
+				valid[j] = ""
+			}
+		}
+		body, start = "", i+1
+	}
+	if start < len(s.Lines) {
+		pos := theme.Position{
+			Filename: s.Filename(), Line: start + 1, EndLine: len(s.Lines),
+		}
+		s.parseErrors = append(s.parseErrors, &parseError{
+			pos: pos,
+			err: &theme.UnfinishedContinuationError{Position: pos},
+		})
+		for j := start; j < len(s.Lines); j++ {
+			valid[j] = ""
+		}
+	}
+
+	t := theme.New()
+	t.Filename = s.Filename()
+	// Every statement left parses, and the trailing continuation has been
+	// blanked out.
+	_ = t.Parse(strings.NewReader(strings.Join(valid, "\n")))
+	t.Lines = s.Lines
+	s.Theme = t
+
+	s.Executed, s.ExecuteError = t.Evaluate(nil)
+}
+
+// splitLines splits text into lines the way bufio.ScanLines does.
+func splitLines(text string) []string {
+	lines := strings.Split(text, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+
+	return lines
+}
+
+// setStatement returns the set-option statement at index i, if it is one.
+func (s *Document) setStatement(i int) (*theme.SetOptionStatement, bool) {
+	if i < 0 || i >= len(s.Theme.Statements) {
+		return nil, false
+	}
+	st, ok := s.Theme.Statements[i].(*theme.SetOptionStatement)
+
+	return st, ok
+}
+
+// statementAt returns the index of the statement covering the zero-based
+// line, or -1.
+func (s *Document) statementAt(line int) int {
+	for i, pos := range s.Theme.Positions {
+		if line+1 >= pos.Line && line+1 <= pos.EndLine {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// offset returns the line at p and the byte offset p points to within it.
+func (s *Document) offset(p Position) (string, int, bool) {
+	if p.Line < 0 || p.Line >= len(s.Lines) {
+		return "", 0, false
+	}
+	line := s.Lines[p.Line]
+
+	units := 0
+	for i, r := range line {
+		if units >= p.Character {
+			return line, i, true
+		}
+		units += utf16.RuneLen(r)
+	}
+
+	return line, len(line), true
+}
+
+// span returns the range of bytes start to end on the zero-based line.
+func (s *Document) span(line, start, end int) Range {
+	text := ""
+	if line >= 0 && line < len(s.Lines) {
+		text = s.Lines[line]
+	}
+
+	return Range{
+		Start: Position{Line: line, Character: character(text, start)},
+		End:   Position{Line: line, Character: character(text, end)},
+	}
+}
+
+// statementRange returns the range of a statement's lines.
+func (s *Document) statementRange(pos theme.Position) Range {
+	end := pos.EndLine - 1
+	length := 0
+	if end >= 0 && end < len(s.Lines) {
+		length = len(s.Lines[end])
+	}
+
+	return Range{
+		Start: Position{Line: pos.Line - 1},
+		End:   s.span(end, length, length).End,
+	}
+}
+
+// character converts a byte offset within line to UTF-16 code units, which
+// LSP positions count in.
+func character(line string, offset int) int {
+	if offset > len(line) {
+		offset = len(line)
+	}
+
+	return len(utf16.Encode([]rune(line[:offset])))
+}
+
+// word is a whitespace separated argument of a command line, with quotes
+// kept.
+type word struct {
+	start int
+	end   int
+	text  string
+}
+
+// splitWords splits a command line on whitespace outside quotes.
+func splitWords(line string) []word {
+	words := []word{}
+
+	start, quote := -1, byte(0)
+	for i := 0; i <= len(line); i++ {
+		var c byte
+		if i < len(line) {
+			c = line[i]
+		}
+
+		switch {
+		case quote != 0 && i < len(line):
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' && i+1 < len(line) {
+				i++
+			}
+		case i == len(line) || c == ' ' || c == '\t':
+			if start >= 0 {
+				words = append(words, word{start, i, line[start:i]})
+				start = -1
+			}
+		default:
+			if start < 0 {
+				start = i
+			}
+			if c == '"' || c == '\'' {
+				quote = c
+			} else if c == '\\' && i+1 < len(line) {
+				i++
+			}
+		}
+	}
+
+	return words
+}
+
+// optionChars are the characters option names are made of.
+const optionChars = "abcdefghijklmnopqrstuvwxyz" +
+	"ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789@_-"
+
+// nameAt returns the option name around offset in line, and its bounds.
+func nameAt(line string, offset int) (string, int, int) {
+	start, end := offset, offset
+	for start > 0 && strings.IndexByte(optionChars, line[start-1]) >= 0 {
+		start--
+	}
+	for end < len(line) && strings.IndexByte(optionChars, line[end]) >= 0 {
+		end++
+	}
+
+	name := line[start:end]
+	if i := strings.LastIndexByte(name, '@'); i > 0 {
+		if start+i > offset {
+			return "", offset, offset
+		}
+		name, start = name[i:], start+i
+	}
+
+	return name, start, end
+}
+
+// isOptionName reports whether name is a user option or built-in option.
+func isOptionName(name string) bool {
+	if strings.HasPrefix(name, "@") {
+		return len(name) > 1
+	}
+	_, ok := theme.LookupOptionDefinition(name)
+
+	return ok
+}
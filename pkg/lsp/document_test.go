@@ -0,0 +1,143 @@
+package lsp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testURI = "file:///home/user/theme.tmuxtheme"
+
+func newTestDocument(body string) *Document {
+	return NewDocument(testURI, strings.TrimLeft(body, "\n"))
+}
+
+func TestNewDocument(t *testing.T) {
+	d := newTestDocument(`
+# Colours
+set -g @fg white
+bind-key x kill-pane
+set -g status-style \
+  "fg=#{@fg}"
+`)
+
+	assert.Equal(t, "/home/user/theme.tmuxtheme", d.Filename())
+	assert.Equal(t, "/home/user/theme.tmuxtheme", d.Theme.Filename)
+	assert.Equal(t, d.Lines, d.Theme.Lines)
+	assert.Len(t, d.Theme.Statements, 4)
+	assert.Equal(t, theme.Position{
+		Filename: "/home/user/theme.tmuxtheme", Line: 4, EndLine: 5,
+	}, d.Theme.Position(3))
+
+	require.Len(t, d.parseErrors, 1)
+	assert.Equal(t, 3, d.parseErrors[0].pos.Line)
+	assert.Equal(t, "Unsupported statement: bind-key x kill-pane",
+		d.parseErrors[0].err.Error())
+
+	require.NotNil(t, d.Executed)
+	assert.Equal(t, "white", d.Executed.GlobalSessionOptions["@fg"])
+	assert.NoError(t, d.ExecuteError)
+}
+
+func TestNewDocumentUnfinishedContinuation(t *testing.T) {
+	d := newTestDocument(`
+set -g @fg white
+set -g @bg \
+  black \
+`)
+
+	assert.Len(t, d.Theme.Statements, 2)
+	require.Len(t, d.parseErrors, 1)
+	assert.Equal(t, 2, d.parseErrors[0].pos.Line)
+	assert.Equal(t, 3, d.parseErrors[0].pos.EndLine)
+	assert.IsType(t, &theme.UnfinishedContinuationError{},
+		d.parseErrors[0].err)
+
+	require.NotNil(t, d.Executed)
+	assert.Equal(t, "white", d.Executed.GlobalSessionOptions["@fg"])
+}
+
+func TestDocumentFilename(t *testing.T) {
+	assert.Equal(t, "/a b/t.tmuxtheme",
+		NewDocument("file:///a%20b/t.tmuxtheme", "").Filename())
+	assert.Equal(t, "untitled:Untitled-1",
+		NewDocument("untitled:Untitled-1", "").Filename())
+}
+
+func TestSplitLines(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", ""}, splitLines("a\r\nb\n\n"))
+	assert.Equal(t, []string{"a", "b"}, splitLines("a\nb"))
+	assert.Equal(t, []string{}, splitLines(""))
+}
+
+func TestDocumentOffset(t *testing.T) {
+	d := newTestDocument("set -g @a \"€😀x\"\n")
+
+	tests := []struct {
+		pos    Position
+		offset int
+		ok     bool
+	}{
+		{pos: Position{Line: 0, Character: 4}, offset: 4, ok: true},
+		{pos: Position{Line: 0, Character: 11}, offset: 11, ok: true},
+		{pos: Position{Line: 0, Character: 12}, offset: 14, ok: true},
+		{pos: Position{Line: 0, Character: 14}, offset: 18, ok: true},
+		{pos: Position{Line: 0, Character: 99}, offset: 20, ok: true},
+		{pos: Position{Line: 1}, ok: false},
+	}
+	for _, tt := range tests {
+		_, offset, ok := d.offset(tt.pos)
+
+		assert.Equal(t, tt.ok, ok, "%+v", tt.pos)
+		assert.Equal(t, tt.offset, offset, "%+v", tt.pos)
+	}
+
+	assert.Equal(t, 14, character(d.Lines[0], 18))
+}
+
+func TestSplitWords(t *testing.T) {
+	texts := func(line string) []string {
+		out := []string{}
+		for _, w := range splitWords(line) {
+			assert.Equal(t, w.text, line[w.start:w.end])
+			out = append(out, w.text)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"set", "-g", "@a", `"fg=red bold"`},
+		texts(`set  -g	@a "fg=red bold"`))
+	assert.Equal(t, []string{"set", `'a "b'`, `"c \" d"`, `e\ f`, `\`},
+		texts(`set 'a "b' "c \" d" e\ f \`))
+	assert.Equal(t, []string{"set", `"open`}, texts(`set "open`))
+	assert.Equal(t, []string{}, texts("  "))
+}
+
+func TestNameAt(t *testing.T) {
+	line := `set -gF status-left "#{@theme-fg}#{@theme-bg}"`
+
+	tests := []struct {
+		offset int
+		name   string
+	}{
+		{offset: 9, name: "status-left"},
+		{offset: 24, name: "@theme-fg"},
+		{offset: 32, name: "@theme-fg"},
+		{offset: 36, name: "@theme-bg"},
+		{offset: 21, name: ""},
+	}
+	for _, tt := range tests {
+		name, start, end := nameAt(line, tt.offset)
+
+		assert.Equal(t, tt.name, name, "offset %d", tt.offset)
+		assert.Equal(t, tt.name, line[start:end], "offset %d", tt.offset)
+	}
+
+	assert.True(t, isOptionName("@a"))
+	assert.True(t, isOptionName("status-left"))
+	assert.False(t, isOptionName("@"))
+	assert.False(t, isOptionName("set"))
+}
@@ -0,0 +1,9 @@
+package lsp
+
+// ExitError is returned by Run when the client sends exit without first
+// requesting shutdown, which should make the server exit with status 1.
+type ExitError struct{}
+
+func (s *ExitError) Error() string {
+	return "Exit notification received before shutdown"
+}
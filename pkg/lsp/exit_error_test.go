@@ -0,0 +1,17 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &ExitError{})
+}
+
+func TestExitErrorError(t *testing.T) {
+	err := &ExitError{}
+
+	assert.Equal(t, "Exit notification received before shutdown", err.Error())
+}
@@ -0,0 +1,33 @@
+package lsp
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Formatting returns an edit rewriting the document the way Theme.Write
+// writes themes, or no edits if it is already formatted. Documents with
+// parse errors are left alone, as their statements can't be written back.
+func (s *Document) Formatting() []TextEdit {
+	if len(s.parseErrors) > 0 || len(s.Lines) == 0 {
+		return []TextEdit{}
+	}
+
+	var buf bytes.Buffer
+	if err := s.Theme.Write(&buf); err != nil {
+		return []TextEdit{}
+	}
+	text := strings.TrimSuffix(buf.String(), "\n")
+	if text == strings.Join(s.Lines, "\n") {
+		return []TextEdit{}
+	}
+
+	last := len(s.Lines) - 1
+
+	return []TextEdit{{
+		Range: Range{
+			End: s.span(last, len(s.Lines[last]), len(s.Lines[last])).End,
+		},
+		NewText: text,
+	}}
+}
@@ -0,0 +1,44 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentFormatting(t *testing.T) {
+	var tests = []struct {
+		body  string
+		edits []TextEdit
+	}{
+		{
+			body: "set  -g status-left  '#S'\n\n# Ünïcode\nset -wg @a x\n",
+			edits: []TextEdit{{
+				Range: Range{End: Position{Line: 3, Character: 12}},
+				NewText: "set -g status-left \"#S\"\n\n" +
+					"# Ünïcode\nset -gw @a x",
+			}},
+		},
+		{
+			body: "set -g @a \\\n  x",
+			edits: []TextEdit{{
+				Range:   Range{End: Position{Line: 1, Character: 3}},
+				NewText: "set -g @a x",
+			}},
+		},
+		{body: "set -g status-left \"#S\"\n", edits: []TextEdit{}},
+		{
+			body:  "set -goqF @a \"#{@b}\"\nset -goq @b \"\"\nset -gF @c x\n",
+			edits: []TextEdit{},
+		},
+		{body: "", edits: []TextEdit{}},
+		{body: "set  -g @a x\nset -x\n", edits: []TextEdit{}},
+		{body: "set -g @a \\\n", edits: []TextEdit{}},
+	}
+
+	for _, tt := range tests {
+		d := NewDocument(testURI, tt.body)
+
+		assert.Equal(t, tt.edits, d.Formatting(), tt.body)
+	}
+}
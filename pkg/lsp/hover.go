@@ -0,0 +1,91 @@
+package lsp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// Hover describes the option named at p: the type, default and choices of
+// built-in options, and the value the theme resolves it to along with the
+// line which set it.
+func (s *Document) Hover(p Position) *Hover {
+	line, offset, ok := s.offset(p)
+	if !ok {
+		return nil
+	}
+	name, start, end := nameAt(line, offset)
+	if !isOptionName(name) {
+		return nil
+	}
+
+	parts := []string{}
+	if def, ok := theme.LookupOptionDefinition(name); ok {
+		parts = append(parts,
+			fmt.Sprintf("**%s** %s", name, scopeNames[def.Scope]),
+			"Type: "+def.Type.String())
+		if def.Default != "" {
+			parts = append(parts, "Default: "+code(def.Default))
+		}
+		if len(def.Choices) > 0 {
+			choices := []string{}
+			for _, c := range def.Choices {
+				choices = append(choices, code(c))
+			}
+			parts = append(parts, "Choices: "+strings.Join(choices, ", "))
+		}
+	} else {
+		parts = append(parts, fmt.Sprintf("**%s** user option", name))
+	}
+	parts = append(parts, s.resolved(name)...)
+
+	r := s.span(p.Line, start, end)
+
+	return &Hover{
+		Contents: MarkupContent{
+			Kind:  "markdown",
+			Value: strings.Join(parts, "\n\n"),
+		},
+		Range: &r,
+	}
+}
+
+// resolved describes the value the executed theme gives name, as #{name}
+// would expand outside any particular session or window.
+func (s *Document) resolved(name string) []string {
+	if s.Executed == nil {
+		return nil
+	}
+
+	v := s.Executed.TraceLookup(name, "", "").Result
+	if v == nil {
+		return []string{"Not set in this theme"}
+	}
+	if v.Default {
+		return nil
+	}
+
+	parts := []string{"Value: " + code(v.Value)}
+	p := s.Executed.Provenance(v.Scope, v.Target, name)
+	if p != nil && len(p.Assignments) > 0 {
+		pos := p.Assignments[len(p.Assignments)-1].Position
+		parts = append(parts,
+			fmt.Sprintf("Set on line %d in the %s scope", pos.Line, v.Scope))
+	}
+
+	return parts
+}
+
+// code formats value as a Markdown code span.
+func code(value string) string {
+	fence := "`"
+	for strings.Contains(value, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(value, "`") || strings.HasSuffix(value, "`") {
+		return fence + " " + value + " " + fence
+	}
+
+	return fence + value + fence
+}
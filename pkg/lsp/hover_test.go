@@ -0,0 +1,100 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentHover(t *testing.T) {
+	d := newTestDocument(`
+set -g @theme-fg white
+set -g @theme-fg "#eceff4"
+set -gF status-left-style "fg=#{@theme-fg},#{@missing}"
+set -g status-position top
+set -g mouse on
+`)
+
+	tests := []struct {
+		pos  Position
+		want string
+		rng  Range
+	}{
+		{
+			pos: Position{Line: 1, Character: 10},
+			want: "**@theme-fg** user option\n\n" +
+				"Value: `#eceff4`\n\n" +
+				"Set on line 2 in the global-session scope",
+			rng: Range{
+				Start: Position{Line: 1, Character: 7},
+				End:   Position{Line: 1, Character: 16},
+			},
+		},
+		{
+			pos: Position{Line: 2, Character: 36},
+			want: "**@theme-fg** user option\n\n" +
+				"Value: `#eceff4`\n\n" +
+				"Set on line 2 in the global-session scope",
+			rng: Range{
+				Start: Position{Line: 2, Character: 32},
+				End:   Position{Line: 2, Character: 41},
+			},
+		},
+		{
+			pos:  Position{Line: 2, Character: 46},
+			want: "**@missing** user option\n\nNot set in this theme",
+			rng: Range{
+				Start: Position{Line: 2, Character: 45},
+				End:   Position{Line: 2, Character: 53},
+			},
+		},
+		{
+			pos: Position{Line: 3, Character: 8},
+			want: "**status-position** session option\n\n" +
+				"Type: choice\n\n" +
+				"Default: `bottom`\n\n" +
+				"Choices: `top`, `bottom`\n\n" +
+				"Value: `top`\n\n" +
+				"Set on line 4 in the global-session scope",
+			rng: Range{
+				Start: Position{Line: 3, Character: 7},
+				End:   Position{Line: 3, Character: 22},
+			},
+		},
+	}
+	for _, tt := range tests {
+		got := d.Hover(tt.pos)
+		require.NotNil(t, got, "%+v", tt.pos)
+
+		assert.Equal(t, "markdown", got.Contents.Kind)
+		assert.Equal(t, tt.want, got.Contents.Value, "%+v", tt.pos)
+		assert.Equal(t, &tt.rng, got.Range, "%+v", tt.pos)
+	}
+
+	assert.Nil(t, d.Hover(Position{Line: 0, Character: 1}))
+	assert.Nil(t, d.Hover(Position{Line: 4, Character: 15}))
+	assert.Nil(t, d.Hover(Position{Line: 9}))
+}
+
+func TestDocumentHoverDefault(t *testing.T) {
+	d := newTestDocument("set -g status-left-style \"\"\n")
+
+	got := d.Hover(Position{Line: 0, Character: 8})
+	require.NotNil(t, got)
+
+	assert.Equal(t, "**status-left-style** session option\n\n"+
+		"Type: style\n\n"+
+		"Default: `default`\n\n"+
+		"Value: ``\n\n"+
+		"Set on line 1 in the global-session scope", got.Contents.Value)
+
+	got = d.Hover(Position{Line: 0, Character: 5})
+	assert.Nil(t, got)
+}
+
+func TestCode(t *testing.T) {
+	assert.Equal(t, "`a`", code("a"))
+	assert.Equal(t, "``a`b``", code("a`b"))
+	assert.Equal(t, "`` `a ``", code("`a"))
+}
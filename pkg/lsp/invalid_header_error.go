@@ -0,0 +1,12 @@
+package lsp
+
+import "fmt"
+
+// InvalidHeaderError is returned when a message header cannot be read.
+type InvalidHeaderError struct {
+	Header string
+}
+
+func (s *InvalidHeaderError) Error() string {
+	return fmt.Sprintf("Invalid message header: %q", s.Header)
+}
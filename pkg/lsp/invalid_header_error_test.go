@@ -0,0 +1,18 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidHeaderErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &InvalidHeaderError{})
+}
+
+func TestInvalidHeaderErrorError(t *testing.T) {
+	err := &InvalidHeaderError{Header: "Content-Length: x"}
+
+	assert.Equal(t, `Invalid message header: "Content-Length: x"`,
+		err.Error())
+}
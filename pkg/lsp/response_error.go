@@ -0,0 +1,22 @@
+package lsp
+
+import "fmt"
+
+// JSON-RPC error codes.
+const (
+	ParseError     = -32700
+	InvalidRequest = -32600
+	MethodNotFound = -32601
+	InvalidParams  = -32602
+	InternalError  = -32603
+)
+
+// ResponseError is the error member of a JSON-RPC response.
+type ResponseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (s *ResponseError) Error() string {
+	return fmt.Sprintf("Request failed with code %d: %s", s.Code, s.Message)
+}
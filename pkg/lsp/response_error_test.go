@@ -0,0 +1,18 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &ResponseError{})
+}
+
+func TestResponseErrorError(t *testing.T) {
+	err := &ResponseError{Code: MethodNotFound, Message: "Unknown method"}
+
+	assert.Equal(t, "Request failed with code -32601: Unknown method",
+		err.Error())
+}
@@ -0,0 +1,74 @@
+package lsp
+
+import (
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// semanticTokenTypes maps token kinds to the LSP token types listed in the
+// legend. Text tokens are left unhighlighted.
+var semanticTokenTypes = []struct {
+	kind theme.TokenKind
+	name string
+}{
+	{theme.CommandToken, "keyword"},
+	{theme.FlagToken, "parameter"},
+	{theme.OptionToken, "property"},
+	{theme.UserOptionToken, "variable"},
+	{theme.StringToken, "string"},
+	{theme.FormatToken, "macro"},
+	{theme.StyleToken, "decorator"},
+	{theme.ColourToken, "number"},
+	{theme.CommentToken, "comment"},
+	{theme.ContinuationToken, "operator"},
+}
+
+func semanticTokensLegend() SemanticTokensLegend {
+	legend := SemanticTokensLegend{TokenModifiers: []string{}}
+	for _, t := range semanticTokenTypes {
+		legend.TokenTypes = append(legend.TokenTypes, t.name)
+	}
+
+	return legend
+}
+
+// SemanticTokens returns the document's tokens, as classified by
+// theme.Tokenize, encoded relative to each other.
+func (s *Document) SemanticTokens() *SemanticTokens {
+	types := map[theme.TokenKind]int{}
+	for i, t := range semanticTokenTypes {
+		types[t.kind] = i
+	}
+
+	src := strings.Join(s.Lines, "\n")
+	starts := []int{0}
+	for i := 0; i < len(src); i++ {
+		if src[i] == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+
+	data := []int{}
+	line, prevLine, prevChar := 0, 0, 0
+	for _, tok := range theme.Tokenize(src) {
+		kind, ok := types[tok.Kind]
+		if !ok {
+			continue
+		}
+		for line+1 < len(starts) && starts[line+1] <= tok.Start {
+			line++
+		}
+
+		r := s.span(line, tok.Start-starts[line], tok.End-starts[line])
+		char := r.Start.Character
+		if line != prevLine {
+			prevChar = 0
+		}
+		data = append(data, line-prevLine, char-prevChar,
+			r.End.Character-char, kind, 0)
+		prevLine, prevChar = line, char
+	}
+
+	return &SemanticTokens{Data: data}
+}
@@ -0,0 +1,38 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentSemanticTokens(t *testing.T) {
+	d := newTestDocument(`
+# é
+set -g @é \
+  red
+set -t x status-bg "€"
+`)
+
+	assert.Equal(t, &SemanticTokens{Data: []int{
+		0, 0, 3, 8, 0, // # é
+		1, 0, 3, 0, 0, // set
+		0, 4, 2, 1, 0, // -g
+		0, 3, 2, 3, 0, // @é
+		0, 3, 1, 9, 0, // \
+		2, 0, 3, 0, 0, // set
+		0, 4, 2, 1, 0, // -t
+		0, 5, 9, 2, 0, // status-bg
+		0, 10, 1, 4, 0, // "
+		0, 1, 1, 4, 0, // €
+		0, 1, 1, 4, 0, // "
+	}}, d.SemanticTokens())
+}
+
+func TestSemanticTokensLegend(t *testing.T) {
+	legend := semanticTokensLegend()
+
+	assert.Len(t, legend.TokenTypes, len(semanticTokenTypes))
+	assert.Equal(t, "keyword", legend.TokenTypes[0])
+	assert.Equal(t, []string{}, legend.TokenModifiers)
+}
@@ -0,0 +1,329 @@
+// Package lsp implements a Language Server Protocol server for theme files,
+// speaking JSON-RPC over a pair of streams such as stdio.
+package lsp
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/lint"
+)
+
+type handler func(s *Server, params json.RawMessage) (interface{}, error)
+
+var requestHandlers = map[string]handler{
+	"initialize":                     (*Server).initialize,
+	"shutdown":                       (*Server).shutdown,
+	"textDocument/completion":        (*Server).completion,
+	"textDocument/hover":             (*Server).hover,
+	"textDocument/definition":        (*Server).definition,
+	"textDocument/documentSymbol":    (*Server).documentSymbol,
+	"textDocument/documentColor":     (*Server).documentColor,
+	"textDocument/colorPresentation": (*Server).colorPresentation,
+	"textDocument/formatting":        (*Server).formatting,
+
+	"textDocument/semanticTokens/full": (*Server).semanticTokens,
+}
+
+var notificationHandlers = map[string]handler{
+	"textDocument/didOpen":   (*Server).didOpen,
+	"textDocument/didChange": (*Server).didChange,
+	"textDocument/didClose":  (*Server).didClose,
+}
+
+// Server answers requests for the documents a client opens. Diagnostics
+// are published whenever a document is opened or changed.
+type Server struct {
+	conn     *conn
+	docs     map[string]*Document
+	linter   *lint.Linter
+	shutDown bool
+}
+
+func NewServer(r io.Reader, w io.Writer) *Server {
+	return &Server{
+		conn:   newConn(r, w),
+		docs:   map[string]*Document{},
+		linter: lint.New(nil),
+	}
+}
+
+// Run serves requests until the client sends exit or closes the input. An
+// ExitError is returned if exit was not preceded by shutdown.
+func (s *Server) Run() error {
+	for {
+		body, err := s.conn.read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		msg := &message{}
+		if err := json.Unmarshal(body, msg); err != nil {
+			err = s.conn.replyError(nil, &ResponseError{
+				Code: ParseError, Message: err.Error(),
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if msg.Method == "exit" {
+			if !s.shutDown {
+				return &ExitError{}
+			}
+			return nil
+		}
+
+		if err := s.handle(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(msg *message) error {
+	if msg.ID == nil {
+		if h, ok := notificationHandlers[msg.Method]; ok && !s.shutDown {
+			_, err := h(s, msg.Params)
+			return err
+		}
+		return nil
+	}
+
+	h, ok := requestHandlers[msg.Method]
+	switch {
+	case s.shutDown:
+		return s.conn.replyError(msg.ID, &ResponseError{
+			Code: InvalidRequest, Message: "Server is shutting down",
+		})
+	case !ok:
+		return s.conn.replyError(msg.ID, &ResponseError{
+			Code: MethodNotFound, Message: "Unknown method " + msg.Method,
+		})
+	}
+
+	result, err := h(s, msg.Params)
+	if err != nil {
+		rerr, ok := err.(*ResponseError)
+		if !ok {
+			rerr = &ResponseError{Code: InternalError, Message: err.Error()}
+		}
+		return s.conn.replyError(msg.ID, rerr)
+	}
+
+	return s.conn.reply(msg.ID, result)
+}
+
+func decode(params json.RawMessage, v interface{}) error {
+	if err := json.Unmarshal(params, v); err != nil {
+		return &ResponseError{Code: InvalidParams, Message: err.Error()}
+	}
+
+	return nil
+}
+
+// initialize loads the lint config from the root of the workspace, if the
+// client opened one which has it.
+func (s *Server) initialize(params json.RawMessage) (interface{}, error) {
+	p := &InitializeParams{}
+	if err := decode(params, p); err != nil {
+		return nil, err
+	}
+
+	if u, err := url.Parse(p.RootURI); err == nil && u.Scheme == "file" {
+		filename := filepath.Join(u.Path, lint.ConfigFilename)
+		if _, err := os.Stat(filename); err == nil {
+			config, err := lint.LoadConfig(filename)
+			if err != nil {
+				return nil, err
+			}
+			s.linter = lint.New(config)
+		}
+	}
+
+	return &InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync: TextDocumentSyncFull,
+			CompletionProvider: CompletionOptions{
+				TriggerCharacters: []string{"-", "@"},
+			},
+			HoverProvider:              true,
+			DefinitionProvider:         true,
+			DocumentSymbolProvider:     true,
+			ColorProvider:              true,
+			DocumentFormattingProvider: true,
+			SemanticTokensProvider: SemanticTokensOptions{
+				Legend: semanticTokensLegend(),
+				Full:   true,
+			},
+		},
+		ServerInfo: ServerInfo{Name: "tmuxtheme"},
+	}, nil
+}
+
+func (s *Server) shutdown(params json.RawMessage) (interface{}, error) {
+	s.shutDown = true
+
+	return nil, nil
+}
+
+func (s *Server) didOpen(params json.RawMessage) (interface{}, error) {
+	p := &DidOpenTextDocumentParams{}
+	if err := decode(params, p); err != nil {
+		return nil, nil
+	}
+
+	return nil, s.update(p.TextDocument.URI, p.TextDocument.Text)
+}
+
+func (s *Server) didChange(params json.RawMessage) (interface{}, error) {
+	p := &DidChangeTextDocumentParams{}
+	if err := decode(params, p); err != nil || len(p.ContentChanges) == 0 {
+		return nil, nil
+	}
+	changes := p.ContentChanges
+
+	return nil, s.update(p.TextDocument.URI, changes[len(changes)-1].Text)
+}
+
+func (s *Server) didClose(params json.RawMessage) (interface{}, error) {
+	p := &DidCloseTextDocumentParams{}
+	if err := decode(params, p); err != nil {
+		return nil, nil
+	}
+	delete(s.docs, p.TextDocument.URI)
+
+	return nil, s.conn.notify("textDocument/publishDiagnostics",
+		&PublishDiagnosticsParams{
+			URI: p.TextDocument.URI, Diagnostics: []Diagnostic{},
+		})
+}
+
+func (s *Server) update(uri, text string) error {
+	d := NewDocument(uri, text)
+	s.docs[uri] = d
+
+	return s.conn.notify("textDocument/publishDiagnostics",
+		&PublishDiagnosticsParams{
+			URI: uri, Diagnostics: d.Diagnostics(s.linter),
+		})
+}
+
+// document returns the open document a request is for.
+func (s *Server) document(uri string) (*Document, error) {
+	d, ok := s.docs[uri]
+	if !ok {
+		return nil, &ResponseError{
+			Code: InvalidParams, Message: "Document is not open: " + uri,
+		}
+	}
+
+	return d, nil
+}
+
+func (s *Server) positionRequest(
+	params json.RawMessage,
+) (*Document, Position, error) {
+	p := &TextDocumentPositionParams{}
+	if err := decode(params, p); err != nil {
+		return nil, Position{}, err
+	}
+	d, err := s.document(p.TextDocument.URI)
+
+	return d, p.Position, err
+}
+
+func (s *Server) completion(params json.RawMessage) (interface{}, error) {
+	d, pos, err := s.positionRequest(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Completion(pos), nil
+}
+
+func (s *Server) hover(params json.RawMessage) (interface{}, error) {
+	d, pos, err := s.positionRequest(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Hover(pos), nil
+}
+
+func (s *Server) definition(params json.RawMessage) (interface{}, error) {
+	d, pos, err := s.positionRequest(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Definition(pos), nil
+}
+
+func (s *Server) documentSymbol(params json.RawMessage) (interface{}, error) {
+	p := &DocumentSymbolParams{}
+	if err := decode(params, p); err != nil {
+		return nil, err
+	}
+	d, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Symbols(), nil
+}
+
+func (s *Server) documentColor(params json.RawMessage) (interface{}, error) {
+	p := &DocumentColorParams{}
+	if err := decode(params, p); err != nil {
+		return nil, err
+	}
+	d, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Colours(), nil
+}
+
+func (s *Server) colorPresentation(
+	params json.RawMessage,
+) (interface{}, error) {
+	p := &ColorPresentationParams{}
+	if err := decode(params, p); err != nil {
+		return nil, err
+	}
+
+	return ColourPresentations(p.Color, p.Range), nil
+}
+
+func (s *Server) formatting(params json.RawMessage) (interface{}, error) {
+	p := &DocumentFormattingParams{}
+	if err := decode(params, p); err != nil {
+		return nil, err
+	}
+	d, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Formatting(), nil
+}
+
+func (s *Server) semanticTokens(params json.RawMessage) (interface{}, error) {
+	p := &SemanticTokensParams{}
+	if err := decode(params, p); err != nil {
+		return nil, err
+	}
+	d, err := s.document(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.SemanticTokens(), nil
+}
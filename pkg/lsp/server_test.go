@@ -0,0 +1,222 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// frame returns messages framed as a client would send them.
+func frame(messages ...string) string {
+	var b strings.Builder
+	for _, m := range messages {
+		fmt.Fprintf(&b, "Content-Length: %d\r\n\r\n%s", len(m), m)
+	}
+
+	return b.String()
+}
+
+// serve runs a server with the framed messages as input, returning the
+// messages written and the error from Run.
+func serve(t *testing.T, messages ...string) ([]string, error) {
+	var out bytes.Buffer
+	err := NewServer(strings.NewReader(frame(messages...)), &out).Run()
+
+	c := newConn(&out, nil)
+	replies := []string{}
+	for {
+		body, rerr := c.read()
+		if rerr == io.EOF {
+			break
+		}
+		require.NoError(t, rerr)
+		replies = append(replies, string(body))
+	}
+
+	return replies, err
+}
+
+func TestServerRun(t *testing.T) {
+	open, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "textDocument/didOpen",
+		"params": map[string]interface{}{
+			"textDocument": map[string]interface{}{
+				"uri": "file:///t.tmuxtheme", "languageId": "tmux",
+				"version": 1, "text": "set -gF @a x\nset -g @b \"#{@a}\"\n",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	replies, err := serve(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"initialized","params":{}}`,
+		string(open),
+		`{"jsonrpc":"2.0","id":2,"method":"textDocument/definition",`+
+			`"params":{"textDocument":{"uri":"file:///t.tmuxtheme"},`+
+			`"position":{"line":1,"character":13}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"textDocument/hover",`+
+			`"params":{"textDocument":{"uri":"file:///t.tmuxtheme"},`+
+			`"position":{"line":1,"character":1}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"textDocument/documentSymbol",`+
+			`"params":{"textDocument":{"uri":"file:///other"}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"textDocument/rename",`+
+			`"params":{}}`,
+		`{"jsonrpc":"2.0","method":"textDocument/didChange",`+
+			`"params":{"textDocument":{"uri":"file:///t.tmuxtheme"},`+
+			`"contentChanges":[{"text":"set -g @a x\n"}]}}`,
+		`{"jsonrpc":"2.0","method":"textDocument/didClose",`+
+			`"params":{"textDocument":{"uri":"file:///t.tmuxtheme"}}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","id":7,"method":"textDocument/hover","params":{}}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+		`{"jsonrpc":"2.0","id":8,"method":"shutdown"}`,
+	)
+	require.NoError(t, err)
+	require.Len(t, replies, 10)
+
+	assert.Equal(t, `{"jsonrpc":"2.0","id":1,"result":{"capabilities":{`+
+		`"textDocumentSync":1,"completionProvider":{"triggerCharacters":`+
+		`["-","@"]},"hoverProvider":true,"definitionProvider":true,`+
+		`"documentSymbolProvider":true,"colorProvider":true,`+
+		`"documentFormattingProvider":true,`+
+		`"semanticTokensProvider":{"legend":{"tokenTypes":["keyword",`+
+		`"parameter","property","variable","string","macro","decorator",`+
+		`"number","comment","operator"],"tokenModifiers":[]},`+
+		`"full":true}},`+
+		`"serverInfo":{"name":"tmuxtheme"}}}`, replies[0])
+	assert.Equal(t, `{"jsonrpc":"2.0","method":"textDocument/publishDiagnostics",`+
+		`"params":{"uri":"file:///t.tmuxtheme","diagnostics":[{"range":{`+
+		`"start":{"line":0,"character":0},"end":{"line":0,"character":12}},`+
+		`"severity":3,"code":"useless-format","source":"tmuxtheme",`+
+		`"message":"-F is not needed, the value of @a has no formats"}]}}`,
+		replies[1])
+	assert.Equal(t, `{"jsonrpc":"2.0","id":2,"result":[{`+
+		`"uri":"file:///t.tmuxtheme","range":{`+
+		`"start":{"line":0,"character":8},"end":{"line":0,"character":10}}`+
+		`}]}`, replies[2])
+	assert.Equal(t, `{"jsonrpc":"2.0","id":3,"result":null}`, replies[3])
+	assert.Equal(t, `{"jsonrpc":"2.0","id":4,"error":{"code":-32602,`+
+		`"message":"Document is not open: file:///other"}}`, replies[4])
+	assert.Equal(t, `{"jsonrpc":"2.0","id":5,"error":{"code":-32601,`+
+		`"message":"Unknown method textDocument/rename"}}`, replies[5])
+	assert.Equal(t, `{"jsonrpc":"2.0","method":"textDocument/publishDiagnostics",`+
+		`"params":{"uri":"file:///t.tmuxtheme","diagnostics":[]}}`,
+		replies[6])
+	assert.Equal(t, replies[6], replies[7])
+	assert.Equal(t, `{"jsonrpc":"2.0","id":6,"result":null}`, replies[8])
+	assert.Equal(t, `{"jsonrpc":"2.0","id":7,"error":{"code":-32600,`+
+		`"message":"Server is shutting down"}}`, replies[9])
+}
+
+func TestServerFormatting(t *testing.T) {
+	replies, err := serve(t,
+		`{"jsonrpc":"2.0","method":"textDocument/didOpen",`+
+			`"params":{"textDocument":{"uri":"file:///t.tmuxtheme",`+
+			`"languageId":"tmux","version":1,`+
+			`"text":"set  -wg @a 'x'\nset -g @b y\n"}}}`,
+		`{"jsonrpc":"2.0","id":1,"method":"textDocument/formatting",`+
+			`"params":{"textDocument":{"uri":"file:///t.tmuxtheme"},`+
+			`"options":{"tabSize":2,"insertSpaces":true}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"textDocument/formatting",`+
+			`"params":{"textDocument":{"uri":"file:///other"}}}`,
+	)
+	require.NoError(t, err)
+	require.Len(t, replies, 3)
+
+	assert.Equal(t, `{"jsonrpc":"2.0","id":1,"result":[{"range":{`+
+		`"start":{"line":0,"character":0},"end":{"line":1,"character":11}},`+
+		`"newText":"set -gw @a x\nset -g @b y"}]}`, replies[1])
+	assert.Equal(t, `{"jsonrpc":"2.0","id":2,"error":{"code":-32602,`+
+		`"message":"Document is not open: file:///other"}}`, replies[2])
+}
+
+func TestServerSemanticTokens(t *testing.T) {
+	replies, err := serve(t,
+		`{"jsonrpc":"2.0","method":"textDocument/didOpen",`+
+			`"params":{"textDocument":{"uri":"file:///t.tmuxtheme",`+
+			`"languageId":"tmux","version":1,`+
+			`"text":"# é\nset -g @a x\n"}}}`,
+		`{"jsonrpc":"2.0","id":1,"method":"textDocument/semanticTokens/full",`+
+			`"params":{"textDocument":{"uri":"file:///t.tmuxtheme"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"textDocument/semanticTokens/full",`+
+			`"params":{"textDocument":{"uri":"file:///other"}}}`,
+	)
+	require.NoError(t, err)
+	require.Len(t, replies, 3)
+
+	assert.Equal(t, `{"jsonrpc":"2.0","id":1,"result":{"data":[`+
+		`0,0,3,8,0,1,0,3,0,0,0,4,2,1,0,0,3,2,3,0]}}`, replies[1])
+	assert.Equal(t, `{"jsonrpc":"2.0","id":2,"error":{"code":-32602,`+
+		`"message":"Document is not open: file:///other"}}`, replies[2])
+}
+
+func TestServerRunExitWithoutShutdown(t *testing.T) {
+	replies, err := serve(t, `{"jsonrpc":"2.0","method":"exit"}`)
+
+	assert.Equal(t, &ExitError{}, err)
+	assert.Empty(t, replies)
+}
+
+func TestServerRunOversizedMessage(t *testing.T) {
+	var out bytes.Buffer
+	err := NewServer(
+		strings.NewReader("Content-Length: 9000000000000\r\n\r\n{}"), &out,
+	).Run()
+
+	assert.Equal(t,
+		&InvalidHeaderError{Header: "Content-Length: 9000000000000"}, err)
+	assert.Empty(t, out.String())
+}
+
+func TestServerRunInvalidMessages(t *testing.T) {
+	replies, err := serve(t,
+		`{"jsonrpc":`,
+		`{"jsonrpc":"2.0","id":1,"method":"textDocument/hover",`+
+			`"params":{"position":"x"}}`,
+	)
+	require.NoError(t, err)
+	require.Len(t, replies, 2)
+
+	assert.Equal(t, `{"jsonrpc":"2.0","id":null,"error":{"code":-32700,`+
+		`"message":"unexpected end of JSON input"}}`, replies[0])
+	assert.Contains(t, replies[1], `"id":1,"error":{"code":-32602,`)
+}
+
+func TestServerInitializeConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tmuxtheme")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, ".tmuxtheme-lint.yml"),
+		[]byte("rules:\n  useless-format: error\n"), 0644)
+	require.NoError(t, err)
+
+	s := NewServer(nil, ioutil.Discard)
+	_, err = s.initialize(json.RawMessage(
+		`{"rootUri":"file://` + filepath.ToSlash(dir) + `"}`))
+	require.NoError(t, err)
+
+	d := NewDocument("file:///t.tmuxtheme", "set -gF @a x\n")
+	got := d.Diagnostics(s.linter)
+	require.Len(t, got, 1)
+	assert.Equal(t, SeverityError, got[0].Severity)
+
+	err = ioutil.WriteFile(filepath.Join(dir, ".tmuxtheme-lint.yml"),
+		[]byte("rules:\n  nope: error\n"), 0644)
+	require.NoError(t, err)
+
+	_, err = s.initialize(json.RawMessage(
+		`{"rootUri":"file://` + filepath.ToSlash(dir) + `"}`))
+	assert.Error(t, err)
+}
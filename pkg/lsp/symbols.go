@@ -0,0 +1,35 @@
+package lsp
+
+import "strings"
+
+// Symbols returns a symbol for every set-option statement, named after the
+// option it sets.
+func (s *Document) Symbols() []DocumentSymbol {
+	symbols := []DocumentSymbol{}
+
+	for i := range s.Theme.Statements {
+		st, ok := s.setStatement(i)
+		if !ok {
+			continue
+		}
+
+		kind := SymbolProperty
+		if strings.HasPrefix(st.Option, "@") {
+			kind = SymbolVariable
+		}
+		detail := st.Value
+		if st.Flags.Unset {
+			detail = "unset"
+		}
+
+		symbols = append(symbols, DocumentSymbol{
+			Name:           st.Option,
+			Detail:         detail,
+			Kind:           kind,
+			Range:          s.statementRange(s.Theme.Position(i)),
+			SelectionRange: s.optionRange(i),
+		})
+	}
+
+	return symbols
+}
@@ -0,0 +1,58 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentSymbols(t *testing.T) {
+	d := newTestDocument(`
+# Palette
+set -g @theme-fg white
+set -g status-style "fg=red"
+set -gu @gone
+`)
+
+	assert.Equal(t, []DocumentSymbol{
+		{
+			Name:   "@theme-fg",
+			Detail: "white",
+			Kind:   SymbolVariable,
+			Range: Range{
+				Start: Position{Line: 1},
+				End:   Position{Line: 1, Character: 22},
+			},
+			SelectionRange: Range{
+				Start: Position{Line: 1, Character: 7},
+				End:   Position{Line: 1, Character: 16},
+			},
+		},
+		{
+			Name:   "status-style",
+			Detail: "fg=red",
+			Kind:   SymbolProperty,
+			Range: Range{
+				Start: Position{Line: 2},
+				End:   Position{Line: 2, Character: 28},
+			},
+			SelectionRange: Range{
+				Start: Position{Line: 2, Character: 7},
+				End:   Position{Line: 2, Character: 19},
+			},
+		},
+		{
+			Name:   "@gone",
+			Detail: "unset",
+			Kind:   SymbolVariable,
+			Range: Range{
+				Start: Position{Line: 3},
+				End:   Position{Line: 3, Character: 13},
+			},
+			SelectionRange: Range{
+				Start: Position{Line: 3, Character: 8},
+				End:   Position{Line: 3, Character: 13},
+			},
+		},
+	}, d.Symbols())
+}
@@ -0,0 +1,47 @@
+package merge
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// Conflict is an option changed differently by upstream and local. Base,
+// Upstream and Local hold the source lines setting the option in each
+// file, and are empty when the file does not set it.
+type Conflict struct {
+	Scope    theme.Scope
+	Target   string
+	Option   string
+	Base     []string
+	Upstream []string
+	Local    []string
+}
+
+func (s *Conflict) String() string {
+	name := s.Scope.String()
+	if s.Target != "" {
+		name += " " + s.Target
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Conflict: %s %s\n", name, s.Option)
+	for _, side := range []struct {
+		name  string
+		lines []string
+	}{
+		{"base", s.Base},
+		{"upstream", s.Upstream},
+		{"local", s.Local},
+	} {
+		if len(side.lines) == 0 {
+			fmt.Fprintf(&b, "  %-9s (not set)\n", side.name+":")
+		}
+		for _, line := range side.lines {
+			fmt.Fprintf(&b, "  %-9s %s\n", side.name+":", line)
+		}
+	}
+
+	return b.String()
+}
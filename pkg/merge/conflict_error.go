@@ -0,0 +1,11 @@
+package merge
+
+import "fmt"
+
+type ConflictError struct {
+	Conflicts int
+}
+
+func (s *ConflictError) Error() string {
+	return fmt.Sprintf("Merge has %d conflicting options", s.Conflicts)
+}
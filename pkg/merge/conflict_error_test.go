@@ -0,0 +1,17 @@
+package merge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConflictErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &ConflictError{})
+}
+
+func TestConflictError(t *testing.T) {
+	err := &ConflictError{Conflicts: 3}
+
+	assert.Equal(t, "Merge has 3 conflicting options", err.Error())
+}
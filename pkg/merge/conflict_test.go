@@ -0,0 +1,24 @@
+package merge
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConflictString(t *testing.T) {
+	c := &Conflict{
+		Scope:    theme.WindowScope,
+		Target:   "main:1",
+		Option:   "window-status-format",
+		Base:     []string{`set -w -t main:1 window-status-format "#I"`},
+		Upstream: []string{`set -w -t main:1 window-status-format "#I:#W"`},
+	}
+
+	assert.Equal(t, `Conflict: window main:1 window-status-format
+  base:     set -w -t main:1 window-status-format "#I"
+  upstream: set -w -t main:1 window-status-format "#I:#W"
+  local:    (not set)
+`, c.String())
+}
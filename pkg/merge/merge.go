@@ -0,0 +1,282 @@
+// Package merge performs three-way merges of theme files, carrying upstream
+// changes into a locally modified copy of a theme.
+package merge
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+type Options struct {
+	// Markers writes conflicting options with both sides between conflict
+	// markers, instead of keeping the local statements.
+	Markers bool
+}
+
+// Result is the merged theme file and the options which conflicted.
+type Result struct {
+	Lines     []string
+	Conflicts []*Conflict
+}
+
+// Write writes the merged theme file.
+func (s *Result) Write(w io.Writer) error {
+	for _, line := range s.Lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Merge merges the changes made between base and upstream into local.
+//
+// Options are compared by scope, target and name using every statement
+// which sets them, so reformatting or moving a statement is not a change.
+// An option changed only upstream is taken from upstream, everything else
+// keeps the statements, comments and layout of local. Options changed
+// differently on both sides are conflicts, which keep the local statements
+// and are returned along with a ConflictError.
+//
+// Statements are copied from the source lines of the themes. Themes without
+// them, like composed or decoded themes, have their statements written the
+// way Theme.Write writes them.
+func Merge(
+	base, upstream, local *theme.Theme,
+	opts *Options,
+) (*Result, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	for _, t := range []*theme.Theme{base, upstream, local} {
+		if err := checkText(t); err != nil {
+			return nil, err
+		}
+	}
+
+	m := &merger{
+		base:     newSetters(base),
+		upstream: newSetters(upstream),
+		local:    newSetters(local),
+		replace:  map[int][]string{},
+		after:    map[int][]string{},
+	}
+
+	result := &Result{Conflicts: []*Conflict{}}
+
+	keys := append([]key{}, m.local.order...)
+	for _, k := range m.upstream.order {
+		if len(m.local.indexes[k]) == 0 {
+			keys = append(keys, k)
+		}
+	}
+
+	for _, k := range keys {
+		b, u, l := m.base.sig(k), m.upstream.sig(k), m.local.sig(k)
+
+		switch {
+		case u == b, u == l:
+			continue
+		case l == b:
+			m.place(k, m.upstream.text(k, len(m.local.indexes[k]) == 0))
+		default:
+			c := &Conflict{
+				Scope:    k.scope,
+				Target:   k.target,
+				Option:   k.option,
+				Base:     m.base.text(k, false),
+				Upstream: m.upstream.text(k, false),
+				Local:    m.local.text(k, false),
+			}
+			result.Conflicts = append(result.Conflicts, c)
+
+			if opts.Markers {
+				lines := append([]string{"<<<<<<< local"}, c.Local...)
+				lines = append(append(lines, "======="), c.Upstream...)
+				m.place(k, append(lines, ">>>>>>> upstream"))
+			}
+		}
+	}
+
+	result.Lines = m.lines()
+
+	if len(result.Conflicts) > 0 {
+		return result, &ConflictError{Conflicts: len(result.Conflicts)}
+	}
+
+	return result, nil
+}
+
+// checkText returns an error if any statement of t has neither source
+// lines nor a way to be written.
+func checkText(t *theme.Theme) error {
+	for i, st := range t.Statements {
+		if t.Text(i) != nil {
+			continue
+		}
+		if _, ok := st.(fmt.Stringer); !ok {
+			return &theme.UnsupportedStatementError{Body: fmt.Sprintf("%T", st)}
+		}
+	}
+
+	return nil
+}
+
+// statementText returns the source lines of the statement at index i of t,
+// or the statement as written by Theme.Write when they are unknown.
+func statementText(t *theme.Theme, i int) []string {
+	if lines := t.Text(i); lines != nil {
+		return lines
+	}
+
+	return []string{t.Statements[i].(fmt.Stringer).String()}
+}
+
+// key identifies an option by where it is set.
+type key struct {
+	scope  theme.Scope
+	target string
+	option string
+}
+
+// setters indexes the set-option statements of a theme by the option they
+// set.
+type setters struct {
+	theme   *theme.Theme
+	order   []key
+	indexes map[key][]int
+}
+
+func newSetters(t *theme.Theme) *setters {
+	s := &setters{theme: t, order: []key{}, indexes: map[key][]int{}}
+
+	for i, st := range t.Statements {
+		set, ok := st.(*theme.SetOptionStatement)
+		if !ok {
+			continue
+		}
+
+		k := key{set.Scope(), set.Target(), set.Option}
+		if len(s.indexes[k]) == 0 {
+			s.order = append(s.order, k)
+		}
+		s.indexes[k] = append(s.indexes[k], i)
+	}
+
+	return s
+}
+
+// sig returns a comparable summary of the statements setting k, ignoring
+// how they are quoted and where they appear.
+func (s *setters) sig(k key) string {
+	parts := []string{}
+	for _, i := range s.indexes[k] {
+		set := s.theme.Statements[i].(*theme.SetOptionStatement)
+		parts = append(parts,
+			fmt.Sprintf("%+v\x00%s\x00%s", *set.Flags, set.Option, set.Value))
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// text returns the source lines of the statements setting k, optionally
+// including the comments directly above the first of them.
+func (s *setters) text(k key, comments bool) []string {
+	lines := []string{}
+	indexes := s.indexes[k]
+
+	if comments && len(indexes) > 0 {
+		start := indexes[0]
+		for start > 0 {
+			if _, ok := s.theme.Statements[start-1].(*theme.CommentStatement); !ok {
+				break
+			}
+			start--
+		}
+		for i := start; i < indexes[0]; i++ {
+			lines = append(lines, statementText(s.theme, i)...)
+		}
+	}
+
+	for _, i := range indexes {
+		lines = append(lines, statementText(s.theme, i)...)
+	}
+
+	return lines
+}
+
+type merger struct {
+	base     *setters
+	upstream *setters
+	local    *setters
+
+	// replace holds the lines written instead of a local statement, nil to
+	// remove it.
+	replace map[int][]string
+
+	// after holds lines inserted after a local statement, -1 being the top
+	// of the file.
+	after map[int][]string
+}
+
+// place writes lines in place of the local statements setting k, or when
+// local does not set k, after the statement upstream sets it after.
+func (s *merger) place(k key, lines []string) {
+	if indexes := s.local.indexes[k]; len(indexes) > 0 {
+		s.replace[indexes[0]] = lines
+		for _, i := range indexes[1:] {
+			s.replace[i] = nil
+		}
+		return
+	}
+
+	if len(lines) > 0 {
+		a := s.anchor(k)
+		s.after[a] = append(s.after[a], lines...)
+	}
+}
+
+// anchor returns the local statement to insert an upstream option after:
+// the last local statement setting the nearest option set before it
+// upstream, or failing that, just before the first local option.
+func (s *merger) anchor(k key) int {
+	statements := s.upstream.theme.Statements
+	if indexes := s.upstream.indexes[k]; len(indexes) > 0 {
+		for i := indexes[0] - 1; i >= 0; i-- {
+			set, ok := statements[i].(*theme.SetOptionStatement)
+			if !ok {
+				continue
+			}
+			prev := key{set.Scope(), set.Target(), set.Option}
+			if local := s.local.indexes[prev]; len(local) > 0 {
+				return local[len(local)-1]
+			}
+		}
+	}
+
+	if len(s.local.order) > 0 {
+		return s.local.indexes[s.local.order[0]][0] - 1
+	}
+
+	return len(s.local.theme.Statements) - 1
+}
+
+func (s *merger) lines() []string {
+	lines := append([]string{}, s.after[-1]...)
+
+	for i := range s.local.theme.Statements {
+		if r, ok := s.replace[i]; ok {
+			lines = append(lines, r...)
+		} else {
+			lines = append(lines, statementText(s.local.theme, i)...)
+		}
+		lines = append(lines, s.after[i]...)
+	}
+
+	return lines
+}
@@ -0,0 +1,288 @@
+package merge
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	var tests = []struct {
+		name     string
+		base     string
+		upstream string
+		local    string
+		want     string
+	}{
+		{
+			name: "upstream change",
+			base: `
+set -g status-style "bg=black"
+set -g status-left "#S"
+`,
+			upstream: `
+set -g status-style "bg=blue"
+set -g status-left "#S"
+`,
+			local: `
+# My tweaks.
+set -g status-style bg=black
+
+set -g status-left "[#S]" # brackets
+`,
+			want: `
+# My tweaks.
+set -g status-style "bg=blue"
+
+set -g status-left "[#S]" # brackets
+`,
+		},
+		{
+			name: "upstream addition",
+			base: `
+set -g status-left "#S"
+set -g status-right "%H:%M"
+`,
+			upstream: `
+set -g status-left "#S"
+# Clock colour.
+set -g @clock-fg white
+set -g status-right "%H:%M"
+`,
+			local: `
+# Theme.
+set -g status-right "%H:%M:%S"
+set -g status-left "#S"
+`,
+			want: `
+# Theme.
+set -g status-right "%H:%M:%S"
+set -g status-left "#S"
+# Clock colour.
+set -g @clock-fg white
+`,
+		},
+		{
+			name: "upstream addition at top",
+			base: `
+set -g status-left "#S"
+`,
+			upstream: `
+set -g @accent blue
+set -g status-left "#S"
+`,
+			local: `
+# Theme.
+set -g status-left "#S"
+`,
+			want: `
+# Theme.
+set -g @accent blue
+set -g status-left "#S"
+`,
+		},
+		{
+			name: "upstream removal",
+			base: `
+set -g status-left "#S"
+set -g @old yes
+`,
+			upstream: `
+set -g status-left "#S"
+`,
+			local: `
+set -g @old yes
+set -g status-left "#S "
+`,
+			want: `
+set -g status-left "#S "
+`,
+		},
+		{
+			name: "same change on both sides",
+			base: `
+set -g status-left "#S"
+`,
+			upstream: `
+set -g status-left "#S:#I"
+`,
+			local: `
+set -g status-left '#S:#I' # same
+`,
+			want: `
+set -g status-left '#S:#I' # same
+`,
+		},
+		{
+			name: "scopes kept apart",
+			base: `
+set -g status-left "#S"
+`,
+			upstream: `
+set -g status-left "#S"
+set -t main status-left "#S!"
+`,
+			local: `
+set -g status-left "#H"
+`,
+			want: `
+set -g status-left "#H"
+set -t main status-left "#S!"
+`,
+		},
+		{
+			name: "multiple statements per option",
+			base: `
+set -g status-left "#S"
+set -ga status-left " "
+`,
+			upstream: `
+set -g status-left "#S"
+set -ga status-left " | "
+`,
+			local: `
+set -g status-left "#S"
+set -g status-right ""
+set -ga status-left " "
+`,
+			want: `
+set -g status-left "#S"
+set -ga status-left " | "
+set -g status-right ""
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		result, err := Merge(
+			themetest.Parse(t, "base", tt.base),
+			themetest.Parse(t, "upstream", tt.upstream),
+			themetest.Parse(t, "local", tt.local),
+			nil,
+		)
+		require.NoError(t, err, tt.name)
+
+		var buf bytes.Buffer
+		require.NoError(t, result.Write(&buf), tt.name)
+		assert.Equal(t, strings.TrimLeft(tt.want, "\n"), buf.String(), tt.name)
+		assert.Empty(t, result.Conflicts, tt.name)
+	}
+}
+
+func TestMergeConflict(t *testing.T) {
+	base := themetest.Parse(t, "base", `
+set -g status-style "bg=black"
+set -g @removed yes
+set -g status-left "#S"
+`)
+	upstream := themetest.Parse(t, "upstream", `
+set -g status-style "bg=blue"
+set -g @removed no
+set -g status-left "#S"
+`)
+	local := themetest.Parse(t, "local", `
+set -g status-style "bg=red"
+set -g status-left "#S"
+`)
+
+	result, err := Merge(base, upstream, local, nil)
+
+	assert.Equal(t, &ConflictError{Conflicts: 2}, err)
+	assert.Equal(t, []string{
+		`set -g status-style "bg=red"`,
+		`set -g status-left "#S"`,
+	}, result.Lines)
+	assert.Equal(t, []*Conflict{
+		{
+			Scope:    theme.GlobalSessionScope,
+			Option:   "status-style",
+			Base:     []string{`set -g status-style "bg=black"`},
+			Upstream: []string{`set -g status-style "bg=blue"`},
+			Local:    []string{`set -g status-style "bg=red"`},
+		},
+		{
+			Scope:    theme.GlobalSessionScope,
+			Option:   "@removed",
+			Base:     []string{`set -g @removed yes`},
+			Upstream: []string{`set -g @removed no`},
+			Local:    []string{},
+		},
+	}, result.Conflicts)
+}
+
+func TestMergeConflictMarkers(t *testing.T) {
+	base := themetest.Parse(t, "base", `set -g status-style "bg=black"`)
+	upstream := themetest.Parse(t, "upstream", `set -g status-style "bg=blue"`)
+	local := themetest.Parse(t, "local", `
+# Local.
+set -g status-style "bg=red"
+`)
+
+	result, err := Merge(base, upstream, local, &Options{Markers: true})
+
+	assert.Error(t, err)
+	assert.Equal(t, []string{
+		"# Local.",
+		"<<<<<<< local",
+		`set -g status-style "bg=red"`,
+		"=======",
+		`set -g status-style "bg=blue"`,
+		">>>>>>> upstream",
+	}, result.Lines)
+}
+
+func TestMergeWithoutLines(t *testing.T) {
+	base := themetest.Parse(t, "base", `
+set -g status-style "bg=black"
+set -g status-left "#S"
+`)
+	upstream := themetest.Parse(t, "upstream", `
+set -g status-style "bg=blue"
+set -g status-left "#S"
+set -goq @separator ""
+`)
+	local, err := theme.Compose(themetest.Execute(t, "local", `
+# Local.
+set -g status-style 'bg=black'
+set -g status-left '[#S]'
+`))
+	require.NoError(t, err)
+	require.Empty(t, local.Lines)
+
+	result, err := Merge(base, upstream, local, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"# Local.",
+		`set -g status-style "bg=blue"`,
+		`set -g status-left "[#S]"`,
+		`set -goq @separator ""`,
+	}, result.Lines)
+}
+
+type unwritableStatement struct{}
+
+func (s unwritableStatement) Parse(string) error {
+	return nil
+}
+
+func (s unwritableStatement) Execute(*theme.Theme) error {
+	return nil
+}
+
+func TestMergeUnwritableStatement(t *testing.T) {
+	base := themetest.Parse(t, "base", `set -g status-style "bg=black"`)
+	local := theme.New()
+	local.Statements = append(local.Statements, unwritableStatement{})
+
+	_, err := Merge(base, base, local, nil)
+
+	assert.Equal(t, &theme.UnsupportedStatementError{
+		Body: "merge.unwritableStatement",
+	}, err)
+}
@@ -0,0 +1,61 @@
+package palette
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// Airline returns a vim-airline theme named name matching the palette. It
+// is meant to be saved as autoload/airline/themes/<name>.vim.
+func (s *Palette) Airline(name string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "\" vim-airline theme generated from a tmux theme.\n")
+	fmt.Fprintf(&b, "let g:airline#themes#%s#palette = {}\n\n", name)
+
+	modes := []struct {
+		name       string
+		fg, bg     theme.Colour
+		inactiveFg theme.Colour
+	}{
+		{"normal", s.AccentText, s.Accent, s.Foreground},
+		{"insert", s.Background, s.ActiveBorder, s.Foreground},
+		{"visual", s.SelectionText, s.Selection, s.Foreground},
+		{"replace", s.Background, s.Warning, s.Foreground},
+		{"inactive", s.Border, s.Background, s.Border},
+	}
+
+	for _, m := range modes {
+		fmt.Fprintf(
+			&b,
+			"let g:airline#themes#%s#palette.%s = "+
+				"airline#themes#generate_color_map(%s, %s, %s)\n",
+			name, m.name,
+			airlineColours(m.fg, m.bg),
+			airlineColours(s.MessageForeground, s.MessageBackground),
+			airlineColours(m.inactiveFg, s.Background),
+		)
+	}
+
+	return b.String()
+}
+
+// airlineColours returns the [guifg, guibg, ctermfg, ctermbg] list airline
+// uses to define a section's colours.
+func airlineColours(fg, bg theme.Colour) string {
+	return fmt.Sprintf(
+		"['%s', '%s', %s, %s]", hex(fg), hex(bg), cterm(fg), cterm(bg),
+	)
+}
+
+func cterm(c theme.Colour) string {
+	i := index(c)
+	if i < 0 {
+		return "'NONE'"
+	}
+
+	return strconv.Itoa(i)
+}
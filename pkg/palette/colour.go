@@ -0,0 +1,66 @@
+package palette
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// hex returns c as "#rrggbb", or "NONE" for colours which depend on the
+// terminal.
+func hex(c theme.Colour) string {
+	r, g, b, ok := c.RGB()
+	if !ok {
+		return "NONE"
+	}
+
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// index returns the 256 colour palette index of c, approximating RGB
+// colours with the nearest palette colour. -1 is returned for colours
+// which depend on the terminal.
+func index(c theme.Colour) int {
+	switch c.Type {
+	case theme.ANSIColour, theme.PaletteColour:
+		return c.Index
+	case theme.RGBColour:
+		return nearestIndex(c)
+	}
+
+	return -1
+}
+
+// nearestIndex returns the index of the colour cube or grey ramp colour
+// closest to c. The 16 ANSI colours are skipped as terminals redefine them.
+func nearestIndex(c theme.Colour) int {
+	best, bestDist := 16, -1
+	for i := 16; i < 256; i++ {
+		r, g, b, _ := theme.Colour{Type: theme.PaletteColour, Index: i}.RGB()
+		dr, dg, db := int(r)-int(c.R), int(g)-int(c.G), int(b)-int(c.B)
+		dist := dr*dr + dg*dg + db*db
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+
+	return best
+}
+
+// fgSGR returns the SGR parameters selecting c as the foreground colour.
+func fgSGR(c theme.Colour) string {
+	switch c.Type {
+	case theme.ANSIColour:
+		if c.Index >= 8 {
+			return strconv.Itoa(90 + c.Index - 8)
+		}
+		return strconv.Itoa(30 + c.Index)
+	case theme.PaletteColour:
+		return "38;5;" + strconv.Itoa(c.Index)
+	case theme.RGBColour:
+		return fmt.Sprintf("38;2;%d;%d;%d", c.R, c.G, c.B)
+	}
+
+	return "39"
+}
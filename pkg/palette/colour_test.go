@@ -0,0 +1,34 @@
+package palette
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColourFormats(t *testing.T) {
+	var tests = []struct {
+		value string
+		hex   string
+		index int
+		sgr   string
+		fzf   string
+	}{
+		{"default", "NONE", -1, "39", "-1"},
+		{"red", "#cd0000", 1, "31", "1"},
+		{"brightblue", "#5c5cff", 12, "94", "12"},
+		{"colour208", "#ff8700", 208, "38;5;208", "208"},
+		{"#ff8700", "#ff8700", 208, "38;2;255;135;0", "#ff8700"},
+		{"#2e3440", "#2e3440", 237, "38;2;46;52;64", "#2e3440"},
+	}
+
+	for _, tt := range tests {
+		c, _ := theme.ParseColour(tt.value)
+
+		assert.Equal(t, tt.hex, hex(c), tt.value)
+		assert.Equal(t, tt.index, index(c), tt.value)
+		assert.Equal(t, tt.sgr, fgSGR(c), tt.value)
+		assert.Equal(t, tt.fzf, fzfColour(c), tt.value)
+	}
+}
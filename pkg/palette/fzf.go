@@ -0,0 +1,50 @@
+package palette
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// FZF returns an fzf --color option matching the palette.
+func (s *Palette) FZF() string {
+	colours := []struct {
+		name   string
+		colour theme.Colour
+	}{
+		{"fg", s.Foreground},
+		{"bg", s.Background},
+		{"hl", s.Accent},
+		{"fg+", s.SelectionText},
+		{"bg+", s.Selection},
+		{"hl+", s.AccentText},
+		{"info", s.Warning},
+		{"prompt", s.Accent},
+		{"pointer", s.Accent},
+		{"marker", s.Warning},
+		{"spinner", s.Accent},
+		{"header", s.Border},
+		{"border", s.Border},
+	}
+
+	parts := make([]string, len(colours))
+	for i, c := range colours {
+		parts[i] = c.name + ":" + fzfColour(c.colour)
+	}
+
+	return "--color=" + strings.Join(parts, ",")
+}
+
+// fzfColour formats c the way fzf expects: ANSI and palette colours by
+// index, RGB colours in hex and terminal colours as -1.
+func fzfColour(c theme.Colour) string {
+	switch c.Type {
+	case theme.ANSIColour, theme.PaletteColour:
+		return strconv.Itoa(c.Index)
+	case theme.RGBColour:
+		return hex(c)
+	}
+
+	return "-1"
+}
@@ -0,0 +1,47 @@
+package palette
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lualine returns a Neovim lualine theme, a Lua module returning the theme
+// table, matching the palette.
+func (s *Palette) Lualine() string {
+	var b strings.Builder
+
+	b.WriteString("-- lualine theme generated from a tmux theme.\n")
+	b.WriteString("local colors = {\n")
+	for _, c := range s.roles() {
+		fmt.Fprintf(&b, "  %s = '%s',\n", c.name, hex(*c.colour))
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString(`return {
+  normal = {
+    a = { fg = colors.accent_text, bg = colors.accent, gui = 'bold' },
+    b = { fg = colors.message_fg, bg = colors.message_bg },
+    c = { fg = colors.fg, bg = colors.bg },
+  },
+  insert = {
+    a = { fg = colors.bg, bg = colors.active_border, gui = 'bold' },
+  },
+  visual = {
+    a = { fg = colors.selection_text, bg = colors.selection, gui = 'bold' },
+  },
+  replace = {
+    a = { fg = colors.bg, bg = colors.warning, gui = 'bold' },
+  },
+  command = {
+    a = { fg = colors.message_fg, bg = colors.message_bg, gui = 'bold' },
+  },
+  inactive = {
+    a = { fg = colors.border, bg = colors.bg },
+    b = { fg = colors.border, bg = colors.bg },
+    c = { fg = colors.border, bg = colors.bg },
+  },
+}
+`)
+
+	return b.String()
+}
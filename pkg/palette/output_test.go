@@ -0,0 +1,26 @@
+package palette
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+)
+
+func TestOutputs(t *testing.T) {
+	p := loadPalette(t, "testdata/nord.tmuxtheme")
+
+	var tests = []struct {
+		golden string
+		output string
+	}{
+		{"testdata/nord.fzf", p.FZF() + "\n"},
+		{"testdata/nord.lua", p.Lualine()},
+		{"testdata/nord.vim", p.Airline("nord")},
+		{"testdata/nord.starship.toml", p.Starship()},
+		{"testdata/nord.sh", p.Shell()},
+	}
+
+	for _, tt := range tests {
+		themetest.AssertGolden(t, tt.golden, tt.output)
+	}
+}
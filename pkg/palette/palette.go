@@ -0,0 +1,114 @@
+// Package palette extracts the effective colours of an executed theme and
+// writes matching configuration for other terminal tools, so they stay
+// visually consistent with tmux.
+package palette
+
+import (
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// Palette holds the colours a theme effectively uses, by role. Extract
+// always resolves them to concrete colours, never default or terminal.
+type Palette struct {
+	// Background and Foreground are the status line colours.
+	Background theme.Colour
+	Foreground theme.Colour
+	// Accent and AccentText are the current window's background and
+	// foreground in the window list.
+	Accent     theme.Colour
+	AccentText theme.Colour
+	// Border and ActiveBorder are the pane border colours.
+	Border       theme.Colour
+	ActiveBorder theme.Colour
+	// MessageBackground and MessageForeground are the message line colours.
+	MessageBackground theme.Colour
+	MessageForeground theme.Colour
+	// Selection and SelectionText are the copy mode selection colours.
+	Selection     theme.Colour
+	SelectionText theme.Colour
+	// Warning marks windows with activity.
+	Warning theme.Colour
+}
+
+// The colours Extract falls back to when a theme leaves the status line
+// and activity styles unset.
+var (
+	DefaultBackground = theme.Colour{Type: theme.ANSIColour, Index: 0}
+	DefaultForeground = theme.Colour{Type: theme.ANSIColour, Index: 7}
+	DefaultWarning    = theme.Colour{Type: theme.ANSIColour, Index: 3}
+)
+
+// Extract resolves the palette of an executed theme from its global
+// session and window options, falling back to tmux's defaults. Colours a
+// style leaves unset, or sets to "default", fall back to related colours:
+// the current window to the status line, borders to the foreground.
+func Extract(t *theme.Theme) (*Palette, error) {
+	e := &extractor{theme: t}
+
+	status := e.style(theme.SessionScope, "status-style")
+	current := e.style(theme.WindowScope, "window-status-current-style")
+	border := e.style(theme.WindowScope, "pane-border-style")
+	active := e.style(theme.WindowScope, "pane-active-border-style")
+	message := e.style(theme.SessionScope, "message-style")
+	mode := e.style(theme.WindowScope, "mode-style")
+	activity := e.style(theme.WindowScope, "window-status-activity-style")
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	p := &Palette{
+		Background: pick(status.Bg, DefaultBackground),
+		Foreground: pick(status.Fg, DefaultForeground),
+	}
+	if isColour(current.Bg) {
+		p.Accent = current.Bg
+		p.AccentText = pick(current.Fg, p.Background)
+	} else {
+		p.Accent = pick(current.Fg, active.Fg, p.Foreground)
+		p.AccentText = p.Background
+	}
+	p.Border = pick(border.Fg, p.Foreground)
+	p.ActiveBorder = pick(active.Fg, p.Accent)
+	p.MessageBackground = pick(message.Bg, p.Background)
+	p.MessageForeground = pick(message.Fg, p.Foreground)
+	p.Selection = pick(mode.Bg, p.Accent)
+	p.SelectionText = pick(mode.Fg, p.Background)
+	p.Warning = pick(activity.Fg, DefaultWarning)
+
+	return p, nil
+}
+
+type extractor struct {
+	theme *theme.Theme
+	err   error
+}
+
+func (s *extractor) style(scope theme.Scope, name string) theme.Style {
+	if s.err != nil {
+		return theme.Style{}
+	}
+
+	style, err := s.theme.GetStyle(scope, "", name)
+	if err != nil {
+		s.err = err
+	}
+
+	return style
+}
+
+// pick returns the first of colours which is an actual colour rather than
+// unset or default.
+func pick(colours ...theme.Colour) theme.Colour {
+	for _, c := range colours {
+		if isColour(c) {
+			return c
+		}
+	}
+
+	return theme.Colour{Type: theme.DefaultColour}
+}
+
+func isColour(c theme.Colour) bool {
+	return c.IsSet() && c.Type != theme.DefaultColour &&
+		c.Type != theme.TerminalColour
+}
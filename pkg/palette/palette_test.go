@@ -0,0 +1,106 @@
+package palette
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func colour(t *testing.T, value string) theme.Colour {
+	c, err := theme.ParseColour(value)
+	require.NoError(t, err)
+
+	return c
+}
+
+func loadPalette(t *testing.T, filename string) *Palette {
+	th, err := themetest.Load(filename, themetest.State())
+	require.NoError(t, err)
+
+	p, err := Extract(th)
+	require.NoError(t, err)
+
+	return p
+}
+
+func TestExtract(t *testing.T) {
+	p := loadPalette(t, "testdata/nord.tmuxtheme")
+
+	assert.Equal(t, &Palette{
+		Background:        colour(t, "#2e3440"),
+		Foreground:        colour(t, "#d8dee9"),
+		Accent:            colour(t, "#88c0d0"),
+		AccentText:        colour(t, "#2e3440"),
+		Border:            colour(t, "#4c566a"),
+		ActiveBorder:      colour(t, "#88c0d0"),
+		MessageBackground: colour(t, "#3b4252"),
+		MessageForeground: colour(t, "#d8dee9"),
+		Selection:         colour(t, "#88c0d0"),
+		SelectionText:     colour(t, "#2e3440"),
+		Warning:           colour(t, "#ebcb8b"),
+	}, p)
+}
+
+func TestExtractFallbacks(t *testing.T) {
+	var tests = []struct {
+		name  string
+		body  string
+		check func(*testing.T, *Palette)
+	}{
+		{
+			name: "tmux defaults",
+			body: "",
+			check: func(t *testing.T, p *Palette) {
+				assert.Equal(t, colour(t, "green"), p.Background)
+				assert.Equal(t, colour(t, "black"), p.Foreground)
+				assert.Equal(t, colour(t, "green"), p.Accent)
+				assert.Equal(t, colour(t, "green"), p.AccentText)
+				assert.Equal(t, colour(t, "black"), p.Border)
+				assert.Equal(t, colour(t, "yellow"), p.MessageBackground)
+				assert.Equal(t, colour(t, "yellow"), p.Warning)
+			},
+		},
+		{
+			name: "default colours",
+			body: "set -g status-style bg=default,fg=default",
+			check: func(t *testing.T, p *Palette) {
+				assert.Equal(t, colour(t, "black"), p.Background)
+				assert.Equal(t, colour(t, "white"), p.Foreground)
+			},
+		},
+		{
+			name: "current window foreground only",
+			body: "set -g window-status-current-style fg=colour39,bold",
+			check: func(t *testing.T, p *Palette) {
+				assert.Equal(t, colour(t, "colour39"), p.Accent)
+				assert.Equal(t, colour(t, "green"), p.AccentText)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := theme.New()
+			require.NoError(t, th.Parse(strings.NewReader(tt.body)))
+			require.NoError(t, th.Execute())
+
+			p, err := Extract(th)
+			require.NoError(t, err)
+
+			tt.check(t, p)
+		})
+	}
+}
+
+func TestExtractInvalidStyle(t *testing.T) {
+	th := theme.New()
+	th.GlobalSessionOptions["status-style"] = "fg=purplish"
+
+	_, err := Extract(th)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,26 @@
+package palette
+
+import "github.com/jimeh/go-tmuxtheme/pkg/theme"
+
+type role struct {
+	name   string
+	colour *theme.Colour
+}
+
+// roles returns the palette's colours with the snake case names used in
+// generated configs.
+func (s *Palette) roles() []role {
+	return []role{
+		{"bg", &s.Background},
+		{"fg", &s.Foreground},
+		{"accent", &s.Accent},
+		{"accent_text", &s.AccentText},
+		{"border", &s.Border},
+		{"active_border", &s.ActiveBorder},
+		{"message_bg", &s.MessageBackground},
+		{"message_fg", &s.MessageForeground},
+		{"selection", &s.Selection},
+		{"selection_text", &s.SelectionText},
+		{"warning", &s.Warning},
+	}
+}
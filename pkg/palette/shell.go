@@ -0,0 +1,42 @@
+package palette
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Shell returns shell export statements for the palette: a TMUX_THEME_*
+// variable with each colour in hex, and an LS_COLORS value colouring file
+// types with the theme's colours.
+func (s *Palette) Shell() string {
+	var b strings.Builder
+
+	for _, c := range s.roles() {
+		fmt.Fprintf(
+			&b, "export TMUX_THEME_%s='%s'\n",
+			strings.ToUpper(c.name), hex(*c.colour),
+		)
+	}
+
+	fmt.Fprintf(&b, "export LS_COLORS='%s'\n", s.LSColors())
+
+	return b.String()
+}
+
+// LSColors returns an LS_COLORS value for the main file types: directories
+// in the accent colour, links in the active border colour, executables and
+// special files in the warning colour.
+func (s *Palette) LSColors() string {
+	entries := []string{
+		"di=01;" + fgSGR(s.Accent),
+		"ln=" + fgSGR(s.ActiveBorder),
+		"so=" + fgSGR(s.Selection),
+		"pi=" + fgSGR(s.Warning),
+		"ex=01;" + fgSGR(s.Warning),
+		"bd=01;" + fgSGR(s.MessageForeground),
+		"cd=01;" + fgSGR(s.MessageForeground),
+		"or=01;" + fgSGR(s.Border),
+	}
+
+	return strings.Join(entries, ":")
+}
@@ -0,0 +1,42 @@
+package palette
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Starship returns a starship.toml snippet defining a "tmux" palette with
+// the theme's colours, and prompt modules styled with it.
+func (s *Palette) Starship() string {
+	var b strings.Builder
+
+	b.WriteString("# starship configuration generated from a tmux theme.\n")
+	b.WriteString("palette = \"tmux\"\n\n")
+	b.WriteString("[palettes.tmux]\n")
+	for _, c := range s.roles() {
+		fmt.Fprintf(&b, "%s = \"%s\"\n", c.name, hex(*c.colour))
+	}
+
+	b.WriteString(`
+[character]
+success_symbol = "[❯](bold accent)"
+error_symbol = "[❯](bold warning)"
+
+[directory]
+style = "bold accent"
+
+[git_branch]
+style = "active_border"
+
+[git_status]
+style = "warning"
+
+[hostname]
+style = "bold fg"
+
+[time]
+style = "fg"
+`)
+
+	return b.String()
+}
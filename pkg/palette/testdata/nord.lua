@@ -0,0 +1,39 @@
+-- lualine theme generated from a tmux theme.
+local colors = {
+  bg = '#2e3440',
+  fg = '#d8dee9',
+  accent = '#88c0d0',
+  accent_text = '#2e3440',
+  border = '#4c566a',
+  active_border = '#88c0d0',
+  message_bg = '#3b4252',
+  message_fg = '#d8dee9',
+  selection = '#88c0d0',
+  selection_text = '#2e3440',
+  warning = '#ebcb8b',
+}
+
+return {
+  normal = {
+    a = { fg = colors.accent_text, bg = colors.accent, gui = 'bold' },
+    b = { fg = colors.message_fg, bg = colors.message_bg },
+    c = { fg = colors.fg, bg = colors.bg },
+  },
+  insert = {
+    a = { fg = colors.bg, bg = colors.active_border, gui = 'bold' },
+  },
+  visual = {
+    a = { fg = colors.selection_text, bg = colors.selection, gui = 'bold' },
+  },
+  replace = {
+    a = { fg = colors.bg, bg = colors.warning, gui = 'bold' },
+  },
+  command = {
+    a = { fg = colors.message_fg, bg = colors.message_bg, gui = 'bold' },
+  },
+  inactive = {
+    a = { fg = colors.border, bg = colors.bg },
+    b = { fg = colors.border, bg = colors.bg },
+    c = { fg = colors.border, bg = colors.bg },
+  },
+}
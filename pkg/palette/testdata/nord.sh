@@ -0,0 +1,12 @@
+export TMUX_THEME_BG='#2e3440'
+export TMUX_THEME_FG='#d8dee9'
+export TMUX_THEME_ACCENT='#88c0d0'
+export TMUX_THEME_ACCENT_TEXT='#2e3440'
+export TMUX_THEME_BORDER='#4c566a'
+export TMUX_THEME_ACTIVE_BORDER='#88c0d0'
+export TMUX_THEME_MESSAGE_BG='#3b4252'
+export TMUX_THEME_MESSAGE_FG='#d8dee9'
+export TMUX_THEME_SELECTION='#88c0d0'
+export TMUX_THEME_SELECTION_TEXT='#2e3440'
+export TMUX_THEME_WARNING='#ebcb8b'
+export LS_COLORS='di=01;38;2;136;192;208:ln=38;2;136;192;208:so=38;2;136;192;208:pi=38;2;235;203;139:ex=01;38;2;235;203;139:bd=01;38;2;216;222;233:cd=01;38;2;216;222;233:or=01;38;2;76;86;106'
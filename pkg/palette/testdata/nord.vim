@@ -0,0 +1,8 @@
+" vim-airline theme generated from a tmux theme.
+let g:airline#themes#nord#palette = {}
+
+let g:airline#themes#nord#palette.normal = airline#themes#generate_color_map(['#2e3440', '#88c0d0', 237, 110], ['#d8dee9', '#3b4252', 254, 238], ['#d8dee9', '#2e3440', 254, 237])
+let g:airline#themes#nord#palette.insert = airline#themes#generate_color_map(['#2e3440', '#88c0d0', 237, 110], ['#d8dee9', '#3b4252', 254, 238], ['#d8dee9', '#2e3440', 254, 237])
+let g:airline#themes#nord#palette.visual = airline#themes#generate_color_map(['#2e3440', '#88c0d0', 237, 110], ['#d8dee9', '#3b4252', 254, 238], ['#d8dee9', '#2e3440', 254, 237])
+let g:airline#themes#nord#palette.replace = airline#themes#generate_color_map(['#2e3440', '#ebcb8b', 237, 186], ['#d8dee9', '#3b4252', 254, 238], ['#d8dee9', '#2e3440', 254, 237])
+let g:airline#themes#nord#palette.inactive = airline#themes#generate_color_map(['#4c566a', '#2e3440', 240, 237], ['#d8dee9', '#3b4252', 254, 238], ['#4c566a', '#2e3440', 240, 237])
@@ -0,0 +1,87 @@
+package preview
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+var ansiAttributes = []struct {
+	attr theme.Attributes
+	code string
+}{
+	{theme.BrightAttr, "1"},
+	{theme.DimAttr, "2"},
+	{theme.ItalicsAttr, "3"},
+	{theme.UnderscoreAttr, "4"},
+	{theme.DoubleUnderscoreAttr, "4:2"},
+	{theme.CurlyUnderscoreAttr, "4:3"},
+	{theme.DottedUnderscoreAttr, "4:4"},
+	{theme.DashedUnderscoreAttr, "4:5"},
+	{theme.BlinkAttr, "5"},
+	{theme.ReverseAttr, "7"},
+	{theme.HiddenAttr, "8"},
+	{theme.StrikethroughAttr, "9"},
+	{theme.OverlineAttr, "53"},
+}
+
+// ANSI returns the line as text with SGR escape sequences, resetting all
+// attributes at the end.
+func (s Line) ANSI() string {
+	var b strings.Builder
+	var current *theme.Style
+
+	for i := range s {
+		if current == nil || s[i].Style != *current {
+			b.WriteString(SGR(s[i].Style))
+			current = &s[i].Style
+		}
+		b.WriteRune(s[i].Rune)
+	}
+
+	if len(s) > 0 {
+		b.WriteString("\x1b[0m")
+	}
+
+	return b.String()
+}
+
+// SGR returns the escape sequence which resets the terminal and then
+// selects style.
+func SGR(style theme.Style) string {
+	codes := []string{"0"}
+
+	for _, a := range ansiAttributes {
+		if style.Attrs&a.attr != 0 {
+			codes = append(codes, a.code)
+		}
+	}
+
+	codes = append(codes, sgrColour(style.Fg, 30, 90, "38")...)
+	codes = append(codes, sgrColour(style.Bg, 40, 100, "48")...)
+	if style.Us.Type == theme.PaletteColour || style.Us.Type == theme.RGBColour {
+		codes = append(codes, sgrColour(style.Us, 0, 0, "58")...)
+	}
+
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
+
+func sgrColour(c theme.Colour, base, bright int, extended string) []string {
+	switch c.Type {
+	case theme.ANSIColour:
+		if c.Index >= 8 {
+			return []string{strconv.Itoa(bright + c.Index - 8)}
+		}
+		return []string{strconv.Itoa(base + c.Index)}
+	case theme.PaletteColour:
+		return []string{extended, "5", strconv.Itoa(c.Index)}
+	case theme.RGBColour:
+		return []string{
+			extended, "2",
+			strconv.Itoa(int(c.R)), strconv.Itoa(int(c.G)), strconv.Itoa(int(c.B)),
+		}
+	}
+
+	return nil
+}
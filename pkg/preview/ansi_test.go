@@ -0,0 +1,44 @@
+package preview
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSGR(t *testing.T) {
+	var tests = []struct {
+		style string
+		sgr   string
+	}{
+		{style: "default", sgr: "\x1b[0m"},
+		{style: "fg=default,bg=default", sgr: "\x1b[0m"},
+		{style: "fg=red,bg=black", sgr: "\x1b[0;31;40m"},
+		{style: "fg=brightred,bg=brightblack", sgr: "\x1b[0;91;100m"},
+		{style: "fg=colour160,bg=colour234", sgr: "\x1b[0;38;5;160;48;5;234m"},
+		{style: "fg=#ff8000", sgr: "\x1b[0;38;2;255;128;0m"},
+		{style: "bold,italics,reverse", sgr: "\x1b[0;1;3;7m"},
+		{style: "curly-underscore,us=colour1", sgr: "\x1b[0;4:3;58;5;1m"},
+		{style: "overline,strikethrough", sgr: "\x1b[0;9;53m"},
+	}
+
+	for _, tt := range tests {
+		style, err := theme.ParseStyle(tt.style)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tt.sgr, SGR(style), tt.style)
+	}
+}
+
+func TestLineANSI(t *testing.T) {
+	base, _ := theme.ParseStyle("bg=black,fg=cyan")
+	line := ParseLine("ab#[fg=red]c#[default]d", base)
+
+	assert.Equal(
+		t,
+		"\x1b[0;36;40mab\x1b[0;31;40mc\x1b[0;36;40md\x1b[0m",
+		line.ANSI(),
+	)
+	assert.Equal(t, "", Line{}.ANSI())
+}
@@ -0,0 +1,60 @@
+package preview
+
+import (
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+var clockGlyphs = map[rune][5]string{
+	'0': {"11111", "10001", "10001", "10001", "11111"},
+	'1': {"00001", "00001", "00001", "00001", "00001"},
+	'2': {"11111", "00001", "11111", "10000", "11111"},
+	'3': {"11111", "00001", "11111", "00001", "11111"},
+	'4': {"10001", "10001", "11111", "00001", "00001"},
+	'5': {"11111", "10000", "11111", "00001", "11111"},
+	'6': {"11111", "10000", "11111", "10001", "11111"},
+	'7': {"11111", "00001", "00001", "00001", "00001"},
+	'8': {"11111", "10001", "11111", "10001", "11111"},
+	'9': {"11111", "10001", "11111", "00001", "11111"},
+	':': {"00000", "00100", "00000", "00100", "00000"},
+	'A': {"11111", "10001", "11111", "10001", "10001"},
+	'P': {"11111", "10001", "11111", "10000", "10000"},
+	'M': {"10001", "11011", "10101", "10001", "10001"},
+	' ': {"00000", "00000", "00000", "00000", "00000"},
+}
+
+// drawClock draws the time in tmux's clock-mode style centred within the
+// given area, falling back to plain text when the area is too small for the
+// large digits.
+func drawClock(
+	screen *Screen,
+	x, y, width, height int,
+	text string,
+	colour theme.Colour,
+	base theme.Style,
+) {
+	runes := []rune(text)
+
+	if width < len(runes)*6 || height < 6 {
+		style := base
+		style.Fg = colour
+		cx := x + (width-len(runes))/2
+		screen.Draw(cx, y+height/2, ParseLine(text, style))
+		return
+	}
+
+	on := base
+	on.Bg = colour
+	cx := x + (width-len(runes)*6)/2
+	cy := y + (height-5)/2
+
+	for i, r := range runes {
+		glyph := clockGlyphs[r]
+		for row, bits := range glyph {
+			for col, bit := range bits {
+				if bit == '1' {
+					screen.Set(cx+i*6+col, cy+row, ' ', on)
+				}
+			}
+		}
+	}
+}
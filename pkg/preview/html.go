@@ -0,0 +1,73 @@
+package preview
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+func WriteHTML(w io.Writer, screen *Screen, opts *OutputOptions) error {
+	if opts == nil {
+		opts = DefaultOutputOptions()
+	}
+
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(opts.Title))
+	fmt.Fprintf(
+		&b,
+		"<style>\npre.tmux { font-family: %s; font-size: %dpx; "+
+			"line-height: %dpx; color: %s; background: %s; "+
+			"display: inline-block; margin: 0; padding: 0; }\n</style>\n",
+		opts.FontFamily, opts.CellHeight*3/4, opts.CellHeight,
+		hexColour(opts.Foreground), hexColour(opts.Background),
+	)
+	b.WriteString("</head>\n<body>\n<pre class=\"tmux\">")
+
+	for y, row := range screen.Rows {
+		if y > 0 {
+			b.WriteString("\n")
+		}
+		for _, run := range lineRuns(row) {
+			text := html.EscapeString(string(run.text))
+			if run.style == (theme.Style{}) {
+				b.WriteString(text)
+				continue
+			}
+			fmt.Fprintf(
+				&b, `<span style="%s">%s</span>`,
+				htmlStyle(run.style, opts), text,
+			)
+		}
+	}
+
+	b.WriteString("</pre>\n</body>\n</html>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func htmlStyle(style theme.Style, opts *OutputOptions) string {
+	fg, bg := opts.Colours(style)
+	css := []string{"color: " + hexColour(fg), "background: " + hexColour(bg)}
+
+	if style.Attrs&theme.BrightAttr != 0 {
+		css = append(css, "font-weight: bold")
+	}
+	if style.Attrs&theme.ItalicsAttr != 0 {
+		css = append(css, "font-style: italic")
+	}
+	if style.Attrs&theme.DimAttr != 0 {
+		css = append(css, "opacity: 0.6")
+	}
+
+	decorations := textDecorations(style.Attrs)
+	if len(decorations) > 0 {
+		css = append(css, "text-decoration: "+strings.Join(decorations, " "))
+	}
+
+	return strings.Join(css, "; ")
+}
@@ -0,0 +1,54 @@
+package preview
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteHTML(t *testing.T) {
+	style, _ := theme.ParseStyle("bg=colour234,fg=#ff8000,italics")
+	screen := NewScreen(4, 2)
+	screen.Draw(0, 0, ParseLine("<a>", theme.Style{}))
+	screen.Draw(0, 1, ParseLine("ok", style))
+
+	var buf bytes.Buffer
+	err := WriteHTML(&buf, screen, nil)
+	require.NoError(t, err)
+
+	html := buf.String()
+	assert.Contains(t, html, "<title>tmux theme preview</title>")
+	assert.Contains(
+		t, html,
+		"<pre class=\"tmux\">&lt;a&gt; \n"+
+			`<span style="color: #ff8000; background: #1c1c1c; `+
+			`font-style: italic">ok</span>  </pre>`,
+	)
+}
+
+func TestOutputOptionsColours(t *testing.T) {
+	opts := DefaultOutputOptions()
+
+	var tests = []struct {
+		style string
+		fg    string
+		bg    string
+	}{
+		{"default", "#e5e5e5", "#1c1c1c"},
+		{"fg=red,bg=blue", "#cd0000", "#0000ee"},
+		{"fg=red,bg=blue,reverse", "#0000ee", "#cd0000"},
+		{"fg=red,bg=blue,hidden", "#0000ee", "#0000ee"},
+		{"fg=terminal,bg=colour255", "#e5e5e5", "#eeeeee"},
+	}
+
+	for _, tt := range tests {
+		style, _ := theme.ParseStyle(tt.style)
+		fg, bg := opts.Colours(style)
+
+		assert.Equal(t, tt.fg, hexColour(fg), tt.style)
+		assert.Equal(t, tt.bg, hexColour(bg), tt.style)
+	}
+}
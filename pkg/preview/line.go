@@ -0,0 +1,72 @@
+package preview
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+type Cell struct {
+	Rune  rune
+	Style theme.Style
+}
+
+type Line []Cell
+
+// ParseLine draws expanded format text into cells, applying #[...] style
+// blocks on top of base. "#[default]" resets to base, and invalid style
+// blocks are ignored like tmux does.
+func ParseLine(text string, base theme.Style) Line {
+	line := Line{}
+	style := base
+
+	for i := 0; i < len(text); {
+		if strings.HasPrefix(text[i:], "#[") {
+			end := strings.IndexByte(text[i:], ']')
+			if end >= 0 {
+				if s, err := style.Update(text[i+2:i+end], base); err == nil {
+					style = s
+				}
+				i += end + 1
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(text[i:])
+		line = append(line, Cell{Rune: r, Style: style})
+		i += size
+	}
+
+	return line
+}
+
+func FillLine(width int, style theme.Style) Line {
+	line := make(Line, width)
+	for i := range line {
+		line[i] = Cell{Rune: ' ', Style: style}
+	}
+
+	return line
+}
+
+// Truncate returns at most width cells from the start of the line.
+func (s Line) Truncate(width int) Line {
+	if width < 0 {
+		width = 0
+	}
+	if len(s) > width {
+		return s[:width]
+	}
+
+	return s
+}
+
+func (s Line) String() string {
+	runes := make([]rune, len(s))
+	for i, c := range s {
+		runes[i] = c.Rune
+	}
+
+	return string(runes)
+}
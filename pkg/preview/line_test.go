@@ -0,0 +1,72 @@
+package preview
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLine(t *testing.T) {
+	base, _ := theme.ParseStyle("bg=black,fg=cyan")
+	red, _ := theme.ParseStyle("bg=black,fg=red")
+	boldRed, _ := theme.ParseStyle("bg=black,fg=red,bold")
+
+	var tests = []struct {
+		text   string
+		str    string
+		styles []theme.Style
+	}{
+		{text: "", str: "", styles: []theme.Style{}},
+		{text: "ab", str: "ab", styles: []theme.Style{base, base}},
+		{
+			text:   "a#[fg=red]b#[bold]c#[default]d",
+			str:    "abcd",
+			styles: []theme.Style{base, red, boldRed, base},
+		},
+		{
+			text:   "a#[fg=nope]b",
+			str:    "ab",
+			styles: []theme.Style{base, base},
+		},
+		{
+			text:   "»#[unterminated",
+			str:    "»#[unterminated",
+			styles: nil,
+		},
+		{
+			text:   "a\xffb",
+			str:    "a\ufffdb",
+			styles: []theme.Style{base, base, base},
+		},
+	}
+
+	for _, tt := range tests {
+		line := ParseLine(tt.text, base)
+
+		assert.Equal(t, tt.str, line.String(), tt.text)
+		if tt.styles != nil {
+			styles := []theme.Style{}
+			for _, c := range line {
+				styles = append(styles, c.Style)
+			}
+			assert.Equal(t, tt.styles, styles, tt.text)
+		}
+	}
+}
+
+func TestLineTruncate(t *testing.T) {
+	line := ParseLine("abcdef", theme.Style{})
+
+	assert.Equal(t, "abc", line.Truncate(3).String())
+	assert.Equal(t, "abcdef", line.Truncate(10).String())
+	assert.Equal(t, "", line.Truncate(-1).String())
+}
+
+func TestFillLine(t *testing.T) {
+	style, _ := theme.ParseStyle("bg=blue")
+	line := FillLine(3, style)
+
+	assert.Equal(t, "   ", line.String())
+	assert.Equal(t, style, line[2].Style)
+}
@@ -0,0 +1,78 @@
+package preview
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+type OutputOptions struct {
+	CellWidth  int
+	CellHeight int
+	Foreground color.RGBA
+	Background color.RGBA
+	FontFamily string
+	Title      string
+}
+
+func DefaultOutputOptions() *OutputOptions {
+	return &OutputOptions{
+		CellWidth:  8,
+		CellHeight: 16,
+		Foreground: color.RGBA{0xe5, 0xe5, 0xe5, 0xff},
+		Background: color.RGBA{0x1c, 0x1c, 0x1c, 0xff},
+		FontFamily: "Menlo, Consolas, 'DejaVu Sans Mono', monospace",
+		Title:      "tmux theme preview",
+	}
+}
+
+// Colours resolves the foreground and background a cell is drawn with,
+// applying the reverse and hidden attributes.
+func (s *OutputOptions) Colours(style theme.Style) (color.RGBA, color.RGBA) {
+	fg := s.resolve(style.Fg, s.Foreground)
+	bg := s.resolve(style.Bg, s.Background)
+
+	if style.Attrs&theme.ReverseAttr != 0 {
+		fg, bg = bg, fg
+	}
+	if style.Attrs&theme.HiddenAttr != 0 {
+		fg = bg
+	}
+
+	return fg, bg
+}
+
+func (s *OutputOptions) resolve(c theme.Colour, fallback color.RGBA) color.RGBA {
+	r, g, b, ok := c.RGB()
+	if !ok {
+		return fallback
+	}
+
+	return color.RGBA{r, g, b, 0xff}
+}
+
+func hexColour(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// cellRun is a sequence of cells on one row sharing the same style.
+type cellRun struct {
+	x     int
+	text  []rune
+	style theme.Style
+}
+
+func lineRuns(line Line) []*cellRun {
+	runs := []*cellRun{}
+
+	for x, c := range line {
+		if len(runs) == 0 || runs[len(runs)-1].style != c.Style {
+			runs = append(runs, &cellRun{x: x, style: c.Style})
+		}
+		run := runs[len(runs)-1]
+		run.text = append(run.text, c.Rune)
+	}
+
+	return runs
+}
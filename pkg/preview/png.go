@@ -0,0 +1,207 @@
+package preview
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+const (
+	fontWidth  = 6
+	fontHeight = 13
+)
+
+// boxArms describes a box-drawing rune as the weight of the line running
+// from the cell centre towards each edge: 0 for none, 1 for light, 2 for
+// heavy and 3 for double.
+type boxArms struct {
+	up, down, left, right int
+}
+
+var boxRunes = map[rune]boxArms{
+	'─': {0, 0, 1, 1},
+	'━': {0, 0, 2, 2},
+	'│': {1, 1, 0, 0},
+	'┃': {2, 2, 0, 0},
+	'┌': {0, 1, 0, 1},
+	'┐': {0, 1, 1, 0},
+	'└': {1, 0, 0, 1},
+	'┘': {1, 0, 1, 0},
+	'├': {1, 1, 0, 1},
+	'┣': {2, 2, 0, 2},
+	'┤': {1, 1, 1, 0},
+	'┫': {2, 2, 2, 0},
+	'┬': {0, 1, 1, 1},
+	'┴': {1, 0, 1, 1},
+	'┼': {1, 1, 1, 1},
+	'═': {0, 0, 3, 3},
+	'║': {3, 3, 0, 0},
+	'╠': {3, 3, 0, 3},
+	'╣': {3, 3, 3, 0},
+}
+
+// WritePNG rasterizes screen to a PNG image using the bundled bitmap font
+// scaled to the cell size in opts.
+func WritePNG(w io.Writer, screen *Screen, opts *OutputOptions) error {
+	return png.Encode(w, Image(screen, opts))
+}
+
+// Image rasterizes screen to an RGBA image, one CellWidth by CellHeight
+// block of pixels per cell.
+func Image(screen *Screen, opts *OutputOptions) *image.RGBA {
+	if opts == nil {
+		opts = DefaultOutputOptions()
+	}
+
+	cw, ch := opts.CellWidth, opts.CellHeight
+	img := image.NewRGBA(image.Rect(0, 0, screen.Width*cw, screen.Height*ch))
+	draw.Draw(img, img.Bounds(), image.NewUniform(opts.Background),
+		image.Point{}, draw.Src)
+
+	for y, row := range screen.Rows {
+		for x, c := range row {
+			r := &rasterCell{
+				img:    img,
+				bounds: image.Rect(x*cw, y*ch, (x+1)*cw, (y+1)*ch),
+			}
+			r.draw(c, opts)
+		}
+	}
+
+	return img
+}
+
+type rasterCell struct {
+	img    *image.RGBA
+	bounds image.Rectangle
+	fg     color.RGBA
+}
+
+func (s *rasterCell) draw(c Cell, opts *OutputOptions) {
+	fg, bg := opts.Colours(c.Style)
+	if c.Style.Attrs&theme.DimAttr != 0 {
+		fg = blend(fg, bg)
+	}
+	s.fg = fg
+
+	draw.Draw(s.img, s.bounds, image.NewUniform(bg), image.Point{}, draw.Src)
+
+	if arms, ok := boxRunes[c.Rune]; ok {
+		s.box(arms)
+	} else if c.Rune != ' ' {
+		s.glyph(c.Rune, c.Style.Attrs)
+	}
+
+	s.decorate(c.Style.Attrs)
+}
+
+// glyph draws r from the bitmap font, scaling it to the cell with nearest
+// neighbour sampling. Runes outside printable ASCII use the replacement
+// glyph.
+func (s *rasterCell) glyph(r rune, attrs theme.Attributes) {
+	index := int(r) - 0x20
+	if index < 0 || index >= len(fontGlyphs) {
+		index = len(fontGlyphs) - 1
+	}
+	rows := fontGlyphs[index]
+
+	width, height := s.bounds.Dx(), s.bounds.Dy()
+	bold := attrs&theme.BrightAttr != 0
+	italic := attrs&theme.ItalicsAttr != 0
+
+	for py := 0; py < height; py++ {
+		bits := rows[py*fontHeight/height]
+		if bold {
+			bits |= bits >> 1
+		}
+
+		shift := 0
+		if italic {
+			shift = (height - py) * width / (height * 4)
+		}
+
+		for px := 0; px < width; px++ {
+			gx := (px - shift) * fontWidth / width
+			if gx < 0 || gx >= fontWidth || bits&(1<<uint(fontWidth-1-gx)) == 0 {
+				continue
+			}
+			s.img.SetRGBA(s.bounds.Min.X+px, s.bounds.Min.Y+py, s.fg)
+		}
+	}
+}
+
+// box draws a box-drawing rune procedurally so borders join up across
+// cells regardless of the cell size.
+func (s *rasterCell) box(arms boxArms) {
+	width, height := s.bounds.Dx(), s.bounds.Dy()
+	cx, cy := width/2, height/2
+
+	s.vertical(cx, 0, cy+1, arms.up)
+	s.vertical(cx, cy, height, arms.down)
+	s.horizontal(cy, 0, cx+1, arms.left)
+	s.horizontal(cy, cx, width, arms.right)
+}
+
+func (s *rasterCell) vertical(x, y0, y1, weight int) {
+	for _, dx := range lineOffsets(weight) {
+		s.rect(x+dx, y0, x+dx+1, y1)
+	}
+}
+
+func (s *rasterCell) horizontal(y, x0, x1, weight int) {
+	for _, dy := range lineOffsets(weight) {
+		s.rect(x0, y+dy, x1, y+dy+1)
+	}
+}
+
+func lineOffsets(weight int) []int {
+	switch weight {
+	case 1:
+		return []int{0}
+	case 2:
+		return []int{-1, 0, 1}
+	case 3:
+		return []int{-2, 2}
+	}
+
+	return nil
+}
+
+func (s *rasterCell) decorate(attrs theme.Attributes) {
+	width, height := s.bounds.Dx(), s.bounds.Dy()
+
+	if attrs&(theme.UnderscoreAttr|theme.CurlyUnderscoreAttr|
+		theme.DottedUnderscoreAttr|theme.DashedUnderscoreAttr) != 0 {
+		s.rect(0, height-2, width, height-1)
+	}
+	if attrs&theme.DoubleUnderscoreAttr != 0 {
+		s.rect(0, height-4, width, height-3)
+		s.rect(0, height-2, width, height-1)
+	}
+	if attrs&theme.StrikethroughAttr != 0 {
+		s.rect(0, height/2, width, height/2+1)
+	}
+	if attrs&theme.OverlineAttr != 0 {
+		s.rect(0, 0, width, 1)
+	}
+}
+
+// rect fills a rectangle relative to the cell origin with the foreground
+// colour, clipped to the cell.
+func (s *rasterCell) rect(x0, y0, x1, y1 int) {
+	r := image.Rect(x0, y0, x1, y1).Add(s.bounds.Min).Intersect(s.bounds)
+	draw.Draw(s.img, r, image.NewUniform(s.fg), image.Point{}, draw.Src)
+}
+
+// blend mixes fg towards bg to approximate the dim attribute.
+func blend(fg, bg color.RGBA) color.RGBA {
+	mix := func(a, b uint8) uint8 {
+		return uint8((int(a)*3 + int(b)*2) / 5)
+	}
+
+	return color.RGBA{mix(fg.R, bg.R), mix(fg.G, bg.G), mix(fg.B, bg.B), 0xff}
+}
@@ -0,0 +1,145 @@
+package preview
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWritePNG(t *testing.T) {
+	style, _ := theme.ParseStyle("bg=red,fg=white")
+	screen := NewScreen(4, 2)
+	screen.Draw(0, 0, ParseLine("A", theme.Style{}))
+	screen.Draw(1, 1, ParseLine("  ", style))
+
+	var buf bytes.Buffer
+	err := WritePNG(&buf, screen, nil)
+	require.NoError(t, err)
+
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, image.Rect(0, 0, 32, 32), img.Bounds())
+	assert.Equal(t, rgba(0x1c, 0x1c, 0x1c), colourAt(img, 30, 2))
+	assert.Equal(t, rgba(0xcd, 0x00, 0x00), colourAt(img, 12, 20))
+
+	lit := 0
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 8; x++ {
+			if colourAt(img, x, y) == rgba(0xe5, 0xe5, 0xe5) {
+				lit++
+			}
+		}
+	}
+	assert.True(t, lit > 0, "glyph pixels drawn")
+}
+
+func TestImageCellSize(t *testing.T) {
+	screen := NewScreen(3, 2)
+	opts := DefaultOutputOptions()
+	opts.CellWidth = 6
+	opts.CellHeight = 13
+
+	img := Image(screen, opts)
+
+	assert.Equal(t, image.Rect(0, 0, 18, 26), img.Bounds())
+}
+
+func TestImageGlyph(t *testing.T) {
+	screen := NewScreen(1, 1)
+	screen.Set(0, 0, 'A', theme.Style{})
+	opts := DefaultOutputOptions()
+	opts.CellWidth = fontWidth
+	opts.CellHeight = fontHeight
+
+	img := Image(screen, opts)
+
+	for y, bits := range fontGlyphs['A'-0x20] {
+		for x := 0; x < fontWidth; x++ {
+			want := opts.Background
+			if bits&(1<<uint(fontWidth-1-x)) != 0 {
+				want = opts.Foreground
+			}
+			assert.Equal(t, want, img.RGBAAt(x, y), "pixel %d,%d", x, y)
+		}
+	}
+}
+
+func TestImageBoxDrawing(t *testing.T) {
+	screen := NewScreen(3, 1)
+	screen.Set(0, 0, '─', theme.Style{})
+	screen.Set(1, 0, '├', theme.Style{})
+	screen.Set(2, 0, '═', theme.Style{})
+	opts := DefaultOutputOptions()
+
+	img := Image(screen, opts)
+
+	for x := 0; x < 24; x++ {
+		if x >= 8 && x < 12 {
+			continue
+		}
+		y := 8
+		if x >= 16 {
+			y = 6
+		}
+		assert.Equal(t, opts.Foreground, img.RGBAAt(x, y), "pixel %d,%d", x, y)
+	}
+	assert.Equal(t, opts.Foreground, img.RGBAAt(12, 0))
+	assert.Equal(t, opts.Foreground, img.RGBAAt(12, 15))
+	assert.Equal(t, opts.Background, img.RGBAAt(20, 8))
+}
+
+func TestImageAttributes(t *testing.T) {
+	var tests = []struct {
+		style string
+		x, y  int
+		want  color.RGBA
+	}{
+		{"underscore", 3, 14, rgba(0xe5, 0xe5, 0xe5)},
+		{"strikethrough", 3, 8, rgba(0xe5, 0xe5, 0xe5)},
+		{"overline", 3, 0, rgba(0xe5, 0xe5, 0xe5)},
+		{"reverse", 3, 3, rgba(0xe5, 0xe5, 0xe5)},
+		{"reverse,dim,underscore", 3, 14, rgba(0x6c, 0x6c, 0x6c)},
+	}
+
+	for _, tt := range tests {
+		style, _ := theme.ParseStyle(tt.style)
+		screen := NewScreen(1, 1)
+		screen.Set(0, 0, ' ', style)
+
+		img := Image(screen, nil)
+
+		assert.Equal(t, tt.want, img.RGBAAt(tt.x, tt.y), tt.style)
+	}
+}
+
+func TestImageRender(t *testing.T) {
+	th := theme.New()
+	err := th.Parse(strings.NewReader("set -g status-style bg=blue,fg=white"))
+	require.NoError(t, err)
+	require.NoError(t, th.Execute())
+
+	screen, err := Render(th, testState(80))
+	require.NoError(t, err)
+
+	img := Image(screen, nil)
+
+	assert.Equal(t, image.Rect(0, 0, 80*8, 24*16), img.Bounds())
+	assert.Equal(t, rgba(0x00, 0x00, 0xee), img.RGBAAt(639, 23*16+1))
+}
+
+func rgba(r, g, b uint8) color.RGBA {
+	return color.RGBA{r, g, b, 0xff}
+}
+
+func colourAt(img image.Image, x, y int) color.RGBA {
+	r, g, b, a := img.At(x, y).RGBA()
+	return color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), uint8(a >> 8)}
+}
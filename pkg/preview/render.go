@@ -0,0 +1,342 @@
+package preview
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+var borderRunes = map[string][3]rune{
+	"single": {'│', '─', '├'},
+	"double": {'║', '═', '╠'},
+	"heavy":  {'┃', '━', '┣'},
+	"simple": {'|', '-', '+'},
+}
+
+var shellLines = []string{
+	"$ ls",
+	"README.md  go.mod  go.sum  pkg",
+	"$ git status --short",
+	" M pkg/theme/theme.go",
+	"$ ",
+}
+
+var copyModeLines = []string{
+	"set -g status-style bg=black",
+	"set -g status-left \"#S\"",
+	"set -g status-right \"%H:%M\"",
+	"set -gw mode-style bg=red",
+}
+
+type pane struct {
+	x, y, width, height int
+	format              *theme.FormatPane
+}
+
+// Render draws a mock tmux screen for the executed theme: a window split
+// into three panes showing a shell, clock mode and a copy mode selection,
+// the pane borders, a message line and the status line.
+func Render(t *theme.Theme, state *State) (*Screen, error) {
+	r := &screenRenderer{
+		state:  state,
+		window: state.CurrentWindow(),
+		screen: NewScreen(state.Width, state.Height),
+	}
+	r.theme = t.WithContext(state.Context(r.window))
+	r.target = state.windowTarget(r.window)
+
+	return r.screen, r.render()
+}
+
+type screenRenderer struct {
+	theme  *theme.Theme
+	state  *State
+	window *theme.FormatWindow
+	target string
+	screen *Screen
+}
+
+func (s *screenRenderer) render() error {
+	status, err := s.theme.GetString(
+		theme.SessionScope, s.state.sessionTarget(), "status",
+	)
+	if err != nil {
+		return err
+	}
+	position, err := s.theme.GetString(
+		theme.SessionScope, s.state.sessionTarget(), "status-position",
+	)
+	if err != nil {
+		return err
+	}
+
+	top, height := 0, s.state.Height-1
+	messageRow := s.state.Height - 1
+	if status != "off" {
+		line, err := StatusLine(s.theme, s.state)
+		if err != nil {
+			return err
+		}
+
+		if position == "top" {
+			s.screen.Draw(0, 0, line)
+			top = 1
+		} else {
+			s.screen.Draw(0, s.state.Height-1, line)
+			messageRow--
+		}
+		height--
+	}
+
+	if err := s.message(messageRow); err != nil {
+		return err
+	}
+
+	return s.panes(top, height)
+}
+
+func (s *screenRenderer) message(row int) error {
+	style, err := s.theme.GetStyle(
+		theme.SessionScope, s.state.sessionTarget(), "message-style",
+	)
+	if err != nil {
+		return err
+	}
+
+	s.screen.Fill(0, row, s.state.Width, 1, style)
+	s.screen.Draw(0, row, ParseLine(s.state.Message, style))
+
+	return nil
+}
+
+func (s *screenRenderer) panes(top, height int) error {
+	width := s.state.Width
+	lw := (width - 1) / 2
+	rt := (height - 1) / 2
+
+	active := s.state.Pane
+	if active == nil {
+		active = &theme.FormatPane{Active: true}
+	}
+	left := &pane{x: 0, y: top, width: lw, height: height, format: active}
+	right := &pane{
+		x: lw + 1, y: top, width: width - lw - 1, height: rt,
+		format: &theme.FormatPane{Index: active.Index + 1, Title: "clock"},
+	}
+	bottom := &pane{
+		x: lw + 1, y: top + rt + 1, width: width - lw - 1,
+		height: height - rt - 1,
+		format: &theme.FormatPane{
+			Index: active.Index + 2, Title: "copy", InMode: true,
+			Mode: "copy-mode",
+		},
+	}
+
+	if err := s.borders(left, right, bottom, lw, top+rt); err != nil {
+		return err
+	}
+
+	for _, p := range []*pane{left, right, bottom} {
+		if err := s.paneStatus(p); err != nil {
+			return err
+		}
+	}
+
+	if err := s.shell(left); err != nil {
+		return err
+	}
+	if err := s.clock(right); err != nil {
+		return err
+	}
+
+	return s.copyMode(bottom)
+}
+
+func (s *screenRenderer) borders(left, right, bottom *pane, col, row int) error {
+	lines, err := s.theme.GetString(theme.WindowScope, s.target, "pane-border-lines")
+	if err != nil {
+		return err
+	}
+	activeStyle, err := s.theme.GetStyle(
+		theme.WindowScope, s.target, "pane-active-border-style",
+	)
+	if err != nil {
+		return err
+	}
+	style, err := s.theme.GetStyle(theme.WindowScope, s.target, "pane-border-style")
+	if err != nil {
+		return err
+	}
+
+	runes, ok := borderRunes[lines]
+	if lines == "number" {
+		runes = [3]rune{
+			rune('0' + left.format.Index%10),
+			rune('0' + right.format.Index%10),
+			'+',
+		}
+	} else if !ok {
+		runes = borderRunes["single"]
+	}
+
+	for y := left.y; y < left.y+left.height; y++ {
+		s.screen.Set(col, y, runes[0], activeStyle)
+	}
+	for x := right.x; x < right.x+right.width; x++ {
+		s.screen.Set(x, row, runes[1], style)
+	}
+	s.screen.Set(col, row, runes[2], activeStyle)
+
+	return nil
+}
+
+// paneStatus draws the pane-border-status line for p, shrinking the pane
+// so its content starts below or ends above it.
+func (s *screenRenderer) paneStatus(p *pane) error {
+	position, err := s.theme.GetString(
+		theme.WindowScope, s.target, "pane-border-status",
+	)
+	if err != nil || position == "off" {
+		return err
+	}
+
+	name := "pane-border-style"
+	if p.format.Active {
+		name = "pane-active-border-style"
+	}
+	style, err := s.theme.GetStyle(theme.WindowScope, s.target, name)
+	if err != nil {
+		return err
+	}
+	format, err := s.theme.GetString(
+		theme.WindowScope, s.target, "pane-border-format",
+	)
+	if err != nil {
+		return err
+	}
+
+	ctx := s.state.Context(s.window)
+	ctx.Pane = p.format
+	text := s.theme.WithContext(ctx).Expand(format)
+
+	row := p.y
+	if position == "bottom" {
+		row = p.y + p.height - 1
+	} else {
+		p.y++
+	}
+	p.height--
+
+	s.screen.Fill(p.x, row, p.width, 1, style)
+	s.screen.Draw(p.x, row, ParseLine(text, style).Truncate(p.width))
+
+	return nil
+}
+
+func (s *screenRenderer) paneStyle(p *pane) (theme.Style, error) {
+	style, err := s.theme.GetStyle(theme.WindowScope, s.target, "window-style")
+	if err != nil || !p.format.Active {
+		return style, err
+	}
+
+	value, err := s.theme.GetString(
+		theme.WindowScope, s.target, "window-active-style",
+	)
+	if err != nil {
+		return style, err
+	}
+
+	return style.Update(value, style)
+}
+
+func (s *screenRenderer) shell(p *pane) error {
+	style, err := s.paneStyle(p)
+	if err != nil {
+		return err
+	}
+
+	s.screen.Fill(p.x, p.y, p.width, p.height, style)
+	for i, text := range shellLines {
+		if i >= p.height {
+			break
+		}
+		s.screen.Draw(p.x, p.y+i, ParseLine(text, style).Truncate(p.width))
+	}
+
+	return nil
+}
+
+func (s *screenRenderer) clock(p *pane) error {
+	style, err := s.paneStyle(p)
+	if err != nil {
+		return err
+	}
+	colour, err := s.theme.GetColour(
+		theme.WindowScope, s.target, "clock-mode-colour",
+	)
+	if err != nil {
+		return err
+	}
+	mode, err := s.theme.GetString(
+		theme.WindowScope, s.target, "clock-mode-style",
+	)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	text := theme.Strftime("%H:%M", now)
+	if mode == "12" {
+		text = theme.Strftime("%l:%M %p", now)
+	}
+
+	s.screen.Fill(p.x, p.y, p.width, p.height, style)
+	drawClock(s.screen, p.x, p.y, p.width, p.height, text, colour, style)
+
+	return nil
+}
+
+func (s *screenRenderer) copyMode(p *pane) error {
+	style, err := s.paneStyle(p)
+	if err != nil {
+		return err
+	}
+	mode, err := s.theme.GetStyle(theme.WindowScope, s.target, "mode-style")
+	if err != nil {
+		return err
+	}
+	mode, _ = style.Update(mode.String(), style)
+
+	s.screen.Fill(p.x, p.y, p.width, p.height, style)
+	for i, text := range copyModeLines {
+		if i >= p.height {
+			break
+		}
+		line := ParseLine(text, style).Truncate(p.width)
+		if i == 1 || i == 2 {
+			start := 0
+			if i == 1 {
+				start = 7
+			}
+			for x := start; x < len(line); x++ {
+				line[x].Style = mode
+			}
+		}
+		s.screen.Draw(p.x, p.y+i, line)
+	}
+
+	position := "[0/" + strconv.Itoa(len(copyModeLines)) + "]"
+	indicator := ParseLine(position, mode)
+	s.screen.Draw(p.x+p.width-len(indicator), p.y, indicator)
+
+	return nil
+}
+
+func (s *screenRenderer) now() time.Time {
+	if s.state.Clock != nil {
+		return s.state.Clock()
+	}
+
+	return time.Now()
+}
@@ -0,0 +1,170 @@
+package preview
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testScreenState() *State {
+	state := testState(40)
+	state.Height = 12
+
+	return state
+}
+
+func TestRender(t *testing.T) {
+	th := theme.New()
+	require.NoError(t, th.Parse(strings.NewReader(`
+set -g status-left "#S "
+set -g status-right "%H:%M"
+set -g pane-active-border-style "fg=green"
+set -g pane-border-style "fg=colour240"
+set -g message-style "bg=yellow,fg=black"
+set -g mode-style "bg=red"
+set -g clock-mode-colour magenta
+`)))
+	require.NoError(t, th.Execute())
+
+	screen, err := Render(th, testScreenState())
+	require.NoError(t, err)
+
+	rows := strings.Split(screen.String(), "\n")
+	require.Len(t, rows, 12)
+
+	assert.Equal(t, "$ ls", strings.TrimSpace(rows[0][:strings.Index(rows[0], "│")]))
+	assert.Contains(t, rows[4], "├────")
+	assert.Equal(t, "Theme preview", strings.TrimSpace(rows[10]))
+	assert.True(t, strings.HasPrefix(rows[11], "main 1:bash- 2:vim* 3:logs#"))
+	assert.True(t, strings.HasSuffix(rows[11], "21:05"))
+
+	active, _ := theme.ParseStyle("fg=green")
+	inactive, _ := theme.ParseStyle("fg=colour240")
+	message, _ := theme.ParseStyle("bg=yellow,fg=black")
+	mode, _ := theme.ParseStyle("bg=red")
+
+	assert.Equal(t, active, screen.Rows[0][19].Style)
+	assert.Equal(t, '│', screen.Rows[0][19].Rune)
+	assert.Equal(t, active, screen.Rows[4][19].Style)
+	assert.Equal(t, inactive, screen.Rows[4][25].Style)
+	assert.Equal(t, message, screen.Rows[10][30].Style)
+	assert.Equal(t, mode, screen.Rows[5][39].Style)
+	assert.Equal(t, "[0/4]", screen.Rows[5][35:].String())
+	assert.Equal(t, mode, screen.Rows[6][27].Style)
+	assert.Equal(t, theme.Style{}, screen.Rows[6][26].Style)
+}
+
+func TestRenderClock(t *testing.T) {
+	th := theme.New()
+	err := th.Parse(strings.NewReader(`set -g clock-mode-colour colour160`))
+	require.NoError(t, err)
+	require.NoError(t, th.Execute())
+
+	state := testState(80)
+	state.Height = 24
+
+	screen, err := Render(th, state)
+	require.NoError(t, err)
+
+	colour := theme.Colour{Type: theme.PaletteColour, Index: 160}
+	cells := 0
+	for _, row := range screen.Rows {
+		for _, c := range row {
+			if c.Style.Bg == colour {
+				cells++
+			}
+		}
+	}
+
+	// "21:05" in clock digits: 2=17 cells, 1=5, :=2, 0=16, 5=17.
+	assert.Equal(t, 57, cells)
+}
+
+func TestRenderClockSmall(t *testing.T) {
+	th := theme.New()
+	require.NoError(t, th.Parse(strings.NewReader(`
+set -g clock-mode-colour red
+set -g clock-mode-style 12
+`)))
+	require.NoError(t, th.Execute())
+
+	state := testState(30)
+	state.Height = 8
+
+	screen, err := Render(th, state)
+	require.NoError(t, err)
+	assert.Contains(t, screen.String(), " 9:05 PM")
+}
+
+func TestRenderBorderLines(t *testing.T) {
+	var tests = []struct {
+		lines    string
+		vertical rune
+		corner   rune
+	}{
+		{"single", '│', '├'},
+		{"double", '║', '╠'},
+		{"heavy", '┃', '┣'},
+		{"simple", '|', '+'},
+		{"number", '0', '+'},
+	}
+
+	for _, tt := range tests {
+		th := theme.New()
+		err := th.Parse(strings.NewReader(`set -g pane-border-lines ` + tt.lines))
+		require.NoError(t, err)
+		require.NoError(t, th.Execute())
+
+		screen, err := Render(th, testScreenState())
+		require.NoError(t, err)
+
+		assert.Equal(t, tt.vertical, screen.Rows[0][19].Rune, tt.lines)
+		assert.Equal(t, tt.corner, screen.Rows[4][19].Rune, tt.lines)
+	}
+}
+
+func TestRenderPaneBorderStatus(t *testing.T) {
+	th := theme.New()
+	require.NoError(t, th.Parse(strings.NewReader(`
+set -g pane-border-status top
+set -g pane-border-format "[#{pane_index}:#{pane_title}]"
+set -g pane-active-border-style "fg=green"
+`)))
+	require.NoError(t, th.Execute())
+
+	screen, err := Render(th, testScreenState())
+	require.NoError(t, err)
+
+	active, _ := theme.ParseStyle("fg=green")
+	assert.Equal(t, "[0:localhost]", screen.Rows[0][:13].String())
+	assert.Equal(t, active, screen.Rows[0][0].Style)
+	assert.Equal(t, "[1:clock]", screen.Rows[0][20:29].String())
+	assert.Equal(t, "[2:copy]", screen.Rows[5][20:28].String())
+	assert.Equal(t, "$ ls", screen.Rows[1][:4].String())
+}
+
+func TestRenderStatusPosition(t *testing.T) {
+	th := theme.New()
+	require.NoError(t, th.Parse(strings.NewReader(`
+set -g status-position top
+set -g status-left "TOP"
+`)))
+	require.NoError(t, th.Execute())
+
+	screen, err := Render(th, testScreenState())
+	require.NoError(t, err)
+	assert.Equal(t, "TOP", screen.Rows[0][:3].String())
+	assert.Equal(t, "Theme preview", strings.TrimSpace(screen.Rows[11].String()))
+
+	th = theme.New()
+	require.NoError(t, th.Parse(strings.NewReader(`set -g status off`)))
+	require.NoError(t, th.Execute())
+
+	screen, err = Render(th, testScreenState())
+	require.NoError(t, err)
+	assert.Equal(t, "Theme preview", strings.TrimSpace(screen.Rows[11].String()))
+	assert.Equal(t, "$ ls", screen.Rows[0][:4].String())
+}
@@ -0,0 +1,63 @@
+package preview
+
+import (
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+type Screen struct {
+	Width  int
+	Height int
+	Rows   []Line
+}
+
+func NewScreen(width, height int) *Screen {
+	s := &Screen{Width: width, Height: height}
+	for i := 0; i < height; i++ {
+		s.Rows = append(s.Rows, FillLine(width, theme.Style{}))
+	}
+
+	return s
+}
+
+func (s *Screen) Set(x, y int, r rune, style theme.Style) {
+	if x < 0 || y < 0 || x >= s.Width || y >= s.Height {
+		return
+	}
+
+	s.Rows[y][x] = Cell{Rune: r, Style: style}
+}
+
+// Draw copies line onto the screen at x, y, clipping it at the right edge.
+func (s *Screen) Draw(x, y int, line Line) {
+	for i, c := range line {
+		s.Set(x+i, y, c.Rune, c.Style)
+	}
+}
+
+func (s *Screen) Fill(x, y, width, height int, style theme.Style) {
+	for row := y; row < y+height; row++ {
+		for col := x; col < x+width; col++ {
+			s.Set(col, row, ' ', style)
+		}
+	}
+}
+
+func (s *Screen) String() string {
+	rows := make([]string, len(s.Rows))
+	for i, row := range s.Rows {
+		rows[i] = row.String()
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+func (s *Screen) ANSI() string {
+	rows := make([]string, len(s.Rows))
+	for i, row := range s.Rows {
+		rows[i] = row.ANSI()
+	}
+
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,29 @@
+package preview
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScreen(t *testing.T) {
+	red, _ := theme.ParseStyle("fg=red")
+	screen := NewScreen(4, 2)
+
+	screen.Set(0, 0, 'a', red)
+	screen.Set(10, 10, 'x', red)
+	screen.Draw(2, 1, ParseLine("xyz", theme.Style{}))
+
+	assert.Equal(t, "a   \n  xy", screen.String())
+	assert.Equal(t, red, screen.Rows[0][0].Style)
+
+	screen.Fill(1, 0, 2, 2, red)
+	assert.Equal(t, "a   \n   y", screen.String())
+	assert.Equal(t, red, screen.Rows[1][2].Style)
+	assert.Equal(
+		t,
+		"\x1b[0;31ma  \x1b[0m \x1b[0m\n\x1b[0m \x1b[0;31m  \x1b[0my\x1b[0m",
+		screen.ANSI(),
+	)
+}
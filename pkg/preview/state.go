@@ -0,0 +1,95 @@
+package preview
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// State is the simulated tmux state a theme is previewed with. The active
+// window in Windows is the current window.
+type State struct {
+	Host    string
+	Session *theme.FormatSession
+	Windows []*theme.FormatWindow
+	Pane    *theme.FormatPane
+	Client  *theme.FormatClient
+	Message string
+	Width   int
+	Height  int
+	Clock   func() time.Time
+}
+
+func DefaultState() *State {
+	return &State{
+		Host:    "localhost",
+		Session: &theme.FormatSession{ID: 0, Name: "main", Windows: 3},
+		Windows: []*theme.FormatWindow{
+			{ID: 1, Index: 1, Name: "bash", Panes: 1, Last: true},
+			{ID: 2, Index: 2, Name: "vim", Panes: 2, Active: true},
+			{ID: 3, Index: 3, Name: "logs", Panes: 1, Activity: true},
+		},
+		Pane: &theme.FormatPane{
+			ID: 2, Index: 0, Title: "localhost", Active: true,
+			CurrentPath: "/home/user", CurrentCommand: "vim",
+		},
+		Client:  &theme.FormatClient{Name: "/dev/pts/0", TTY: "/dev/pts/0"},
+		Message: "Theme preview",
+		Width:   80,
+		Height:  24,
+	}
+}
+
+func (s *State) CurrentWindow() *theme.FormatWindow {
+	for _, w := range s.Windows {
+		if w.Active {
+			return w
+		}
+	}
+
+	if len(s.Windows) > 0 {
+		return s.Windows[0]
+	}
+
+	return nil
+}
+
+func (s *State) Context(window *theme.FormatWindow) *theme.FormatContext {
+	client := &theme.FormatClient{}
+	if s.Client != nil {
+		c := *s.Client
+		client = &c
+	}
+	if client.Width == 0 {
+		client.Width = s.Width
+	}
+	if client.Height == 0 {
+		client.Height = s.Height
+	}
+
+	return &theme.FormatContext{
+		Host:    s.Host,
+		Session: s.Session,
+		Window:  window,
+		Pane:    s.Pane,
+		Client:  client,
+		Clock:   s.Clock,
+	}
+}
+
+func (s *State) sessionTarget() string {
+	if s.Session == nil {
+		return ""
+	}
+
+	return s.Session.Name
+}
+
+func (s *State) windowTarget(window *theme.FormatWindow) string {
+	if s.Session == nil || window == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s:%d", s.Session.Name, window.Index)
+}
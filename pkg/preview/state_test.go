@@ -0,0 +1,33 @@
+package preview
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateCurrentWindow(t *testing.T) {
+	state := DefaultState()
+	assert.Equal(t, "vim", state.CurrentWindow().Name)
+
+	state.Windows = []*theme.FormatWindow{{Name: "a"}, {Name: "b"}}
+	assert.Equal(t, "a", state.CurrentWindow().Name)
+
+	state.Windows = nil
+	assert.Nil(t, state.CurrentWindow())
+}
+
+func TestStateContext(t *testing.T) {
+	state := DefaultState()
+	w := state.Windows[0]
+	ctx := state.Context(w)
+
+	assert.Equal(t, "localhost", ctx.Host)
+	assert.Equal(t, state.Session, ctx.Session)
+	assert.Equal(t, w, ctx.Window)
+	assert.Equal(t, 80, ctx.Client.Width)
+	assert.Equal(t, 24, ctx.Client.Height)
+	assert.Equal(t, "main:1", state.windowTarget(w))
+	assert.Equal(t, "main", state.sessionTarget())
+}
@@ -0,0 +1,231 @@
+package preview
+
+import (
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// StatusLine renders the status line tmux would draw for the executed theme
+// and simulated state: status-left, the window list and status-right laid
+// out according to status-justify and truncated to their *-length options.
+func StatusLine(t *theme.Theme, state *State) (Line, error) {
+	r := &statusRenderer{theme: t, state: state}
+
+	return r.render()
+}
+
+type statusRenderer struct {
+	theme *theme.Theme
+	state *State
+}
+
+func (s *statusRenderer) render() (Line, error) {
+	width := s.state.Width
+	session := s.state.sessionTarget()
+	current := s.state.CurrentWindow()
+	t := s.theme.WithContext(s.state.Context(current))
+
+	base, err := t.GetStyle(theme.SessionScope, session, "status-style")
+	if err != nil {
+		return nil, err
+	}
+
+	left, err := s.side(t, base, "status-left")
+	if err != nil {
+		return nil, err
+	}
+	right, err := s.side(t, base, "status-right")
+	if err != nil {
+		return nil, err
+	}
+	if len(left)+len(right) > width {
+		right = right.Truncate(width - len(left))
+		left = left.Truncate(width)
+	}
+
+	list, start, end, err := s.windowList(base)
+	if err != nil {
+		return nil, err
+	}
+
+	justify, err := t.GetString(theme.SessionScope, session, "status-justify")
+	if err != nil {
+		return nil, err
+	}
+
+	available := width - len(left) - len(right)
+	list = s.fitList(list, start, end, available, base)
+
+	offset := len(left)
+	switch justify {
+	case "right":
+		offset = width - len(right) - len(list)
+	case "centre":
+		offset = len(left) + (available-len(list))/2
+	case "absolute-centre":
+		offset = (width - len(list)) / 2
+		if offset < len(left) {
+			offset = len(left)
+		}
+		if offset+len(list) > width-len(right) {
+			offset = width - len(right) - len(list)
+		}
+	}
+
+	line := FillLine(width, base)
+	copy(line, left)
+	copy(line[offset:], list)
+	copy(line[width-len(right):], right)
+
+	return line, nil
+}
+
+func (s *statusRenderer) side(t *theme.Theme, base theme.Style, name string) (Line, error) {
+	session := s.state.sessionTarget()
+
+	style, err := s.style(t, theme.SessionScope, session, name+"-style", base)
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := t.GetInt(theme.SessionScope, session, name+"-length")
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := t.GetString(theme.SessionScope, session, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseLine(t.ExpandTime(format), style).Truncate(length), nil
+}
+
+// windowList draws all windows and returns the cell range of the current
+// window within the list.
+func (s *statusRenderer) windowList(base theme.Style) (Line, int, int, error) {
+	list := Line{}
+	start, end := 0, 0
+
+	for i, w := range s.state.Windows {
+		t := s.theme.WithContext(s.state.Context(w))
+		target := s.state.windowTarget(w)
+
+		if i > 0 {
+			sep, err := t.GetString(theme.WindowScope, target, "window-status-separator")
+			if err != nil {
+				return nil, 0, 0, err
+			}
+			list = append(list, ParseLine(t.ExpandTime(sep), base)...)
+		}
+
+		entry, err := s.window(t, w, target, base)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+
+		if w == s.state.CurrentWindow() {
+			start, end = len(list), len(list)+len(entry)
+		}
+		list = append(list, entry...)
+	}
+
+	return list, start, end, nil
+}
+
+func (s *statusRenderer) window(
+	t *theme.Theme,
+	w *theme.FormatWindow,
+	target string,
+	base theme.Style,
+) (Line, error) {
+	styles := []string{"window-status-style"}
+	format := "window-status-format"
+
+	if w.Active {
+		styles = append(styles, "window-status-current-style")
+		format = "window-status-current-format"
+	} else if w.Last {
+		styles = append(styles, "window-status-last-style")
+	}
+	if w.Bell {
+		styles = append(styles, "window-status-bell-style")
+	} else if w.Activity || w.Silence {
+		styles = append(styles, "window-status-activity-style")
+	}
+
+	style := base
+	for _, name := range styles {
+		var err error
+		style, err = s.style(t, theme.WindowScope, target, name, style)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	text, err := t.GetString(theme.WindowScope, target, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseLine(t.ExpandTime(text), style), nil
+}
+
+func (s *statusRenderer) style(
+	t *theme.Theme,
+	scope theme.Scope,
+	target, name string,
+	base theme.Style,
+) (theme.Style, error) {
+	value, err := t.GetString(scope, target, name)
+	if err != nil {
+		return base, err
+	}
+
+	return base.Update(value, base)
+}
+
+// fitList cuts the window list down to width cells, keeping the current
+// window visible and marking hidden windows with "<" and ">" like tmux.
+func (s *statusRenderer) fitList(
+	list Line,
+	start, end, width int,
+	base theme.Style,
+) Line {
+	if len(list) <= width {
+		return list
+	}
+	if width <= 2 {
+		return list[:0]
+	}
+
+	offset := 0
+	if end > width-1 {
+		offset = end - (width - 2)
+		if offset > start {
+			offset = start
+		}
+	}
+
+	fitted := Line{}
+	room := width
+	if offset > 0 {
+		fitted = append(fitted, Cell{Rune: '<', Style: base})
+		room--
+	}
+
+	rightMarker := len(list)-offset > room
+	if rightMarker {
+		room--
+	}
+
+	if offset+room > len(list) {
+		room = len(list) - offset
+	}
+
+	fitted = append(fitted, list[offset:offset+room]...)
+	if rightMarker {
+		fitted = append(fitted, Cell{Rune: '>', Style: base})
+	}
+
+	return fitted
+}
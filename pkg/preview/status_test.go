@@ -0,0 +1,183 @@
+package preview
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testState(width int) *State {
+	state := DefaultState()
+	state.Width = width
+	state.Clock = func() time.Time {
+		return time.Date(2019, 3, 7, 21, 5, 3, 0, time.UTC)
+	}
+
+	return state
+}
+
+func TestStatusLine(t *testing.T) {
+	var tests = []struct {
+		name  string
+		body  string
+		width int
+		text  string
+	}{
+		{
+			name:  "tmux defaults",
+			width: 60,
+			text: "[main] 1:bash- 2:vim* 3:logs#    " +
+				"\"localhost\" 21:05 07-Mar-19",
+		},
+		{
+			name: "justify right",
+			body: `
+set -g status-left "L"
+set -g status-right "R"
+set -g status-justify right
+set -g window-status-format "#I"
+set -g window-status-current-format "#I"
+`,
+			width: 20,
+			text:  "L             1 2 3R",
+		},
+		{
+			name: "justify centre",
+			body: `
+set -g status-left "LL"
+set -g status-right "R"
+set -g status-justify centre
+set -g window-status-format "#I"
+set -g window-status-current-format "#I"
+set -g window-status-separator ""
+`,
+			width: 20,
+			text:  "LL       123       R",
+		},
+		{
+			name: "justify absolute-centre",
+			body: `
+set -g status-left "LLLL"
+set -g status-right ""
+set -g status-justify absolute-centre
+set -g window-status-format "#I"
+set -g window-status-current-format "#I"
+set -g window-status-separator ""
+`,
+			width: 20,
+			text:  "LLLL    123         ",
+		},
+		{
+			name: "length truncation",
+			body: `
+set -g status-left "#S is a long session name"
+set -g status-left-length 6
+set -g status-right "%H:%M:%S right"
+set -g status-right-length 5
+`,
+			width: 40,
+			text:  "main i1:bash- 2:vim* 3:logs#       21:05",
+		},
+		{
+			name: "window list overflow",
+			body: `
+set -g status-left ""
+set -g status-right ""
+set -g window-status-format "#I:#W"
+set -g window-status-current-format "#I:#W"
+`,
+			width: 12,
+			text:  "<bash 2:vim>",
+		},
+		{
+			name: "theme file formats",
+			body: `
+set -g status-left "#S #[fg=white]» #[fg=yellow]#I #[fg=cyan]#P"
+set -g status-right "#H #[fg=white]« #[fg=yellow]%H:%M:%S #[fg=green]%d-%b-%y"
+set -g status-left-length 40
+set -g window-status-format " #I:#W#F "
+set -g window-status-current-format " #I:#W#F "
+set -g window-status-separator ""
+set -g status-justify centre
+`,
+			width: 80,
+			text: "main » 2 0        1:bash-  2:vim*  3:logs#        " +
+				"localhost « 21:05:03 07-Mar-19",
+		},
+	}
+
+	for _, tt := range tests {
+		th := theme.New()
+		require.NoError(t, th.Parse(strings.NewReader(tt.body)))
+		require.NoError(t, th.Execute())
+
+		line, err := StatusLine(th, testState(tt.width))
+
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.text, line.String(), tt.name)
+		assert.Len(t, line, tt.width, tt.name)
+	}
+}
+
+func TestStatusLineStyles(t *testing.T) {
+	th := theme.New()
+	require.NoError(t, th.Parse(strings.NewReader(`
+set -g status-style "bg=black,fg=cyan"
+set -g status-left "#[fg=red]L#[default]l"
+set -g status-left-style "fg=green"
+set -g status-right ""
+set -g window-status-format "#I"
+set -g window-status-current-format "#I"
+set -g window-status-current-style "bg=red,fg=black,bold"
+set -g window-status-activity-style "fg=yellow"
+set -g window-status-separator ""
+`)))
+	require.NoError(t, th.Execute())
+
+	line, err := StatusLine(th, testState(6))
+	require.NoError(t, err)
+	assert.Equal(t, "Ll123 ", line.String())
+
+	base, _ := theme.ParseStyle("bg=black,fg=cyan")
+	left, _ := theme.ParseStyle("bg=black,fg=green")
+	leftRed, _ := theme.ParseStyle("bg=black,fg=red")
+	current, _ := theme.ParseStyle("bg=red,fg=black,bold")
+	activity, _ := theme.ParseStyle("bg=black,fg=yellow")
+
+	assert.Equal(t, leftRed, line[0].Style)
+	assert.Equal(t, left, line[1].Style)
+	assert.Equal(t, base, line[2].Style)
+	assert.Equal(t, current, line[3].Style)
+	assert.Equal(t, activity, line[4].Style)
+	assert.Equal(t, base, line[5].Style)
+}
+
+func TestStatusLineTargetedOptions(t *testing.T) {
+	th := theme.New()
+	require.NoError(t, th.Parse(strings.NewReader(`
+set -g status-left ""
+set -g status-right ""
+set -g window-status-format "#I"
+set -g window-status-current-format "#I"
+set -t main:3 -w window-status-format "(#I)"
+`)))
+	require.NoError(t, th.Execute())
+
+	line, err := StatusLine(th, testState(12))
+	require.NoError(t, err)
+	assert.Equal(t, "1 2 (3)     ", line.String())
+}
+
+func TestStatusLineInvalidStyle(t *testing.T) {
+	th := theme.New()
+	err := th.Parse(strings.NewReader(`set -g status-style "fg=nope"`))
+	require.NoError(t, err)
+	require.NoError(t, th.Execute())
+
+	_, err = StatusLine(th, testState(20))
+	assert.Error(t, err)
+}
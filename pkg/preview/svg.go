@@ -0,0 +1,102 @@
+package preview
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+func WriteSVG(w io.Writer, screen *Screen, opts *OutputOptions) error {
+	if opts == nil {
+		opts = DefaultOutputOptions()
+	}
+
+	cw, ch := opts.CellWidth, opts.CellHeight
+	width, height := screen.Width*cw, screen.Height*ch
+
+	var b strings.Builder
+	fmt.Fprintf(
+		&b,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" `+
+			`viewBox="0 0 %d %d" font-family="%s" font-size="%d">`+"\n",
+		width, height, width, height,
+		html.EscapeString(opts.FontFamily), ch*3/4,
+	)
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(opts.Title))
+	fmt.Fprintf(
+		&b, `<rect width="%d" height="%d" fill="%s"/>`+"\n",
+		width, height, hexColour(opts.Background),
+	)
+
+	for y, row := range screen.Rows {
+		for _, run := range lineRuns(row) {
+			fg, bg := opts.Colours(run.style)
+			if bg != opts.Background {
+				fmt.Fprintf(
+					&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+					run.x*cw, y*ch, len(run.text)*cw, ch, hexColour(bg),
+				)
+			}
+
+			text := string(run.text)
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+			fmt.Fprintf(
+				&b,
+				`<text x="%d" y="%d" fill="%s" textLength="%d"%s `+
+					`xml:space="preserve">%s</text>`+"\n",
+				run.x*cw, y*ch+ch*4/5, hexColour(fg), len(run.text)*cw,
+				svgAttributes(run.style.Attrs), html.EscapeString(text),
+			)
+		}
+	}
+
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func svgAttributes(attrs theme.Attributes) string {
+	s := ""
+
+	if attrs&theme.BrightAttr != 0 {
+		s += ` font-weight="bold"`
+	}
+	if attrs&theme.ItalicsAttr != 0 {
+		s += ` font-style="italic"`
+	}
+	if attrs&theme.DimAttr != 0 {
+		s += ` opacity="0.6"`
+	}
+
+	decorations := textDecorations(attrs)
+	if len(decorations) > 0 {
+		s += ` text-decoration="` + strings.Join(decorations, " ") + `"`
+	}
+
+	return s
+}
+
+func textDecorations(attrs theme.Attributes) []string {
+	decorations := []string{}
+
+	underscores := theme.UnderscoreAttr | theme.DoubleUnderscoreAttr |
+		theme.CurlyUnderscoreAttr | theme.DottedUnderscoreAttr |
+		theme.DashedUnderscoreAttr
+	if attrs&underscores != 0 {
+		decorations = append(decorations, "underline")
+	}
+	if attrs&theme.OverlineAttr != 0 {
+		decorations = append(decorations, "overline")
+	}
+	if attrs&theme.StrikethroughAttr != 0 {
+		decorations = append(decorations, "line-through")
+	}
+
+	return decorations
+}
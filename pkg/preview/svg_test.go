@@ -0,0 +1,61 @@
+package preview
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSVG(t *testing.T) {
+	style, _ := theme.ParseStyle("bg=red,fg=white,bold")
+	screen := NewScreen(6, 2)
+	screen.Draw(0, 0, ParseLine("a<b", theme.Style{}))
+	screen.Draw(1, 1, ParseLine("ok", style))
+
+	var buf bytes.Buffer
+	err := WriteSVG(&buf, screen, nil)
+	require.NoError(t, err)
+
+	svg := buf.String()
+	assert.Contains(t, svg, `width="48" height="32"`)
+	assert.Contains(t, svg, `<rect width="48" height="32" fill="#1c1c1c"/>`)
+	assert.Contains(
+		t, svg,
+		`<text x="0" y="12" fill="#e5e5e5" textLength="48" `+
+			`xml:space="preserve">a&lt;b   </text>`,
+	)
+	assert.Contains(
+		t, svg, `<rect x="8" y="16" width="16" height="16" fill="#cd0000"/>`,
+	)
+	assert.Contains(
+		t, svg,
+		`<text x="8" y="28" fill="#e5e5e5" textLength="16" `+
+			`font-weight="bold" xml:space="preserve">ok</text>`,
+	)
+
+	assert.NoError(t, xml.Unmarshal(buf.Bytes(), new(interface{})))
+}
+
+func TestSVGAttributes(t *testing.T) {
+	var tests = []struct {
+		style string
+		attrs string
+	}{
+		{"default", ""},
+		{"bold", ` font-weight="bold"`},
+		{"italics,dim", ` font-style="italic" opacity="0.6"`},
+		{
+			"curly-underscore,strikethrough",
+			` text-decoration="underline line-through"`,
+		},
+	}
+
+	for _, tt := range tests {
+		style, _ := theme.ParseStyle(tt.style)
+		assert.Equal(t, tt.attrs, svgAttributes(style.Attrs), tt.style)
+	}
+}
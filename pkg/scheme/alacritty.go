@@ -0,0 +1,64 @@
+package scheme
+
+import (
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+type alacrittyConfig struct {
+	Colors struct {
+		Primary struct {
+			Background string `toml:"background" yaml:"background"`
+			Foreground string `toml:"foreground" yaml:"foreground"`
+		} `toml:"primary" yaml:"primary"`
+		Normal alacrittyColours `toml:"normal" yaml:"normal"`
+		Bright alacrittyColours `toml:"bright" yaml:"bright"`
+	} `toml:"colors" yaml:"colors"`
+}
+
+type alacrittyColours struct {
+	Black   string `toml:"black" yaml:"black"`
+	Red     string `toml:"red" yaml:"red"`
+	Green   string `toml:"green" yaml:"green"`
+	Yellow  string `toml:"yellow" yaml:"yellow"`
+	Blue    string `toml:"blue" yaml:"blue"`
+	Magenta string `toml:"magenta" yaml:"magenta"`
+	Cyan    string `toml:"cyan" yaml:"cyan"`
+	White   string `toml:"white" yaml:"white"`
+}
+
+func (s *alacrittyColours) list() []string {
+	return []string{
+		s.Black, s.Red, s.Green, s.Yellow,
+		s.Blue, s.Magenta, s.Cyan, s.White,
+	}
+}
+
+func parseAlacrittyTOML(b []byte) (*Scheme, error) {
+	config := &alacrittyConfig{}
+	_, err := toml.Decode(string(b), config)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.scheme()
+}
+
+func parseAlacrittyYAML(b []byte) (*Scheme, error) {
+	config := &alacrittyConfig{}
+	err := yaml.Unmarshal(b, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.scheme()
+}
+
+func (s *alacrittyConfig) scheme() (*Scheme, error) {
+	values := append(
+		[]string{s.Colors.Primary.Foreground, s.Colors.Primary.Background},
+		append(s.Colors.Normal.list(), s.Colors.Bright.list()...)...,
+	)
+
+	return newScheme(values)
+}
@@ -0,0 +1,30 @@
+package scheme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAlacrittyTOMLPartial(t *testing.T) {
+	s, err := parseAlacrittyTOML([]byte(`
+[window]
+opacity = 0.9
+
+[colors.primary]
+background = "#282828"
+foreground = "#ebdbb2"
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, &Scheme{Foreground: "#ebdbb2", Background: "#282828"}, s)
+}
+
+func TestParseAlacrittyErrors(t *testing.T) {
+	_, err := parseAlacrittyTOML([]byte("[colors"))
+	assert.Error(t, err)
+
+	_, err = parseAlacrittyYAML([]byte("colors: ["))
+	assert.Error(t, err)
+}
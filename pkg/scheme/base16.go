@@ -0,0 +1,78 @@
+package scheme
+
+import (
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/generator"
+	"gopkg.in/yaml.v2"
+)
+
+// base16ANSI maps ANSI colour indexes to base16 colours, as base16-shell
+// does.
+var base16ANSI = [16]string{
+	"base00", "base08", "base0B", "base0A",
+	"base0D", "base0E", "base0C", "base05",
+	"base03", "base08", "base0B", "base0A",
+	"base0D", "base0E", "base0C", "base07",
+}
+
+// parseBase16 reads a base16 scheme, either the original flat format with
+// "scheme" and baseXX keys or the newer format with "name" and a "palette"
+// map.
+func parseBase16(b []byte) (*Scheme, error) {
+	doc := struct {
+		Scheme  string            `yaml:"scheme"`
+		Name    string            `yaml:"name"`
+		Palette map[string]string `yaml:"palette"`
+	}{}
+	err := yaml.Unmarshal(b, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	colours := doc.Palette
+	if colours == nil {
+		colours = map[string]string{}
+		err = yaml.Unmarshal(b, &colours)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	base := map[string]string{}
+	for key, value := range colours {
+		key = strings.ToLower(key)
+		if !strings.HasPrefix(key, "base") || len(key) != 6 {
+			continue
+		}
+		key = "base" + strings.ToUpper(key[4:])
+
+		c, err := normalizeColour(value)
+		if err != nil {
+			return nil, err
+		}
+		base[key] = c
+	}
+
+	s := &Scheme{
+		Name:       doc.Scheme,
+		Foreground: base["base05"],
+		Background: base["base00"],
+		palette: &generator.Palette{
+			Base:    base["base00"],
+			Surface: base["base01"],
+			Text:    base["base05"],
+			Muted:   base["base03"],
+			Accent:  base["base0D"],
+			Warning: base["base0A"],
+		},
+	}
+	if doc.Name != "" {
+		s.Name = doc.Name
+	}
+	for i, key := range base16ANSI {
+		s.Colours[i] = base[key]
+	}
+
+	return s, nil
+}
@@ -0,0 +1,43 @@
+package scheme
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadBase16(t *testing.T) {
+	for _, filename := range []string{
+		"testdata/base16-tomorrow-night.yaml",
+		"testdata/base16-palette.yaml",
+	} {
+		s, err := Load(filename)
+		require.NoError(t, err, filename)
+
+		assert.Equal(t, "Tomorrow Night", s.Name)
+		assert.Equal(t, "#1d1f21", s.Background)
+		assert.Equal(t, "#c5c8c6", s.Foreground)
+		assert.Equal(t, [16]string{
+			"#1d1f21", "#cc6666", "#b5bd68", "#f0c674",
+			"#81a2be", "#b294bb", "#8abeb7", "#c5c8c6",
+			"#969896", "#cc6666", "#b5bd68", "#f0c674",
+			"#81a2be", "#b294bb", "#8abeb7", "#ffffff",
+		}, s.Colours)
+		assert.Equal(t, generator.Palette{
+			Base:    "#1d1f21",
+			Surface: "#282a2e",
+			Text:    "#c5c8c6",
+			Muted:   "#969896",
+			Accent:  "#81a2be",
+			Warning: "#f0c674",
+		}, s.Palette())
+	}
+}
+
+func TestParseBase16InvalidColour(t *testing.T) {
+	_, err := parseBase16([]byte("scheme: Bad\nbase00: \"zzzzzz\"\n"))
+
+	assert.EqualError(t, err, "Invalid colour: zzzzzz")
+}
@@ -0,0 +1,136 @@
+package scheme
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+type Format int
+
+const (
+	UnknownFormat Format = iota
+	Base16Format
+	AlacrittyTOMLFormat
+	AlacrittyYAMLFormat
+	KittyFormat
+	WindowsTerminalFormat
+	XresourcesFormat
+)
+
+var formatNames = map[Format]string{
+	Base16Format:          "base16",
+	AlacrittyTOMLFormat:   "alacritty-toml",
+	AlacrittyYAMLFormat:   "alacritty-yaml",
+	KittyFormat:           "kitty",
+	WindowsTerminalFormat: "windows-terminal",
+	XresourcesFormat:      "xresources",
+}
+
+var formatParsers = map[Format]func([]byte) (*Scheme, error){
+	Base16Format:          parseBase16,
+	AlacrittyTOMLFormat:   parseAlacrittyTOML,
+	AlacrittyYAMLFormat:   parseAlacrittyYAML,
+	KittyFormat:           parseKitty,
+	WindowsTerminalFormat: parseWindowsTerminal,
+	XresourcesFormat:      parseXresources,
+}
+
+func (s Format) String() string {
+	if name, ok := formatNames[s]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// ParseFormat returns the format with the given name, as returned by
+// Format.String.
+func ParseFormat(name string) (Format, bool) {
+	for f, n := range formatNames {
+		if n == name {
+			return f, true
+		}
+	}
+
+	return UnknownFormat, false
+}
+
+// DetectFormat guesses the format of a scheme from its filename and, for
+// YAML files which may be base16 schemes or Alacritty configs, content.
+func DetectFormat(filename string, content []byte) Format {
+	base := strings.ToLower(filepath.Base(filename))
+	ext := filepath.Ext(base)
+
+	switch {
+	case ext == ".toml":
+		return AlacrittyTOMLFormat
+	case ext == ".yml" || ext == ".yaml":
+		if bytes.Contains(content, []byte("colors:")) {
+			return AlacrittyYAMLFormat
+		}
+		return Base16Format
+	case ext == ".json":
+		return WindowsTerminalFormat
+	case ext == ".conf" || strings.HasPrefix(base, "kitty"):
+		return KittyFormat
+	case strings.Contains(base, "xresources") ||
+		strings.Contains(base, "xdefaults") || ext == ".xresources":
+		return XresourcesFormat
+	}
+
+	return UnknownFormat
+}
+
+// Parse reads a scheme in the given format.
+func Parse(r io.Reader, format Format) (*Scheme, error) {
+	parse, ok := formatParsers[format]
+	if !ok {
+		return nil, &UnknownFormatError{Filename: format.String()}
+	}
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := parse(b)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Load reads the scheme in filename, detecting its format. Schemes without
+// a name of their own are named after the file.
+func Load(filename string) (*Scheme, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	format := DetectFormat(filename, b)
+	if format == UnknownFormat {
+		return nil, &UnknownFormatError{Filename: filename}
+	}
+
+	s, err := Parse(bytes.NewReader(b), format)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Name == "" {
+		base := filepath.Base(filename)
+		s.Name = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+
+	return s, nil
+}
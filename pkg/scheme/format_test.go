@@ -0,0 +1,104 @@
+package scheme
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var tomorrowNight = [16]string{
+	"#1d1f21", "#cc6666", "#b5bd68", "#f0c674",
+	"#81a2be", "#b294bb", "#8abeb7", "#c5c8c6",
+	"#666666", "#d54e53", "#b9ca4a", "#e7c547",
+	"#7aa6da", "#c397d8", "#70c0b1", "#eaeaea",
+}
+
+func TestLoad(t *testing.T) {
+	var tests = []struct {
+		filename string
+		name     string
+	}{
+		{"testdata/tomorrow-night.toml", "tomorrow-night"},
+		{"testdata/alacritty.yml", "alacritty"},
+		{"testdata/kitty.conf", "kitty"},
+		{"testdata/windows-terminal.json", "Tomorrow Night"},
+		{"testdata/tomorrow-night.Xresources", "tomorrow-night"},
+	}
+
+	for _, tt := range tests {
+		s, err := Load(tt.filename)
+		require.NoError(t, err, tt.filename)
+
+		assert.Equal(t, &Scheme{
+			Name:       tt.name,
+			Foreground: "#c5c8c6",
+			Background: "#1d1f21",
+			Colours:    tomorrowNight,
+		}, s, tt.filename)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	_, err := Load("testdata/missing.toml")
+	assert.Error(t, err)
+
+	_, err = Load("format_test.go")
+	assert.Equal(t, &UnknownFormatError{Filename: "format_test.go"}, err)
+}
+
+func TestDetectFormat(t *testing.T) {
+	var tests = []struct {
+		filename string
+		content  string
+		format   Format
+	}{
+		{"alacritty.toml", "", AlacrittyTOMLFormat},
+		{"alacritty.yml", "colors:\n  primary:", AlacrittyYAMLFormat},
+		{"ocean.yaml", "scheme: Ocean\nbase00: 2b303b", Base16Format},
+		{"kitty.conf", "", KittyFormat},
+		{"kitty-theme", "", KittyFormat},
+		{"Campbell.json", "{}", WindowsTerminalFormat},
+		{".Xresources", "", XresourcesFormat},
+		{"themes/dark.xresources", "", XresourcesFormat},
+		{".Xdefaults", "", XresourcesFormat},
+		{"colours.ini", "", UnknownFormat},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(
+			t, tt.format, DetectFormat(tt.filename, []byte(tt.content)),
+			tt.filename,
+		)
+	}
+}
+
+func TestFormatString(t *testing.T) {
+	for format := range formatParsers {
+		parsed, ok := ParseFormat(format.String())
+
+		assert.True(t, ok)
+		assert.Equal(t, format, parsed)
+	}
+
+	assert.Equal(t, "unknown", UnknownFormat.String())
+	_, ok := ParseFormat("iterm")
+	assert.False(t, ok)
+}
+
+func TestParse(t *testing.T) {
+	s, err := Parse(strings.NewReader("foreground #fff\nbackground #000\n"), KittyFormat)
+	require.NoError(t, err)
+	assert.Equal(t, "#ffffff", s.Foreground)
+	assert.Equal(t, "#000000", s.Background)
+
+	_, err = Parse(strings.NewReader("foreground #fff\n"), KittyFormat)
+	assert.Equal(t, &MissingColourError{Colour: "background"}, err)
+
+	_, err = Parse(strings.NewReader("background #000\n"), KittyFormat)
+	assert.Equal(t, &MissingColourError{Colour: "foreground"}, err)
+
+	_, err = Parse(strings.NewReader(""), UnknownFormat)
+	assert.Equal(t, &UnknownFormatError{Filename: "unknown"}, err)
+}
@@ -0,0 +1,69 @@
+package scheme
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// parseKitty reads the colour settings of a kitty.conf: foreground,
+// background and color0 to color15. Other settings are ignored.
+func parseKitty(b []byte) (*Scheme, error) {
+	values := make([]string, 18)
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		if i := colourSlot(fields[0]); i >= 0 {
+			values[i] = fields[1]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return newScheme(values)
+}
+
+// colourSlot returns the index in the values passed to newScheme for a
+// setting named foreground, background or colorN, or -1 for any other name.
+func colourSlot(name string) int {
+	switch name {
+	case "foreground":
+		return 0
+	case "background":
+		return 1
+	}
+
+	if strings.HasPrefix(name, "color") {
+		n, err := strconv.Atoi(name[len("color"):])
+		if err == nil && n >= 0 && n < 16 {
+			return n + 2
+		}
+	}
+
+	return -1
+}
+
+// newScheme builds a scheme from the foreground, background and ANSI
+// colours, in that order.
+func newScheme(values []string) (*Scheme, error) {
+	colours := make([]string, len(values))
+	for i, v := range values {
+		c, err := normalizeColour(v)
+		if err != nil {
+			return nil, err
+		}
+		colours[i] = c
+	}
+
+	s := &Scheme{Foreground: colours[0], Background: colours[1]}
+	copy(s.Colours[:], colours[2:])
+
+	return s, nil
+}
@@ -0,0 +1,32 @@
+package scheme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColourSlot(t *testing.T) {
+	var tests = []struct {
+		name string
+		slot int
+	}{
+		{"foreground", 0},
+		{"background", 1},
+		{"color0", 2},
+		{"color15", 17},
+		{"color16", -1},
+		{"colorx", -1},
+		{"cursor", -1},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.slot, colourSlot(tt.name), tt.name)
+	}
+}
+
+func TestParseKittyInvalidColour(t *testing.T) {
+	_, err := parseKitty([]byte("color1 red\n"))
+
+	assert.EqualError(t, err, "Invalid colour: red")
+}
@@ -0,0 +1,11 @@
+package scheme
+
+import "fmt"
+
+type MissingColourError struct {
+	Colour string
+}
+
+func (s *MissingColourError) Error() string {
+	return fmt.Sprintf("Missing colour in scheme: %s", s.Colour)
+}
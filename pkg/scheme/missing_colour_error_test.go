@@ -0,0 +1,17 @@
+package scheme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingColourErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &MissingColourError{})
+}
+
+func TestMissingColourError(t *testing.T) {
+	err := &MissingColourError{Colour: "background"}
+
+	assert.Equal(t, "Missing colour in scheme: background", err.Error())
+}
@@ -0,0 +1,121 @@
+// Package scheme imports terminal colour schemes, such as base16 schemes and
+// terminal emulator configs, and turns them into matching tmux themes.
+package scheme
+
+import (
+	"io"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/generator"
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// ANSI colour indexes used when mapping a scheme to a palette.
+const (
+	black       = 0
+	yellow      = 3
+	blue        = 4
+	brightBlack = 8
+)
+
+// Scheme is a terminal colour scheme: the default foreground and background
+// and the 16 ANSI colours. Colours are "#rrggbb" strings, empty when the
+// source did not define them.
+type Scheme struct {
+	Name       string
+	Foreground string
+	Background string
+	Colours    [16]string
+
+	// palette, when set, overrides the palette derived from the ANSI
+	// colours. base16 schemes set it as they name their colours by role.
+	palette *generator.Palette
+}
+
+// Palette maps the scheme to a theme palette: the background as the base,
+// black as the surface, bright black as muted, blue as the accent and
+// yellow for warnings. Colours missing from the scheme are left empty so
+// the generator's defaults apply.
+func (s *Scheme) Palette() generator.Palette {
+	if s.palette != nil {
+		return *s.palette
+	}
+
+	p := generator.Palette{
+		Base:    s.Background,
+		Surface: s.Colours[black],
+		Text:    s.Foreground,
+		Muted:   s.Colours[brightBlack],
+		Accent:  s.Colours[blue],
+		Warning: s.Colours[yellow],
+	}
+	if p.Surface == p.Base {
+		p.Surface = ""
+	}
+
+	return p
+}
+
+// Spec returns a generator spec with the default layout and the scheme's
+// palette.
+func (s *Scheme) Spec() *generator.Spec {
+	spec := generator.NewSpec()
+	spec.Name = s.Name
+	spec.Description = "Generated from the " + s.displayName() + " colour scheme."
+	spec.Palette = s.Palette()
+
+	return spec
+}
+
+// Generate writes the .tmuxtheme text matching the scheme.
+func (s *Scheme) Generate(w io.Writer) error {
+	return generator.Generate(w, s.Spec())
+}
+
+// Theme returns the executed theme matching the scheme.
+func (s *Scheme) Theme() (*theme.Theme, error) {
+	return generator.Theme(s.Spec())
+}
+
+func (s *Scheme) displayName() string {
+	if s.Name == "" {
+		return "imported"
+	}
+
+	return s.Name
+}
+
+// validate checks the scheme defines at least its foreground and background.
+func (s *Scheme) validate() error {
+	if s.Background == "" {
+		return &MissingColourError{Colour: "background"}
+	}
+	if s.Foreground == "" {
+		return &MissingColourError{Colour: "foreground"}
+	}
+
+	return nil
+}
+
+// normalizeColour converts the hex colour notations used by terminal
+// configs, "#rrggbb", "0xrrggbb", "rrggbb" and "#rgb", to "#rrggbb".
+func normalizeColour(value string) (string, error) {
+	v := strings.ToLower(strings.Trim(strings.TrimSpace(value), `"'`))
+	if v == "" {
+		return "", nil
+	}
+
+	hex := strings.TrimPrefix(strings.TrimPrefix(v, "#"), "0x")
+	if len(hex) == 3 {
+		hex = string([]byte{
+			hex[0], hex[0], hex[1], hex[1], hex[2], hex[2],
+		})
+	}
+
+	c, err := theme.ParseColour("#" + hex)
+	if err != nil || c.Type != theme.RGBColour {
+		return "", &theme.InvalidColourError{Value: value}
+	}
+
+	return c.String(), nil
+}
@@ -0,0 +1,86 @@
+package scheme
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/generator"
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemePalette(t *testing.T) {
+	s := &Scheme{
+		Foreground: "#c5c8c6",
+		Background: "#1d1f21",
+		Colours:    tomorrowNight,
+	}
+
+	assert.Equal(t, generator.Palette{
+		Base:    "#1d1f21",
+		Text:    "#c5c8c6",
+		Muted:   "#666666",
+		Accent:  "#81a2be",
+		Warning: "#f0c674",
+	}, s.Palette())
+
+	s.Colours[0] = "#282a2e"
+	assert.Equal(t, "#282a2e", s.Palette().Surface)
+}
+
+func TestSchemeGenerate(t *testing.T) {
+	s, err := Load("testdata/kitty.conf")
+	require.NoError(t, err)
+	s.Name = "Tomorrow Night"
+
+	var b strings.Builder
+	err = s.Generate(&b)
+	require.NoError(t, err)
+
+	themetest.AssertGolden(t, "testdata/tomorrow-night.tmuxtheme", b.String())
+}
+
+func TestSchemeTheme(t *testing.T) {
+	s, err := Load("testdata/tomorrow-night.toml")
+	require.NoError(t, err)
+
+	th, err := s.Theme()
+	require.NoError(t, err)
+
+	assert.Equal(
+		t, "bg=#1d1f21,fg=#c5c8c6", th.GlobalSessionOptions["status-style"],
+	)
+	assert.Equal(
+		t, "bg=#81a2be,fg=#1d1f21",
+		th.GlobalWindowOptions["window-status-current-style"],
+	)
+}
+
+func TestNormalizeColour(t *testing.T) {
+	var tests = []struct {
+		value  string
+		colour string
+		err    string
+	}{
+		{"", "", ""},
+		{"#1D1F21", "#1d1f21", ""},
+		{"0x1d1f21", "#1d1f21", ""},
+		{"1d1f21", "#1d1f21", ""},
+		{"'#1d1f21'", "#1d1f21", ""},
+		{"#fff", "#ffffff", ""},
+		{"red", "", "Invalid colour: red"},
+		{"#12345", "", "Invalid colour: #12345"},
+	}
+
+	for _, tt := range tests {
+		colour, err := normalizeColour(tt.value)
+
+		assert.Equal(t, tt.colour, colour, tt.value)
+		if tt.err == "" {
+			assert.NoError(t, err, tt.value)
+		} else {
+			assert.EqualError(t, err, tt.err, tt.value)
+		}
+	}
+}
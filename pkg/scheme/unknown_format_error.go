@@ -0,0 +1,11 @@
+package scheme
+
+import "fmt"
+
+type UnknownFormatError struct {
+	Filename string
+}
+
+func (s *UnknownFormatError) Error() string {
+	return fmt.Sprintf("Unknown colour scheme format: %s", s.Filename)
+}
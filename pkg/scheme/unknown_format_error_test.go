@@ -0,0 +1,17 @@
+package scheme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnknownFormatErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &UnknownFormatError{})
+}
+
+func TestUnknownFormatError(t *testing.T) {
+	err := &UnknownFormatError{Filename: "colours.ini"}
+
+	assert.Equal(t, "Unknown colour scheme format: colours.ini", err.Error())
+}
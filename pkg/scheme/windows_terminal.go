@@ -0,0 +1,63 @@
+package scheme
+
+import (
+	"encoding/json"
+)
+
+type windowsTerminalScheme struct {
+	Name         string `json:"name"`
+	Foreground   string `json:"foreground"`
+	Background   string `json:"background"`
+	Black        string `json:"black"`
+	Red          string `json:"red"`
+	Green        string `json:"green"`
+	Yellow       string `json:"yellow"`
+	Blue         string `json:"blue"`
+	Purple       string `json:"purple"`
+	Cyan         string `json:"cyan"`
+	White        string `json:"white"`
+	BrightBlack  string `json:"brightBlack"`
+	BrightRed    string `json:"brightRed"`
+	BrightGreen  string `json:"brightGreen"`
+	BrightYellow string `json:"brightYellow"`
+	BrightBlue   string `json:"brightBlue"`
+	BrightPurple string `json:"brightPurple"`
+	BrightCyan   string `json:"brightCyan"`
+	BrightWhite  string `json:"brightWhite"`
+}
+
+// parseWindowsTerminal reads a Windows Terminal colour scheme object, or a
+// settings.json file in which case its first scheme is used.
+func parseWindowsTerminal(b []byte) (*Scheme, error) {
+	settings := struct {
+		Schemes []*windowsTerminalScheme `json:"schemes"`
+	}{}
+	err := json.Unmarshal(b, &settings)
+	if err != nil {
+		return nil, err
+	}
+
+	wt := &windowsTerminalScheme{}
+	if len(settings.Schemes) > 0 {
+		wt = settings.Schemes[0]
+	} else {
+		err = json.Unmarshal(b, wt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s, err := newScheme([]string{
+		wt.Foreground, wt.Background,
+		wt.Black, wt.Red, wt.Green, wt.Yellow,
+		wt.Blue, wt.Purple, wt.Cyan, wt.White,
+		wt.BrightBlack, wt.BrightRed, wt.BrightGreen, wt.BrightYellow,
+		wt.BrightBlue, wt.BrightPurple, wt.BrightCyan, wt.BrightWhite,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.Name = wt.Name
+
+	return s, nil
+}
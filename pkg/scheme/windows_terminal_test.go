@@ -0,0 +1,31 @@
+package scheme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWindowsTerminalScheme(t *testing.T) {
+	s, err := parseWindowsTerminal([]byte(`{
+		"name": "Campbell",
+		"foreground": "#CCCCCC",
+		"background": "#0C0C0C",
+		"blue": "#0037DA",
+		"brightBlack": "#767676"
+	}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Campbell", s.Name)
+	assert.Equal(t, "#cccccc", s.Foreground)
+	assert.Equal(t, "#0c0c0c", s.Background)
+	assert.Equal(t, "#0037da", s.Colours[4])
+	assert.Equal(t, "#767676", s.Colours[8])
+}
+
+func TestParseWindowsTerminalInvalid(t *testing.T) {
+	_, err := parseWindowsTerminal([]byte(`{"schemes": `))
+
+	assert.Error(t, err)
+}
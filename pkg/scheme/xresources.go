@@ -0,0 +1,57 @@
+package scheme
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+// parseXresources reads the foreground, background and colorN resources of
+// an Xresources file, for any application class such as "*.color0" or
+// "URxvt*color0". Simple #define macros are substituted in values.
+func parseXresources(b []byte) (*Scheme, error) {
+	values := make([]string, 18)
+	defines := map[string]string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "!") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "#define") {
+			fields := strings.Fields(line)
+			if len(fields) >= 3 {
+				defines[fields[1]] = fields[2]
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		name := parts[0]
+		if i := strings.LastIndexAny(name, ".*"); i >= 0 {
+			name = name[i+1:]
+		}
+		value := strings.TrimSpace(parts[1])
+		if v, ok := defines[value]; ok {
+			value = v
+		}
+
+		if i := colourSlot(strings.TrimSpace(name)); i >= 0 {
+			values[i] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return newScheme(values)
+}
@@ -0,0 +1,26 @@
+package scheme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseXresources(t *testing.T) {
+	s, err := parseXresources([]byte(`
+! comment
+#define bg #000000
+#ifdef COLOR
+URxvt.background: bg
+XTerm*foreground:   #ffffff
+*color4 : #0000ff
+URxvt.font: xft:Monospace
+#endif
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "#000000", s.Background)
+	assert.Equal(t, "#ffffff", s.Foreground)
+	assert.Equal(t, "#0000ff", s.Colours[4])
+}
@@ -0,0 +1,134 @@
+package theme
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type ColourType int
+
+const (
+	NoColour ColourType = iota
+	DefaultColour
+	TerminalColour
+	ANSIColour
+	PaletteColour
+	RGBColour
+)
+
+var colourNames = []string{
+	"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white",
+}
+
+var ansiColourRGB = [16][3]uint8{
+	{0x00, 0x00, 0x00}, {0xcd, 0x00, 0x00}, {0x00, 0xcd, 0x00},
+	{0xcd, 0xcd, 0x00}, {0x00, 0x00, 0xee}, {0xcd, 0x00, 0xcd},
+	{0x00, 0xcd, 0xcd}, {0xe5, 0xe5, 0xe5}, {0x7f, 0x7f, 0x7f},
+	{0xff, 0x00, 0x00}, {0x00, 0xff, 0x00}, {0xff, 0xff, 0x00},
+	{0x5c, 0x5c, 0xff}, {0xff, 0x00, 0xff}, {0x00, 0xff, 0xff},
+	{0xff, 0xff, 0xff},
+}
+
+var colourCubeLevels = [6]uint8{0x00, 0x5f, 0x87, 0xaf, 0xd7, 0xff}
+
+type Colour struct {
+	Type  ColourType
+	Index int
+	R     uint8
+	G     uint8
+	B     uint8
+}
+
+func ParseColour(value string) (Colour, error) {
+	name := strings.ToLower(strings.TrimSpace(value))
+
+	switch name {
+	case "default":
+		return Colour{Type: DefaultColour}, nil
+	case "terminal":
+		return Colour{Type: TerminalColour}, nil
+	}
+
+	if strings.HasPrefix(name, "#") && len(name) == 7 {
+		rgb, err := strconv.ParseUint(name[1:], 16, 32)
+		if err == nil {
+			return Colour{
+				Type: RGBColour,
+				R:    uint8(rgb >> 16),
+				G:    uint8(rgb >> 8),
+				B:    uint8(rgb),
+			}, nil
+		}
+	}
+
+	for _, prefix := range []string{"colour", "color"} {
+		if strings.HasPrefix(name, prefix) {
+			n, err := strconv.Atoi(name[len(prefix):])
+			if err == nil && n >= 0 && n <= 255 {
+				return Colour{Type: PaletteColour, Index: n}, nil
+			}
+		}
+	}
+
+	for i, c := range colourNames {
+		if name == c || name == strconv.Itoa(i) {
+			return Colour{Type: ANSIColour, Index: i}, nil
+		}
+		if name == "bright"+c || name == strconv.Itoa(90+i) {
+			return Colour{Type: ANSIColour, Index: i + 8}, nil
+		}
+	}
+
+	return Colour{}, &InvalidColourError{Value: value}
+}
+
+func (s Colour) IsSet() bool {
+	return s.Type != NoColour
+}
+
+func (s Colour) String() string {
+	switch s.Type {
+	case DefaultColour:
+		return "default"
+	case TerminalColour:
+		return "terminal"
+	case ANSIColour:
+		if s.Index >= 8 {
+			return "bright" + colourNames[s.Index-8]
+		}
+		return colourNames[s.Index]
+	case PaletteColour:
+		return fmt.Sprintf("colour%d", s.Index)
+	case RGBColour:
+		return fmt.Sprintf("#%02x%02x%02x", s.R, s.G, s.B)
+	}
+
+	return ""
+}
+
+// RGB returns the colour's red, green and blue components using the xterm
+// palette for ANSI and 256 colours. Default and terminal colours depend on
+// the terminal, so false is returned for those.
+func (s Colour) RGB() (uint8, uint8, uint8, bool) {
+	switch s.Type {
+	case ANSIColour:
+		c := ansiColourRGB[s.Index]
+		return c[0], c[1], c[2], true
+	case PaletteColour:
+		if s.Index < 16 {
+			c := ansiColourRGB[s.Index]
+			return c[0], c[1], c[2], true
+		} else if s.Index < 232 {
+			i := s.Index - 16
+			return colourCubeLevels[i/36], colourCubeLevels[(i/6)%6],
+				colourCubeLevels[i%6], true
+		}
+		grey := uint8(8 + 10*(s.Index-232))
+		return grey, grey, grey, true
+	case RGBColour:
+		return s.R, s.G, s.B, true
+	}
+
+	return 0, 0, 0, false
+}
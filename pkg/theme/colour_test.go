@@ -0,0 +1,108 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseColour(t *testing.T) {
+	var tests = []struct {
+		value  string
+		colour Colour
+		name   string
+		error  error
+	}{
+		{
+			value:  "default",
+			colour: Colour{Type: DefaultColour},
+			name:   "default",
+		},
+		{
+			value:  "terminal",
+			colour: Colour{Type: TerminalColour},
+			name:   "terminal",
+		},
+		{
+			value:  "red",
+			colour: Colour{Type: ANSIColour, Index: 1},
+			name:   "red",
+		},
+		{
+			value:  "White",
+			colour: Colour{Type: ANSIColour, Index: 7},
+			name:   "white",
+		},
+		{
+			value:  "brightcyan",
+			colour: Colour{Type: ANSIColour, Index: 14},
+			name:   "brightcyan",
+		},
+		{
+			value:  "92",
+			colour: Colour{Type: ANSIColour, Index: 10},
+			name:   "brightgreen",
+		},
+		{
+			value:  "colour160",
+			colour: Colour{Type: PaletteColour, Index: 160},
+			name:   "colour160",
+		},
+		{
+			value:  "color0",
+			colour: Colour{Type: PaletteColour, Index: 0},
+			name:   "colour0",
+		},
+		{
+			value:  "#FF8000",
+			colour: Colour{Type: RGBColour, R: 255, G: 128},
+			name:   "#ff8000",
+		},
+		{value: "colour256", error: &InvalidColourError{"colour256"}},
+		{value: "#ff80", error: &InvalidColourError{"#ff80"}},
+		{value: "purplish", error: &InvalidColourError{"purplish"}},
+		{value: "", error: &InvalidColourError{""}},
+	}
+
+	for _, tt := range tests {
+		colour, err := ParseColour(tt.value)
+
+		if tt.error != nil {
+			assert.Error(t, err)
+			assert.Equal(t, tt.error, err)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, tt.colour, colour)
+			assert.Equal(t, tt.name, colour.String())
+		}
+	}
+}
+
+func TestColourRGB(t *testing.T) {
+	var tests = []struct {
+		value   string
+		r, g, b uint8
+		ok      bool
+	}{
+		{value: "default", ok: false},
+		{value: "terminal", ok: false},
+		{value: "red", r: 0xcd, ok: true},
+		{value: "brightblue", r: 0x5c, g: 0x5c, b: 0xff, ok: true},
+		{value: "colour9", r: 0xff, ok: true},
+		{value: "colour16", ok: true},
+		{value: "colour160", r: 0xd7, ok: true},
+		{value: "colour39", g: 0xaf, b: 0xff, ok: true},
+		{value: "colour232", r: 8, g: 8, b: 8, ok: true},
+		{value: "colour255", r: 238, g: 238, b: 238, ok: true},
+		{value: "#123456", r: 0x12, g: 0x34, b: 0x56, ok: true},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseColour(tt.value)
+		assert.NoError(t, err)
+
+		r, g, b, ok := c.RGB()
+		assert.Equal(t, tt.ok, ok, tt.value)
+		assert.Equal(t, []uint8{tt.r, tt.g, tt.b}, []uint8{r, g, b}, tt.value)
+	}
+}
@@ -28,3 +28,17 @@ func (s *CommentStatement) Parse(body string) error {
 func (s *CommentStatement) Execute(theme *Theme) error {
 	return nil
 }
+
+func (s *CommentStatement) String() string {
+	if s.Msg == "" {
+		return "#"
+	}
+
+	// A trailing space keeps a backslash at the end of the message from
+	// continuing the comment onto the next line.
+	if strings.HasSuffix(s.Msg, `\`) {
+		return "# " + s.Msg + " "
+	}
+
+	return "# " + s.Msg
+}
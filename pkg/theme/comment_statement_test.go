@@ -63,3 +63,24 @@ func TestCommentStatementParse(t *testing.T) {
 		}
 	}
 }
+
+func TestCommentStatementString(t *testing.T) {
+	var tests = []struct {
+		msg  string
+		body string
+	}{
+		{"", "#"},
+		{"This is a comment", "# This is a comment"},
+		{`ends with \`, `# ends with \ `},
+	}
+
+	for _, tt := range tests {
+		s := &CommentStatement{Msg: tt.msg}
+
+		assert.Equal(t, tt.body, s.String())
+
+		parsed := &CommentStatement{}
+		assert.NoError(t, parsed.Parse(s.String()))
+		assert.Equal(t, s, parsed)
+	}
+}
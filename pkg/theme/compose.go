@@ -0,0 +1,118 @@
+package theme
+
+import "fmt"
+
+// optionKey identifies an option set in a specific scope and target.
+type optionKey struct {
+	scope  Scope
+	target string
+	name   string
+}
+
+// Compose returns a new theme executing the statements of base followed by
+// each overlay in order, as if the theme files were sourced one after
+// another. See Overlay for how layers interact.
+//
+// The composed theme is executed with the Deferred setting of base. Every
+// overlay must have the same setting, otherwise a DeferredMismatchError is
+// returned.
+func Compose(base *Theme, overlays ...*Theme) (*Theme, error) {
+	for i, overlay := range overlays {
+		if overlay.Deferred != base.Deferred {
+			return nil, &DeferredMismatchError{
+				Layer:    layerName(overlay, i+1),
+				Deferred: overlay.Deferred,
+			}
+		}
+	}
+
+	t := New()
+	t.Context = base.Context
+	t.Tracer = base.Tracer
+	t.Deferred = base.Deferred
+
+	for _, layer := range append([]*Theme{base}, overlays...) {
+		if err := t.Overlay(layer); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+// Overlay executes the statements of other on top of the theme, appending
+// them to Statements and recording other as a new layer. The theme must
+// already be executed, and its Deferred setting applies to the statements
+// of other.
+//
+// Layers share one set of option maps, so a later layer replaces values set
+// by earlier ones, -a appends to them, and -u removes them. A statement
+// with -o is skipped if any earlier layer set the option, which lets an
+// overlay provide defaults without overriding the base theme.
+func (s *Theme) Overlay(other *Theme) error {
+	if s.layerOf == nil {
+		s.layerOf = map[optionKey]int{}
+	}
+	if len(s.Layers) == 0 && len(s.Statements) > 0 {
+		s.Layers = append(s.Layers, layerName(s, 0))
+		s.layerStarts = append(s.layerStarts, 0)
+	}
+
+	s.Layers = append(s.Layers, layerName(other, len(s.Layers)))
+	s.layerStarts = append(s.layerStarts, len(s.Statements))
+
+	for i, st := range other.Statements {
+		s.Statements = append(s.Statements, st)
+		s.Positions = append(s.Positions, other.Position(i))
+
+		err := s.executeStatement(len(s.Statements) - 1)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LayerOf returns the index in Layers of the layer which last set the
+// option. False is returned when the option is not set.
+func (s *Theme) LayerOf(scope Scope, target, name string) (int, bool) {
+	if _, ok := s.Options(scope, target)[name]; !ok {
+		return 0, false
+	}
+
+	return s.layerOf[optionKey{scope, target, name}], true
+}
+
+// layerAt returns the layer the statement at index i belongs to.
+func (s *Theme) layerAt(i int) int {
+	layer := 0
+	for l, start := range s.layerStarts {
+		if start <= i {
+			layer = l
+		}
+	}
+
+	return layer
+}
+
+func (s *Theme) setLayer(scope Scope, target, name string, set bool) {
+	if s.layerOf == nil {
+		return
+	}
+
+	key := optionKey{scope, target, name}
+	if set {
+		s.layerOf[key] = s.layer
+	} else {
+		delete(s.layerOf, key)
+	}
+}
+
+func layerName(t *Theme, index int) string {
+	if t.Filename != "" {
+		return t.Filename
+	}
+
+	return fmt.Sprintf("layer %d", index)
+}
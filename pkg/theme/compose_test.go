@@ -0,0 +1,172 @@
+package theme
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompose(t *testing.T) {
+	base := New()
+	base.Filename = "base.tmuxtheme"
+	require.NoError(t, base.Parse(strings.NewReader(`set -g @accent blue
+set -g status-left "#S"
+set -g status-right "%H:%M"
+set -gF status-style "bg=#{@accent}"
+set -g @base-only yes
+`)))
+
+	accent := New()
+	accent.Filename = "accent.tmuxtheme"
+	require.NoError(t, accent.Parse(strings.NewReader(`set -g @accent red
+set -gF status-style "bg=#{@accent}"
+set -ga status-left " "
+set -go status-right "ignored"
+set -go @accent-only yes
+set -gu @base-only
+`)))
+
+	th, err := Compose(base, accent)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"base.tmuxtheme", "accent.tmuxtheme"}, th.Layers)
+	assert.Len(t, th.Statements, 11)
+	assert.Equal(t, Position{"accent.tmuxtheme", 1, 1}, th.Position(5))
+	assert.Equal(t, map[string]string{
+		"@accent":      "red",
+		"status-left":  "#S ",
+		"status-right": "%H:%M",
+		"status-style": "bg=red",
+		"@accent-only": "yes",
+	}, th.GlobalSessionOptions)
+
+	var tests = []struct {
+		name  string
+		layer int
+		ok    bool
+	}{
+		{"@accent", 1, true},
+		{"status-style", 1, true},
+		{"status-left", 1, true},
+		{"status-right", 0, true},
+		{"@accent-only", 1, true},
+		{"@base-only", 0, false},
+	}
+
+	for _, tt := range tests {
+		layer, ok := th.LayerOf(GlobalSessionScope, "", tt.name)
+
+		assert.Equal(t, tt.ok, ok, tt.name)
+		assert.Equal(t, tt.layer, layer, tt.name)
+	}
+
+	assert.Empty(t, base.GlobalSessionOptions, "layers are not executed")
+}
+
+func TestComposeDeferred(t *testing.T) {
+	base := New()
+	base.Filename = "base.tmuxtheme"
+	require.NoError(t, base.Parse(strings.NewReader(`set -g @accent blue
+set -gF status-style "bg=#{@accent}"
+`)))
+	base.Deferred = true
+	accent := New()
+	accent.Filename = "accent.tmuxtheme"
+	require.NoError(t, accent.Parse(strings.NewReader(`set -gF status-left "#{session_name} "
+set -g @accent red
+`)))
+	accent.Deferred = true
+
+	th, err := Compose(base, accent)
+	require.NoError(t, err)
+
+	assert.True(t, th.Deferred)
+	assert.Equal(t, "bg=#{@accent}", th.GlobalSessionOptions["status-style"])
+	assert.Equal(t, "#{session_name} ", th.GlobalSessionOptions["status-left"])
+
+	evaluated, err := th.Evaluate(&FormatContext{
+		Session: &FormatSession{Name: "work"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "bg=blue", evaluated.GlobalSessionOptions["status-style"])
+	assert.Equal(t, "work ", evaluated.GlobalSessionOptions["status-left"])
+
+	accent.Deferred = false
+	_, err = Compose(base, accent)
+	assert.Equal(t, &DeferredMismatchError{Layer: "accent.tmuxtheme"}, err)
+
+	base.Deferred = false
+	accent.Deferred = true
+	_, err = Compose(base, accent)
+	assert.Equal(t,
+		&DeferredMismatchError{Layer: "accent.tmuxtheme", Deferred: true}, err)
+}
+
+type failingStatement struct{}
+
+func (s failingStatement) Parse(string) error { return nil }
+
+func (s failingStatement) Execute(*Theme) error {
+	return &OptionNotFoundError{Name: "@nope"}
+}
+
+func TestThemeOverlayText(t *testing.T) {
+	th := New()
+	require.NoError(t, th.Parse(strings.NewReader(`set -g @accent blue
+set -g @fg white
+`)))
+	require.NoError(t, th.Execute())
+
+	overlay := New()
+	require.NoError(t, overlay.Parse(strings.NewReader(`set -g @accent red
+`)))
+
+	require.NoError(t, th.Overlay(overlay))
+
+	assert.Equal(t, []string{"set -g @accent blue"}, th.Text(0))
+	assert.Equal(t, []string{"set -g @fg white"}, th.Text(1))
+	assert.Nil(t, th.Text(2))
+	assert.Equal(t, []string{"set -g @accent red"}, overlay.Text(0))
+}
+
+func TestComposeError(t *testing.T) {
+	base := New()
+	base.Filename = "base.tmuxtheme"
+	require.NoError(t, base.Parse(strings.NewReader(`set -g @accent blue`)))
+
+	bad := New()
+	bad.Statements = append(bad.Statements, failingStatement{})
+
+	_, err := Compose(base, bad)
+
+	assert.Error(t, err)
+}
+
+func TestThemeOverlay(t *testing.T) {
+	th := New()
+	require.NoError(t, th.Parse(strings.NewReader(`set -g @accent blue`)))
+	require.NoError(t, th.Execute())
+
+	overlay := New()
+	require.NoError(t, overlay.Parse(strings.NewReader(`set -w -t main:1 @accent red
+set -g @accent green
+`)))
+
+	err := th.Overlay(overlay)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"layer 0", "layer 1"}, th.Layers)
+
+	layer, ok := th.LayerOf(GlobalSessionScope, "", "@accent")
+	assert.True(t, ok)
+	assert.Equal(t, 1, layer)
+
+	layer, ok = th.LayerOf(WindowScope, "main:1", "@accent")
+	assert.True(t, ok)
+	assert.Equal(t, 1, layer)
+
+	_, ok = th.LayerOf(WindowScope, "main:2", "@accent")
+	assert.False(t, ok)
+}
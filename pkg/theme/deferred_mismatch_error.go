@@ -0,0 +1,24 @@
+package theme
+
+import "fmt"
+
+// DeferredMismatchError is returned by Compose when a layer's Deferred
+// setting differs from the base theme's, as its -F values would otherwise
+// be expanded at a different time than it asked for.
+type DeferredMismatchError struct {
+	Layer    string
+	Deferred bool
+}
+
+func (s *DeferredMismatchError) Error() string {
+	if s.Deferred {
+		return fmt.Sprintf(
+			"Cannot compose deferred layer %s onto a base theme "+
+				"which is not deferred", s.Layer,
+		)
+	}
+
+	return fmt.Sprintf(
+		"Cannot compose layer %s onto a deferred base theme", s.Layer,
+	)
+}
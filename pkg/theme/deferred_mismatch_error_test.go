@@ -0,0 +1,35 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeferredMismatchErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &DeferredMismatchError{})
+}
+
+var deferredMismatchErrorTests = []struct {
+	layer    string
+	deferred bool
+	err      string
+}{
+	{
+		"accent.tmuxtheme", true,
+		"Cannot compose deferred layer accent.tmuxtheme onto a base theme " +
+			"which is not deferred",
+	},
+	{
+		"layer 1", false,
+		"Cannot compose layer layer 1 onto a deferred base theme",
+	},
+}
+
+func TestDeferredMismatchError(t *testing.T) {
+	for _, tt := range deferredMismatchErrorTests {
+		err := DeferredMismatchError{Layer: tt.layer, Deferred: tt.deferred}
+
+		assert.Equal(t, tt.err, err.Error())
+	}
+}
@@ -21,3 +21,7 @@ func (s *EmptyStatement) Parse(body string) error {
 func (s *EmptyStatement) Execute(theme *Theme) error {
 	return nil
 }
+
+func (s *EmptyStatement) String() string {
+	return ""
+}
@@ -47,3 +47,7 @@ func TestEmptyStatementParse(t *testing.T) {
 		}
 	}
 }
+
+func TestEmptyStatementString(t *testing.T) {
+	assert.Equal(t, "", (&EmptyStatement{}).String())
+}
@@ -0,0 +1,42 @@
+package theme
+
+import "strings"
+
+// Explanation describes how an option got its value: the lookup through the
+// scopes tmux checks, and the provenance of the option in each of them.
+type Explanation struct {
+	Trace      *LookupTrace
+	Provenance []*Provenance
+}
+
+// Explain resolves name for the given session and window targets like
+// TraceLookup, collecting the provenance of every scope checked.
+func (s *Theme) Explain(name, session, window string) *Explanation {
+	e := &Explanation{
+		Trace:      s.TraceLookup(name, session, window),
+		Provenance: []*Provenance{},
+	}
+
+	for _, step := range e.Trace.Steps {
+		if step.Default {
+			continue
+		}
+		if p := s.Provenance(step.Scope, step.Target, name); p != nil {
+			e.Provenance = append(e.Provenance, p)
+		}
+	}
+
+	return e
+}
+
+func (s *Explanation) String() string {
+	var b strings.Builder
+
+	b.WriteString(s.Trace.String())
+	for _, p := range s.Provenance {
+		b.WriteString("\n")
+		b.WriteString(p.String())
+	}
+
+	return b.String()
+}
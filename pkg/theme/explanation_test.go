@@ -0,0 +1,32 @@
+package theme
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThemeExplain(t *testing.T) {
+	th := New()
+	th.Filename = "test.tmuxtheme"
+	require.NoError(t, th.Parse(strings.NewReader(`set -g @fg white
+set -w -t main:1 @fg red
+set -gF @style "fg=#{@fg}"
+`)))
+	require.NoError(t, th.Execute())
+
+	e := th.Explain("@fg", "main", "main:1")
+
+	assert.Equal(t, "red", e.Trace.Value())
+	require.Len(t, e.Provenance, 1)
+	assert.Equal(t, `#{@fg} (user option)
+  server: not set
+  window main:1: found "red"
+  => "red"
+
+@fg (window main:1)
+  test.tmuxtheme:2: set "red"
+`, e.String())
+}
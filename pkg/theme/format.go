@@ -0,0 +1,437 @@
+package theme
+
+import (
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const formatLoopLimit = 50
+
+// formatWidthLimit caps the width of padding, matching the largest window
+// tmux allows, so a huge p modifier can't exhaust memory.
+const formatWidthLimit = 10000
+
+type formatModifier struct {
+	name string
+	args []string
+}
+
+type formatExpander struct {
+	theme   *Theme
+	context *FormatContext
+	session string
+	window  string
+	depth   int
+	time    bool
+	now     time.Time
+
+	// onLookup, when set, is called with the trace of every #{name}
+	// reference expanded.
+	onLookup func(*LookupTrace)
+}
+
+// Expand expands the tmux format template against the theme's options and
+// Context, the same way tmux expands formats for the current session.
+func (s *Theme) Expand(template string) string {
+	return s.newFormatExpander("", "").expand(template)
+}
+
+// WithContext returns a shallow copy of the theme, sharing its options, which
+// expands formats against ctx.
+func (s *Theme) WithContext(ctx *FormatContext) *Theme {
+	t := *s
+	t.Context = ctx
+
+	return &t
+}
+
+// ExpandTime expands the template like Expand, but first passes it through
+// strftime using the Context's clock, the same way tmux expands status-left,
+// status-right and the window list formats.
+//
+// As in tmux, strftime is applied to the template at every level of
+// expansion, including the branches of conditionals, but never to values
+// substituted from options or format variables. A literal % within a nested
+// format therefore needs escaping for each level it passes through.
+func (s *Theme) ExpandTime(template string) string {
+	e := s.newFormatExpander("", "")
+	e.time = true
+	e.now = s.Context.Now()
+
+	return e.expand(template)
+}
+
+func (s *Theme) newFormatExpander(session, window string) *formatExpander {
+	return &formatExpander{
+		theme:   s,
+		context: s.Context,
+		session: session,
+		window:  window,
+	}
+}
+
+func (s *formatExpander) expand(template string) string {
+	if s.depth >= formatLoopLimit {
+		return ""
+	}
+	s.depth++
+	defer func() { s.depth-- }()
+
+	if s.time && strings.Contains(template, "%") {
+		template = Strftime(template, s.now)
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(template); i++ {
+		ch := template[i]
+		if ch != '#' || i+1 >= len(template) {
+			b.WriteByte(ch)
+			continue
+		}
+
+		next := template[i+1]
+		switch next {
+		case '{':
+			end := formatSkip(template[i+2:], "}")
+			if end < 0 {
+				b.WriteString(template[i:])
+				return b.String()
+			}
+			b.WriteString(s.block(template[i+2 : i+2+end]))
+			i += 2 + end
+		case '#', ',', '}':
+			b.WriteByte(next)
+			i++
+		default:
+			if name, ok := formatAliases[next]; ok {
+				b.WriteString(s.lookup(name))
+				i++
+			} else {
+				b.WriteByte(ch)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func (s *formatExpander) block(inner string) string {
+	if strings.HasPrefix(inner, "?") {
+		return s.conditional(inner[1:])
+	}
+
+	modifiers, body := parseFormatModifiers(inner)
+	for _, m := range modifiers {
+		switch m.name {
+		case "l":
+			return body
+		case "==", "!=", "<", ">", "<=", ">=", "||", "&&":
+			return s.compare(m.name, body)
+		}
+	}
+
+	value := s.lookup(body)
+	for _, m := range modifiers {
+		switch m.name {
+		case "E":
+			value = s.expand(value)
+		case "T":
+			value = s.expandWithTime(value)
+		}
+	}
+
+	return applyFormatModifiers(modifiers, value)
+}
+
+func (s *formatExpander) expandWithTime(template string) string {
+	if s.time {
+		return s.expand(template)
+	}
+
+	s.time, s.now = true, s.context.Now()
+	defer func() { s.time = false }()
+
+	return s.expand(template)
+}
+
+func (s *formatExpander) conditional(body string) string {
+	args := formatSplit(body)
+
+	for i := 0; i+1 < len(args); i += 2 {
+		if formatTrue(s.condition(args[i])) {
+			return s.expand(args[i+1])
+		}
+	}
+
+	if len(args)%2 == 1 && len(args) > 1 {
+		return s.expand(args[len(args)-1])
+	}
+
+	return ""
+}
+
+func (s *formatExpander) condition(cond string) string {
+	if strings.Contains(cond, "#") {
+		return s.expand(cond)
+	}
+
+	return s.lookup(cond)
+}
+
+func (s *formatExpander) compare(op, body string) string {
+	args := formatSplit(body)
+	if len(args) != 2 {
+		return ""
+	}
+	left, right := s.expand(args[0]), s.expand(args[1])
+
+	var result bool
+	switch op {
+	case "==":
+		result = left == right
+	case "!=":
+		result = left != right
+	case "||":
+		result = formatTrue(left) || formatTrue(right)
+	case "&&":
+		result = formatTrue(left) && formatTrue(right)
+	default:
+		cmp := strings.Compare(left, right)
+		l, lerr := strconv.ParseFloat(left, 64)
+		r, rerr := strconv.ParseFloat(right, 64)
+		if lerr == nil && rerr == nil {
+			cmp = 0
+			if l < r {
+				cmp = -1
+			} else if l > r {
+				cmp = 1
+			}
+		}
+		switch op {
+		case "<":
+			result = cmp < 0
+		case ">":
+			result = cmp > 0
+		case "<=":
+			result = cmp <= 0
+		case ">=":
+			result = cmp >= 0
+		}
+	}
+
+	return formatBool(result)
+}
+
+func (s *formatExpander) lookup(name string) string {
+	trace := s.theme.TraceLookup(name, s.session, s.window)
+	if trace.Result == nil {
+		if value, ok := s.context.Lookup(name); ok {
+			trace.Result = &OptionValue{Name: name, Value: value}
+			trace.Variable = true
+		}
+	}
+
+	if s.onLookup != nil {
+		s.onLookup(trace)
+	}
+	if s.theme.Tracer != nil {
+		s.theme.Tracer(trace)
+	}
+
+	return trace.Value()
+}
+
+func formatTrue(value string) bool {
+	return value != "" && value != "0"
+}
+
+// formatSkip returns the index of the first character in s from chars which
+// is not escaped with # and not nested within a #{...} block, or -1.
+func formatSkip(s string, chars string) int {
+	depth := 0
+
+	for i := 0; i < len(s); i++ {
+		if s[i] == '#' && i+1 < len(s) {
+			if s[i+1] == '{' {
+				depth++
+			}
+			i++
+			continue
+		}
+		if s[i] == '}' && depth > 0 {
+			depth--
+			continue
+		}
+		if depth == 0 && strings.IndexByte(chars, s[i]) >= 0 {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func formatSplit(s string) []string {
+	args := []string{}
+
+	for {
+		i := formatSkip(s, ",")
+		if i < 0 {
+			return append(args, s)
+		}
+		args = append(args, s[:i])
+		s = s[i+1:]
+	}
+}
+
+var formatComparisons = []string{"==", "!=", "<=", ">=", "||", "&&", "<", ">"}
+
+func parseFormatModifiers(inner string) ([]*formatModifier, string) {
+	modifiers := []*formatModifier{}
+	s := inner
+
+	for _, op := range formatComparisons {
+		if strings.HasPrefix(s, op+":") {
+			return []*formatModifier{{name: op}}, s[len(op)+1:]
+		}
+	}
+
+	for len(s) > 0 {
+		if strings.IndexByte("lbdnqwETs=p", s[0]) < 0 {
+			return nil, inner
+		}
+		m := &formatModifier{name: s[:1]}
+		s = s[1:]
+
+		if len(s) > 0 && (s[0] == '-' || (s[0] >= '0' && s[0] <= '9')) {
+			end := 1
+			for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+				end++
+			}
+			m.args = append(m.args, s[:end])
+			s = s[end:]
+		} else if len(s) > 0 && strings.IndexByte("/|%", s[0]) >= 0 {
+			end := formatSkip(s, ":;")
+			if end < 0 {
+				return nil, inner
+			}
+			m.args = strings.Split(s[1:end], s[:1])
+			s = s[end:]
+		}
+
+		modifiers = append(modifiers, m)
+
+		if len(s) == 0 {
+			return nil, inner
+		}
+		switch s[0] {
+		case ':':
+			return modifiers, s[1:]
+		case ';':
+			s = s[1:]
+		default:
+			return nil, inner
+		}
+	}
+
+	return nil, inner
+}
+
+func applyFormatModifiers(modifiers []*formatModifier, value string) string {
+	for _, m := range modifiers {
+		switch m.name {
+		case "s":
+			if len(m.args) >= 2 {
+				if re, err := regexp.Compile(m.args[0]); err == nil {
+					value = re.ReplaceAllString(value, m.args[1])
+				}
+			}
+		case "b":
+			value = path.Base(value)
+		case "d":
+			value = path.Dir(value)
+		case "q":
+			value = formatQuote(value)
+		}
+	}
+
+	for _, m := range modifiers {
+		if len(m.args) == 0 {
+			continue
+		}
+		// Like tmux, widths outside the range of an int are ignored.
+		n64, err := strconv.ParseInt(m.args[0], 10, 32)
+		if err != nil {
+			continue
+		}
+		n := int(n64)
+
+		switch m.name {
+		case "=":
+			marker := ""
+			if len(m.args) > 1 {
+				marker = m.args[1]
+			}
+			value = formatTruncate(value, n, marker)
+		case "p":
+			value = formatPad(value, n)
+		}
+	}
+
+	for _, m := range modifiers {
+		if m.name == "n" || m.name == "w" {
+			value = strconv.Itoa(utf8.RuneCountInString(value))
+		}
+	}
+
+	return value
+}
+
+func formatTruncate(value string, n int, marker string) string {
+	runes := []rune(value)
+	if n < -len(runes) || n > len(runes) {
+		return value
+	}
+
+	if n >= 0 && len(runes) > n {
+		return string(runes[:n]) + marker
+	} else if n < 0 && len(runes) > -n {
+		return marker + string(runes[len(runes)+n:])
+	}
+
+	return value
+}
+
+func formatPad(value string, n int) string {
+	width := utf8.RuneCountInString(value)
+	if n > formatWidthLimit {
+		n = formatWidthLimit
+	} else if n < -formatWidthLimit {
+		n = -formatWidthLimit
+	}
+
+	if n > width {
+		return value + strings.Repeat(" ", n-width)
+	} else if -n > width {
+		return strings.Repeat(" ", -n-width) + value
+	}
+
+	return value
+}
+
+func formatQuote(value string) string {
+	var b strings.Builder
+
+	for _, r := range value {
+		if strings.ContainsRune("|&;<>()$`\\\"' *?[#~=%", r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,222 @@
+package theme
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+type FormatSession struct {
+	ID       int
+	Name     string
+	Path     string
+	Windows  int
+	Attached int
+	Grouped  bool
+	Alerts   string
+}
+
+type FormatWindow struct {
+	ID       int
+	Index    int
+	Name     string
+	Panes    int
+	Width    int
+	Height   int
+	Layout   string
+	Active   bool
+	Last     bool
+	Activity bool
+	Bell     bool
+	Silence  bool
+	Marked   bool
+	Zoomed   bool
+}
+
+type FormatPane struct {
+	ID             int
+	Index          int
+	Title          string
+	CurrentPath    string
+	CurrentCommand string
+	PID            int
+	TTY            string
+	Width          int
+	Height         int
+	Active         bool
+	Dead           bool
+	InMode         bool
+	Mode           string
+	Synchronized   bool
+}
+
+type FormatClient struct {
+	Name     string
+	TTY      string
+	Termname string
+	KeyTable string
+	Width    int
+	Height   int
+	Prefix   bool
+	Readonly bool
+}
+
+// FormatContext describes the simulated tmux state formats are expanded
+// against. Nil sections leave their format variables unset, and Vars can
+// be used to add or override any variable. Clock defaults to time.Now.
+type FormatContext struct {
+	Host    string
+	Session *FormatSession
+	Window  *FormatWindow
+	Pane    *FormatPane
+	Client  *FormatClient
+	Vars    map[string]string
+	Clock   func() time.Time
+}
+
+var formatAliases = map[byte]string{
+	'D': "pane_id",
+	'F': "window_flags",
+	'H': "host",
+	'I': "window_index",
+	'P': "pane_index",
+	'S': "session_name",
+	'T': "pane_title",
+	'W': "window_name",
+	'h': "host_short",
+}
+
+func (s *FormatContext) Lookup(name string) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+
+	if value, ok := s.Vars[name]; ok {
+		return value, true
+	}
+
+	value, ok := s.Variables()[name]
+	return value, ok
+}
+
+func (s *FormatContext) Now() time.Time {
+	if s == nil || s.Clock == nil {
+		return time.Now()
+	}
+
+	return s.Clock()
+}
+
+func (s *FormatContext) Variables() map[string]string {
+	vars := map[string]string{}
+
+	if s.Host != "" {
+		vars["host"] = s.Host
+		vars["host_short"] = strings.SplitN(s.Host, ".", 2)[0]
+	}
+
+	if ses := s.Session; ses != nil {
+		vars["session_id"] = "$" + strconv.Itoa(ses.ID)
+		vars["session_name"] = ses.Name
+		vars["session_path"] = ses.Path
+		vars["session_windows"] = strconv.Itoa(ses.Windows)
+		vars["session_attached"] = strconv.Itoa(ses.Attached)
+		vars["session_many_attached"] = formatBool(ses.Attached > 1)
+		vars["session_grouped"] = formatBool(ses.Grouped)
+		vars["session_alerts"] = ses.Alerts
+	}
+
+	if w := s.Window; w != nil {
+		vars["window_id"] = "@" + strconv.Itoa(w.ID)
+		vars["window_index"] = strconv.Itoa(w.Index)
+		vars["window_name"] = w.Name
+		vars["window_panes"] = strconv.Itoa(w.Panes)
+		vars["window_width"] = strconv.Itoa(w.Width)
+		vars["window_height"] = strconv.Itoa(w.Height)
+		vars["window_layout"] = w.Layout
+		vars["window_active"] = formatBool(w.Active)
+		vars["window_last_flag"] = formatBool(w.Last)
+		vars["window_activity_flag"] = formatBool(w.Activity)
+		vars["window_bell_flag"] = formatBool(w.Bell)
+		vars["window_silence_flag"] = formatBool(w.Silence)
+		vars["window_marked_flag"] = formatBool(w.Marked)
+		vars["window_zoomed_flag"] = formatBool(w.Zoomed)
+		vars["window_flags"] = w.Flags()
+		vars["window_raw_flags"] = w.Flags()
+	}
+
+	if p := s.Pane; p != nil {
+		vars["pane_id"] = "%" + strconv.Itoa(p.ID)
+		vars["pane_index"] = strconv.Itoa(p.Index)
+		vars["pane_title"] = p.Title
+		vars["pane_current_path"] = p.CurrentPath
+		vars["pane_current_command"] = p.CurrentCommand
+		vars["pane_pid"] = strconv.Itoa(p.PID)
+		vars["pane_tty"] = p.TTY
+		vars["pane_width"] = strconv.Itoa(p.Width)
+		vars["pane_height"] = strconv.Itoa(p.Height)
+		vars["pane_active"] = formatBool(p.Active)
+		vars["pane_dead"] = formatBool(p.Dead)
+		vars["pane_in_mode"] = formatBool(p.InMode)
+		vars["pane_mode"] = p.Mode
+		vars["pane_synchronized"] = formatBool(p.Synchronized)
+	}
+
+	if c := s.Client; c != nil {
+		vars["client_name"] = c.Name
+		vars["client_tty"] = c.TTY
+		vars["client_termname"] = c.Termname
+		vars["client_key_table"] = c.KeyTable
+		vars["client_width"] = strconv.Itoa(c.Width)
+		vars["client_height"] = strconv.Itoa(c.Height)
+		vars["client_prefix"] = formatBool(c.Prefix)
+		vars["client_readonly"] = formatBool(c.Readonly)
+		if s.Session != nil {
+			vars["client_session"] = s.Session.Name
+		}
+	}
+
+	for name, value := range s.Vars {
+		vars[name] = value
+	}
+
+	return vars
+}
+
+// Flags returns the window flags the same way tmux prints them in
+// #{window_flags}.
+func (s *FormatWindow) Flags() string {
+	flags := ""
+
+	if s.Activity {
+		flags += "#"
+	}
+	if s.Bell {
+		flags += "!"
+	}
+	if s.Silence {
+		flags += "~"
+	}
+	if s.Active {
+		flags += "*"
+	}
+	if s.Last {
+		flags += "-"
+	}
+	if s.Marked {
+		flags += "M"
+	}
+	if s.Zoomed {
+		flags += "Z"
+	}
+
+	return flags
+}
+
+func formatBool(b bool) string {
+	if b {
+		return "1"
+	}
+
+	return "0"
+}
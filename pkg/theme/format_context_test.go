@@ -0,0 +1,83 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatContextVariables(t *testing.T) {
+	ctx := &FormatContext{
+		Host: "dev.example.com",
+		Session: &FormatSession{
+			ID: 2, Name: "work", Windows: 3, Attached: 1,
+		},
+		Window: &FormatWindow{
+			ID: 5, Index: 1, Name: "vim", Panes: 2, Active: true, Zoomed: true,
+		},
+		Pane: &FormatPane{
+			ID: 7, Index: 0, Title: "nvim", Active: true,
+			CurrentPath: "/home/jim/src", CurrentCommand: "nvim",
+		},
+		Client: &FormatClient{Prefix: true, Width: 120, Height: 40},
+		Vars:   map[string]string{"pane_title": "overridden"},
+	}
+
+	vars := ctx.Variables()
+
+	assert.Equal(t, "dev.example.com", vars["host"])
+	assert.Equal(t, "dev", vars["host_short"])
+	assert.Equal(t, "$2", vars["session_id"])
+	assert.Equal(t, "work", vars["session_name"])
+	assert.Equal(t, "3", vars["session_windows"])
+	assert.Equal(t, "0", vars["session_many_attached"])
+	assert.Equal(t, "@5", vars["window_id"])
+	assert.Equal(t, "1", vars["window_index"])
+	assert.Equal(t, "vim", vars["window_name"])
+	assert.Equal(t, "*Z", vars["window_flags"])
+	assert.Equal(t, "1", vars["window_zoomed_flag"])
+	assert.Equal(t, "%7", vars["pane_id"])
+	assert.Equal(t, "/home/jim/src", vars["pane_current_path"])
+	assert.Equal(t, "nvim", vars["pane_current_command"])
+	assert.Equal(t, "overridden", vars["pane_title"])
+	assert.Equal(t, "1", vars["client_prefix"])
+	assert.Equal(t, "120", vars["client_width"])
+	assert.Equal(t, "work", vars["client_session"])
+}
+
+func TestFormatContextLookup(t *testing.T) {
+	var ctx *FormatContext
+	_, ok := ctx.Lookup("session_name")
+	assert.False(t, ok)
+
+	ctx = &FormatContext{Session: &FormatSession{Name: "work"}}
+	value, ok := ctx.Lookup("session_name")
+	assert.True(t, ok)
+	assert.Equal(t, "work", value)
+
+	_, ok = ctx.Lookup("window_name")
+	assert.False(t, ok)
+}
+
+func TestFormatWindowFlags(t *testing.T) {
+	var tests = []struct {
+		window *FormatWindow
+		flags  string
+	}{
+		{&FormatWindow{}, ""},
+		{&FormatWindow{Active: true}, "*"},
+		{&FormatWindow{Last: true}, "-"},
+		{&FormatWindow{Activity: true, Bell: true}, "#!"},
+		{
+			&FormatWindow{
+				Activity: true, Bell: true, Silence: true, Active: true,
+				Last: true, Marked: true, Zoomed: true,
+			},
+			"#!~*-MZ",
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.flags, tt.window.Flags())
+	}
+}
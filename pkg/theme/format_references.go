@@ -0,0 +1,52 @@
+package theme
+
+import (
+	"regexp"
+	"sort"
+)
+
+// formatReferenceMatcher matches the name looked up by a #{...} block, with
+// optional conditional and modifier prefixes like #{?name,...} and
+// #{=10:name}.
+var formatReferenceMatcher = regexp.MustCompile(
+	`^#\{\??(?:[^:{}#,]*:)?(@?[a-zA-Z][a-zA-Z0-9_-]*)[},]`,
+)
+
+// FormatReferences returns the options referenced within a format template,
+// sorted and without duplicates. Both branches of conditionals are included,
+// while format variables like #{session_name} are not.
+func FormatReferences(template string) []string {
+	seen := map[string]bool{}
+	names := []string{}
+
+	for i := 0; i+1 < len(template); i++ {
+		if template[i] != '#' {
+			continue
+		}
+		if template[i+1] == '#' {
+			i++
+			continue
+		}
+
+		match := formatReferenceMatcher.FindStringSubmatch(template[i:])
+		if match == nil || seen[match[1]] || !isOptionName(match[1]) {
+			continue
+		}
+		seen[match[1]] = true
+		names = append(names, match[1])
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func isOptionName(name string) bool {
+	if len(name) > 1 && name[0] == '@' {
+		return true
+	}
+
+	_, ok := LookupOptionDefinition(name)
+
+	return ok
+}
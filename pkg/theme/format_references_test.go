@@ -0,0 +1,30 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatReferences(t *testing.T) {
+	var tests = []struct {
+		template string
+		want     []string
+	}{
+		{"", []string{}},
+		{"plain #S text", []string{}},
+		{"#{@a}", []string{"@a"}},
+		{"#{@b} #{@a} #{@b}", []string{"@a", "@b"}},
+		{"#{session_name} #{status-left}", []string{"status-left"}},
+		{"#{?@flag,#{@on},#{@off}}", []string{"@flag", "@off", "@on"}},
+		{"#{?#{==:#{@a},x},yes,no}", []string{"@a"}},
+		{"#{=10:@name} #{T:status-right}", []string{"@name", "status-right"}},
+		{"#{s/x/y/:@sub}", []string{"@sub"}},
+		{"##{@escaped} ###{@a}", []string{"@a"}},
+		{"#{@unterminated", []string{}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, FormatReferences(tt.template), tt.template)
+	}
+}
@@ -0,0 +1,168 @@
+package theme
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThemeExpand(t *testing.T) {
+	theme := New()
+	theme.GlobalSessionOptions["@name"] = "John Smith"
+	theme.GlobalSessionOptions["@path"] = "/home/jim/src/project"
+	theme.GlobalSessionOptions["@fmt"] = "#{session_name}:#{window_index}"
+	theme.GlobalSessionOptions["@loop"] = "#{E:@loop}"
+	theme.GlobalSessionOptions["@zero"] = "0"
+	theme.Context = &FormatContext{
+		Host: "box.example.com",
+		Session: &FormatSession{
+			ID: 1, Name: "work", Windows: 2,
+		},
+		Window: &FormatWindow{
+			ID: 3, Index: 2, Name: "vim", Active: true,
+		},
+		Pane: &FormatPane{
+			ID: 4, Index: 1, Title: "editor", CurrentPath: "/tmp",
+		},
+		Client: &FormatClient{Prefix: true},
+	}
+
+	var tests = []struct {
+		template string
+		result   string
+	}{
+		{template: "plain text", result: "plain text"},
+		{template: "#S:#I:#W#F", result: "work:2:vim*"},
+		{template: "#H #h", result: "box.example.com box"},
+		{template: "#D #P #T", result: "%4 1 editor"},
+		{template: "#{session_name}", result: "work"},
+		{template: "#{pane_current_path}", result: "/tmp"},
+		{template: "Hi #{@name}", result: "Hi John Smith"},
+		{template: "#{@missing}", result: ""},
+		{template: "#{status-left-length}", result: "10"},
+		{template: "## #, #} #Q", result: "# , } #Q"},
+		{template: "#[fg=red]#S#[default]", result: "#[fg=red]work#[default]"},
+		{template: "#[fg=#{@zero}]", result: "#[fg=0]"},
+		{template: "trailing #", result: "trailing #"},
+		{template: "#{unterminated", result: "#{unterminated"},
+		{template: "#{?client_prefix,PREFIX,normal}", result: "PREFIX"},
+		{template: "#{?@zero,yes,no}", result: "no"},
+		{template: "#{?@missing,yes}", result: ""},
+		{template: "#{?window_active,#[bold]#W#,,#W}", result: "#[bold]vim,"},
+		{
+			template: "#{?#{==:#{window_index},2},two,other}",
+			result:   "two",
+		},
+		{template: "#{?@zero,a,@missing,b,c}", result: "c"},
+		{template: "#{?@zero,a,client_prefix,b,c}", result: "b"},
+		{template: "#{==:#S,work}", result: "1"},
+		{template: "#{!=:#S,work}", result: "0"},
+		{template: "#{<:#I,10}", result: "1"},
+		{template: "#{>=:#I,10}", result: "0"},
+		{template: "#{||:#{@zero},#{client_prefix}}", result: "1"},
+		{template: "#{&&:#{@zero},#{client_prefix}}", result: "0"},
+		{template: "#{l:#{session_name}}", result: "#{session_name}"},
+		{template: "#{=4:@name}", result: "John"},
+		{template: "#{=-5:@name}", result: "Smith"},
+		{template: "#{=/4/...:@name}", result: "John..."},
+		{template: "#{=20:@name}", result: "John Smith"},
+		{template: "[#{p8:session_name}]", result: "[work    ]"},
+		{template: "[#{p-8:session_name}]", result: "[    work]"},
+		{template: "#{=-9223372036854775808:@name}", result: "John Smith"},
+		{template: "#{=-2147483648:@name}", result: "John Smith"},
+		{template: "#{=2147483647:@name}", result: "John Smith"},
+		{template: "#{p99999999999999:@name}", result: "John Smith"},
+		{template: "#{p2147483647;n:@name}", result: "10000"},
+		{template: "#{p-2147483648;n:@name}", result: "10000"},
+		{template: "#{b:@path}", result: "project"},
+		{template: "#{d:@path}", result: "/home/jim/src"},
+		{template: "#{n:@name}", result: "10"},
+		{template: "#{s/John/Jim/:@name}", result: "Jim Smith"},
+		{template: "#{s/o/0/;=3:@name}", result: "J0h"},
+		{template: "#{q:@name}", result: `John\ Smith`},
+		{template: "#{@fmt}", result: "#{session_name}:#{window_index}"},
+		{template: "#{E:@fmt}", result: "work:2"},
+		{template: "#{E:@loop}", result: ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.result, theme.Expand(tt.template), tt.template)
+	}
+}
+
+func TestThemeExpandWithoutContext(t *testing.T) {
+	theme := New()
+
+	assert.Equal(t, ":", theme.Expand("#S:#{window_name}"))
+	assert.Equal(t, "no", theme.Expand("#{?client_prefix,yes,no}"))
+}
+
+func TestThemeExecuteFormatsWithContext(t *testing.T) {
+	theme := New()
+	theme.Context = &FormatContext{
+		Session: &FormatSession{Name: "work"},
+		Window:  &FormatWindow{Index: 3, Name: "logs"},
+	}
+
+	theme.Statements = []Statement{
+		&SetOptionStatement{
+			Flags:  &SetOptionFlags{Global: true, Format: true},
+			Option: "@title",
+			Value:  "#S/#{window_index}:#{=2:window_name}",
+		},
+	}
+
+	assert.NoError(t, theme.Execute())
+	assert.Equal(t, "work/3:lo", theme.GlobalSessionOptions["@title"])
+}
+
+func TestThemeExpandTime(t *testing.T) {
+	theme := New()
+	theme.GlobalSessionOptions["@clock"] = "%H:%M"
+	theme.GlobalSessionOptions["@status-right"] = "#H « %H:%M:%S"
+	theme.Context = &FormatContext{
+		Host:    "box",
+		Session: &FormatSession{Name: "50%"},
+		Client:  &FormatClient{Prefix: true},
+		Clock: func() time.Time {
+			return time.Date(2019, 3, 7, 21, 5, 3, 0, time.UTC)
+		},
+	}
+
+	var tests = []struct {
+		template string
+		result   string
+	}{
+		{template: "%H:%M:%S %d-%b-%y", result: "21:05:03 07-Mar-19"},
+		{template: "#S %H", result: "50% 21"},
+		{template: "#{@clock}", result: "%H:%M"},
+		{template: "#{T:@clock}", result: "21:05"},
+		{template: "#{E:@status-right}", result: "box « 21:05:03"},
+		{template: "100%% #H", result: "100% box"},
+		{template: "#{?client_prefix,%H,%M}", result: "21"},
+		{template: "#{?client_prefix,100%%%%,no}", result: "100%"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.result, theme.ExpandTime(tt.template), tt.template)
+	}
+
+	assert.Equal(t, "%H:%M", theme.Expand("%H:%M"))
+	assert.Equal(t, "21:05", theme.Expand("#{T:@clock}"))
+}
+
+func TestThemeWithContext(t *testing.T) {
+	theme := New()
+	theme.GlobalSessionOptions["@name"] = "John"
+
+	work := theme.WithContext(&FormatContext{
+		Session: &FormatSession{Name: "work"},
+	})
+
+	assert.Nil(t, theme.Context)
+	assert.Equal(t, "work John", work.Expand("#S #{@name}"))
+
+	theme.GlobalSessionOptions["@name"] = "Jim"
+	assert.Equal(t, "work Jim", work.Expand("#S #{@name}"))
+}
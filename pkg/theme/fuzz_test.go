@@ -0,0 +1,205 @@
+//go:build go1.18
+// +build go1.18
+
+package theme
+
+import (
+	"bufio"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kballard/go-shellquote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fuzzSeedFiles are the themes the fuzz targets are seeded from.
+var fuzzSeedFiles = []string{"theme_test.tmuxtheme", "fuzz_test.tmuxtheme"}
+
+// fuzzSeeds returns the seed theme sources, followed by each of their
+// lines.
+func fuzzSeeds(f *testing.F) []string {
+	seeds := []string{}
+	lines := []string{}
+
+	for _, filename := range fuzzSeedFiles {
+		b, err := ioutil.ReadFile(filename)
+		require.NoError(f, err)
+
+		seeds = append(seeds, string(b))
+		scanner := bufio.NewScanner(strings.NewReader(string(b)))
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+	}
+
+	return append(seeds, lines...)
+}
+
+// fuzzValues returns the values of the seed themes' set-option statements.
+func fuzzValues(f *testing.F) []string {
+	values := []string{}
+	for _, seed := range fuzzSeeds(f) {
+		st, err := NewStatement(seed)
+		if s, ok := st.(*SetOptionStatement); err == nil && ok {
+			values = append(values, s.Value)
+		}
+	}
+
+	return values
+}
+
+func writeTheme(t *testing.T, theme *Theme) string {
+	var buf strings.Builder
+	require.NoError(t, theme.Write(&buf))
+
+	return buf.String()
+}
+
+func FuzzTokenize(f *testing.F) {
+	for _, seed := range fuzzSeeds(f) {
+		f.Add(seed)
+	}
+	f.Add("set -g @a \\")
+	f.Add("set -g @a '#{")
+
+	f.Fuzz(func(t *testing.T, src string) {
+		end := 0
+		for _, token := range Tokenize(src) {
+			require.True(t, token.Start >= end && token.Start < token.End &&
+				token.End <= len(src), "token %+v out of order", token)
+			assert.Equal(t, src[token.Start:token.End], token.Text)
+			assert.NotContains(t, token.Text, "\n")
+			end = token.End
+		}
+	})
+}
+
+func FuzzNewStatement(f *testing.F) {
+	for _, seed := range fuzzSeeds(f) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, body string) {
+		st, err := NewStatement(body)
+		if err != nil {
+			return
+		}
+
+		written := st.(interface{ String() string }).String()
+		parsed, err := NewStatement(written)
+		require.NoError(t, err, "%q written as %q", body, written)
+		assert.Equal(t, st, parsed, "%q written as %q", body, written)
+
+		// tmux needs the value argument even when it is empty.
+		if s, ok := st.(*SetOptionStatement); ok && !s.Flags.Unset {
+			args, err := shellquote.Split(written)
+			require.NoError(t, err)
+			assert.Equal(t, s.Value, args[len(args)-1],
+				"%q written as %q", body, written)
+		}
+	})
+}
+
+func FuzzThemeParse(f *testing.F) {
+	for _, seed := range fuzzSeeds(f) {
+		f.Add(seed)
+	}
+	f.Add("set -g @a \\")
+
+	f.Fuzz(func(t *testing.T, src string) {
+		theme := New()
+		if err := theme.Parse(strings.NewReader(src)); err != nil {
+			return
+		}
+
+		written := writeTheme(t, theme)
+		parsed := New()
+		require.NoError(t, parsed.Parse(strings.NewReader(written)),
+			"%q written as %q", src, written)
+		assert.Equal(t, theme.Statements, parsed.Statements,
+			"%q written as %q", src, written)
+		assert.Equal(t, written, writeTheme(t, parsed))
+
+		theme.Context = fuzzContext()
+		_ = theme.Execute()
+	})
+}
+
+func fuzzContext() *FormatContext {
+	return &FormatContext{
+		Host:    "box.example.com",
+		Session: &FormatSession{ID: 1, Name: "work", Windows: 2},
+		Window:  &FormatWindow{ID: 3, Index: 2, Name: "vim", Active: true},
+		Pane:    &FormatPane{ID: 4, Index: 1, Title: "editor"},
+		Client:  &FormatClient{Prefix: true},
+		Clock: func() time.Time {
+			return time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+		},
+	}
+}
+
+func FuzzExpand(f *testing.F) {
+	for _, value := range fuzzValues(f) {
+		f.Add(value)
+	}
+
+	f.Fuzz(func(t *testing.T, template string) {
+		theme := New()
+		theme.Context = fuzzContext()
+		theme.GlobalSessionOptions["@name"] = "John Smith"
+		theme.GlobalSessionOptions["@loop"] = "#{E:@loop}"
+		theme.GlobalSessionOptions["@self"] = template
+
+		result := theme.Expand(template)
+		assert.Equal(t, result, ParseTemplate(template).Evaluate(theme))
+		if utf8.ValidString(template) && !strings.Contains(template, "#") {
+			assert.Equal(t, template, result)
+		}
+		_ = theme.ExpandTime(template)
+
+		st := &SetOptionStatement{
+			Flags:  &SetOptionFlags{Global: true, Format: true},
+			Option: "@value",
+			Value:  template,
+		}
+		require.NoError(t, st.Execute(theme))
+	})
+}
+
+func FuzzParseStyle(f *testing.F) {
+	for _, value := range fuzzValues(f) {
+		f.Add(value)
+	}
+	f.Add("fg=colour235,bg=#88c0d0,bold,nounderscore,align=centre")
+	f.Add("fill=red list=on range=window|1 default")
+
+	f.Fuzz(func(t *testing.T, value string) {
+		style, err := ParseStyle(value)
+		if err != nil {
+			return
+		}
+
+		formatted := style.String()
+		parsed, err := ParseStyle(formatted)
+		require.NoError(t, err, "%q formatted as %q", value, formatted)
+		assert.Equal(t, style, parsed, "%q formatted as %q", value, formatted)
+		assert.Equal(t, formatted, parsed.String())
+	})
+}
+
+func FuzzQuote(f *testing.F) {
+	for _, value := range fuzzValues(f) {
+		f.Add(value)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		args, err := shellquote.Split("set @x " + Quote(value))
+		require.NoError(t, err, "%q quoted as %q", value, Quote(value))
+		assert.Equal(t, []string{"set", "@x", value}, args,
+			"%q quoted as %q", value, Quote(value))
+	})
+}
@@ -0,0 +1,11 @@
+package theme
+
+import "fmt"
+
+type InvalidColourError struct {
+	Value string
+}
+
+func (s *InvalidColourError) Error() string {
+	return fmt.Sprintf("Invalid colour: %s", s.Value)
+}
@@ -0,0 +1,17 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidColourErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &InvalidColourError{})
+}
+
+func TestInvalidColourError(t *testing.T) {
+	err := &InvalidColourError{Value: "purplish"}
+
+	assert.Equal(t, "Invalid colour: purplish", err.Error())
+}
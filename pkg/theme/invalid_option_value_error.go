@@ -0,0 +1,15 @@
+package theme
+
+import "fmt"
+
+type InvalidOptionValueError struct {
+	Name  string
+	Value string
+	Type  string
+}
+
+func (s *InvalidOptionValueError) Error() string {
+	return fmt.Sprintf(
+		"Invalid %s value for option %s: %q", s.Type, s.Name, s.Value,
+	)
+}
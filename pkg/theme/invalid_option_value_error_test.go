@@ -0,0 +1,23 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidOptionValueErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &InvalidOptionValueError{})
+}
+
+func TestInvalidOptionValueError(t *testing.T) {
+	err := &InvalidOptionValueError{
+		Name: "status-interval", Value: "often", Type: "number",
+	}
+
+	assert.Equal(
+		t,
+		`Invalid number value for option status-interval: "often"`,
+		err.Error(),
+	)
+}
@@ -0,0 +1,12 @@
+package theme
+
+import "fmt"
+
+type InvalidStyleError struct {
+	Value string
+	Token string
+}
+
+func (s *InvalidStyleError) Error() string {
+	return fmt.Sprintf("Invalid style: %s (bad token: %s)", s.Value, s.Token)
+}
@@ -0,0 +1,17 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidStyleErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &InvalidStyleError{})
+}
+
+func TestInvalidStyleError(t *testing.T) {
+	err := &InvalidStyleError{Value: "fg=red,wobbly", Token: "wobbly"}
+
+	assert.Equal(t, "Invalid style: fg=red,wobbly (bad token: wobbly)", err.Error())
+}
@@ -0,0 +1,75 @@
+package theme
+
+import (
+	"fmt"
+	"strings"
+)
+
+type LookupStep struct {
+	Scope   Scope
+	Target  string
+	Default bool
+	Found   bool
+	Value   string
+}
+
+type LookupTrace struct {
+	Name     string
+	Session  string
+	Window   string
+	BuiltIn  bool
+	Variable bool
+	Steps    []*LookupStep
+	Result   *OptionValue
+}
+
+func (s *LookupTrace) Value() string {
+	if s.Result == nil {
+		return ""
+	}
+
+	return s.Result.Value
+}
+
+func (s *LookupTrace) String() string {
+	var b strings.Builder
+
+	kind := "user option"
+	if s.BuiltIn {
+		kind = "built-in option"
+	}
+	fmt.Fprintf(&b, "#{%s} (%s)\n", s.Name, kind)
+
+	for _, step := range s.Steps {
+		where := step.Scope.String()
+		if step.Default {
+			where = "built-in default"
+		} else if step.Target != "" {
+			where += " " + step.Target
+		}
+
+		if step.Found {
+			fmt.Fprintf(&b, "  %s: found %q\n", where, step.Value)
+		} else {
+			fmt.Fprintf(&b, "  %s: not set\n", where)
+		}
+	}
+
+	if s.Variable {
+		fmt.Fprintf(&b, "  format variable: found %q\n", s.Result.Value)
+	}
+
+	if s.Result == nil {
+		fmt.Fprintf(&b, "  => \"\" (not found)\n")
+	} else {
+		fmt.Fprintf(&b, "  => %q\n", s.Result.Value)
+	}
+
+	return b.String()
+}
+
+func (s *LookupTrace) addStep(step *LookupStep) {
+	if s != nil {
+		s.Steps = append(s.Steps, step)
+	}
+}
@@ -0,0 +1,85 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupTraceString(t *testing.T) {
+	var tests = []struct {
+		trace *LookupTrace
+		str   string
+	}{
+		{
+			trace: &LookupTrace{
+				Name: "@name",
+				Steps: []*LookupStep{
+					{Scope: ServerScope},
+					{Scope: WindowScope, Target: "dev:1"},
+					{Scope: GlobalWindowScope, Found: true, Value: "John"},
+				},
+				Result: &OptionValue{
+					Name: "@name", Value: "John", Scope: GlobalWindowScope,
+				},
+			},
+			str: `#{@name} (user option)
+  server: not set
+  window dev:1: not set
+  global-window: found "John"
+  => "John"
+`,
+		},
+		{
+			trace: &LookupTrace{
+				Name:    "status-interval",
+				BuiltIn: true,
+				Steps: []*LookupStep{
+					{Scope: SessionScope},
+					{Scope: GlobalSessionScope},
+					{
+						Scope: GlobalSessionScope, Default: true,
+						Found: true, Value: "15",
+					},
+				},
+				Result: &OptionValue{
+					Name: "status-interval", Value: "15",
+					Scope: GlobalSessionScope, Default: true,
+				},
+			},
+			str: `#{status-interval} (built-in option)
+  session: not set
+  global-session: not set
+  built-in default: found "15"
+  => "15"
+`,
+		},
+		{
+			trace: &LookupTrace{
+				Name:     "session_name",
+				Variable: true,
+				Steps:    []*LookupStep{{Scope: ServerScope}},
+				Result:   &OptionValue{Name: "session_name", Value: "work"},
+			},
+			str: `#{session_name} (user option)
+  server: not set
+  format variable: found "work"
+  => "work"
+`,
+		},
+		{
+			trace: &LookupTrace{
+				Name:  "@missing",
+				Steps: []*LookupStep{{Scope: ServerScope}},
+			},
+			str: `#{@missing} (user option)
+  server: not set
+  => "" (not found)
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.str, tt.trace.String())
+	}
+}
@@ -0,0 +1,235 @@
+package theme
+
+type OptionType int
+
+const (
+	StringOption OptionType = iota
+	NumberOption
+	FlagOption
+	ChoiceOption
+	ColourOption
+	StyleOption
+	KeyOption
+)
+
+var optionTypeNames = map[OptionType]string{
+	StringOption: "string",
+	NumberOption: "number",
+	FlagOption:   "flag",
+	ChoiceOption: "choice",
+	ColourOption: "colour",
+	StyleOption:  "style",
+	KeyOption:    "key",
+}
+
+func (s OptionType) String() string {
+	if name, ok := optionTypeNames[s]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+type OptionDefinition struct {
+	Name    string
+	Scope   Scope
+	Type    OptionType
+	Default string
+	Choices []string
+}
+
+var optionDefinitions = []*OptionDefinition{
+	//
+	// Server Options
+	//
+	{Name: "buffer-limit", Scope: ServerScope, Type: NumberOption, Default: "50"},
+	{Name: "default-terminal", Scope: ServerScope, Type: StringOption, Default: "screen"},
+	{Name: "escape-time", Scope: ServerScope, Type: NumberOption, Default: "500"},
+	{Name: "exit-empty", Scope: ServerScope, Type: FlagOption, Default: "on"},
+	{Name: "exit-unattached", Scope: ServerScope, Type: FlagOption, Default: "off"},
+	{
+		Name: "extended-keys", Scope: ServerScope, Type: ChoiceOption,
+		Default: "off", Choices: []string{"off", "on", "always"},
+	},
+	{Name: "focus-events", Scope: ServerScope, Type: FlagOption, Default: "off"},
+	{Name: "history-file", Scope: ServerScope, Type: StringOption, Default: ""},
+	{Name: "message-limit", Scope: ServerScope, Type: NumberOption, Default: "1000"},
+	{
+		Name: "set-clipboard", Scope: ServerScope, Type: ChoiceOption,
+		Default: "external", Choices: []string{"off", "external", "on"},
+	},
+	{Name: "terminal-overrides", Scope: ServerScope, Type: StringOption, Default: ""},
+	//
+	// Session Options
+	//
+	{
+		Name: "activity-action", Scope: SessionScope, Type: ChoiceOption,
+		Default: "other", Choices: []string{"none", "any", "current", "other"},
+	},
+	{Name: "base-index", Scope: SessionScope, Type: NumberOption, Default: "0"},
+	{
+		Name: "bell-action", Scope: SessionScope, Type: ChoiceOption,
+		Default: "any", Choices: []string{"none", "any", "current", "other"},
+	},
+	{Name: "default-command", Scope: SessionScope, Type: StringOption, Default: ""},
+	{Name: "default-shell", Scope: SessionScope, Type: StringOption, Default: "/bin/sh"},
+	{Name: "default-size", Scope: SessionScope, Type: StringOption, Default: "80x24"},
+	{Name: "destroy-unattached", Scope: SessionScope, Type: FlagOption, Default: "off"},
+	{
+		Name: "detach-on-destroy", Scope: SessionScope, Type: ChoiceOption,
+		Default: "on", Choices: []string{"off", "on", "no-detached"},
+	},
+	{Name: "display-panes-active-colour", Scope: SessionScope, Type: ColourOption, Default: "red"},
+	{Name: "display-panes-colour", Scope: SessionScope, Type: ColourOption, Default: "blue"},
+	{Name: "display-panes-time", Scope: SessionScope, Type: NumberOption, Default: "1000"},
+	{Name: "display-time", Scope: SessionScope, Type: NumberOption, Default: "750"},
+	{Name: "history-limit", Scope: SessionScope, Type: NumberOption, Default: "2000"},
+	{Name: "lock-after-time", Scope: SessionScope, Type: NumberOption, Default: "0"},
+	{Name: "message-command-style", Scope: SessionScope, Type: StyleOption, Default: "bg=black,fg=yellow"},
+	{Name: "message-style", Scope: SessionScope, Type: StyleOption, Default: "bg=yellow,fg=black"},
+	{Name: "mouse", Scope: SessionScope, Type: FlagOption, Default: "off"},
+	{Name: "prefix", Scope: SessionScope, Type: KeyOption, Default: "C-b"},
+	{Name: "prefix2", Scope: SessionScope, Type: KeyOption, Default: "None"},
+	{Name: "renumber-windows", Scope: SessionScope, Type: FlagOption, Default: "off"},
+	{Name: "repeat-time", Scope: SessionScope, Type: NumberOption, Default: "500"},
+	{Name: "set-titles", Scope: SessionScope, Type: FlagOption, Default: "off"},
+	{
+		Name: "set-titles-string", Scope: SessionScope, Type: StringOption,
+		Default: "#S:#I:#W - \"#T\" #{session_alerts}",
+	},
+	{
+		Name: "silence-action", Scope: SessionScope, Type: ChoiceOption,
+		Default: "other", Choices: []string{"none", "any", "current", "other"},
+	},
+	{
+		Name: "status", Scope: SessionScope, Type: ChoiceOption,
+		Default: "on", Choices: []string{"off", "on", "2", "3", "4", "5"},
+	},
+	{Name: "status-bg", Scope: SessionScope, Type: ColourOption, Default: "default"},
+	{Name: "status-fg", Scope: SessionScope, Type: ColourOption, Default: "default"},
+	{Name: "status-interval", Scope: SessionScope, Type: NumberOption, Default: "15"},
+	{
+		Name: "status-justify", Scope: SessionScope, Type: ChoiceOption,
+		Default: "left", Choices: []string{"left", "centre", "right", "absolute-centre"},
+	},
+	{
+		Name: "status-keys", Scope: SessionScope, Type: ChoiceOption,
+		Default: "emacs", Choices: []string{"emacs", "vi"},
+	},
+	{Name: "status-left", Scope: SessionScope, Type: StringOption, Default: "[#{session_name}] "},
+	{Name: "status-left-length", Scope: SessionScope, Type: NumberOption, Default: "10"},
+	{Name: "status-left-style", Scope: SessionScope, Type: StyleOption, Default: "default"},
+	{
+		Name: "status-position", Scope: SessionScope, Type: ChoiceOption,
+		Default: "bottom", Choices: []string{"top", "bottom"},
+	},
+	{
+		Name: "status-right", Scope: SessionScope, Type: StringOption,
+		Default: "\"#{=21:pane_title}\" %H:%M %d-%b-%y",
+	},
+	{Name: "status-right-length", Scope: SessionScope, Type: NumberOption, Default: "40"},
+	{Name: "status-right-style", Scope: SessionScope, Type: StyleOption, Default: "default"},
+	{Name: "status-style", Scope: SessionScope, Type: StyleOption, Default: "bg=green,fg=black"},
+	{Name: "update-environment", Scope: SessionScope, Type: StringOption, Default: ""},
+	{
+		Name: "visual-activity", Scope: SessionScope, Type: ChoiceOption,
+		Default: "off", Choices: []string{"off", "on", "both"},
+	},
+	{
+		Name: "visual-bell", Scope: SessionScope, Type: ChoiceOption,
+		Default: "off", Choices: []string{"off", "on", "both"},
+	},
+	{
+		Name: "visual-silence", Scope: SessionScope, Type: ChoiceOption,
+		Default: "off", Choices: []string{"off", "on", "both"},
+	},
+	{Name: "word-separators", Scope: SessionScope, Type: StringOption, Default: " "},
+	//
+	// Window Options
+	//
+	{Name: "aggressive-resize", Scope: WindowScope, Type: FlagOption, Default: "off"},
+	{Name: "allow-rename", Scope: WindowScope, Type: FlagOption, Default: "off"},
+	{Name: "alternate-screen", Scope: WindowScope, Type: FlagOption, Default: "on"},
+	{Name: "automatic-rename", Scope: WindowScope, Type: FlagOption, Default: "on"},
+	{
+		Name: "automatic-rename-format", Scope: WindowScope, Type: StringOption,
+		Default: "#{?pane_in_mode,[tmux],#{pane_current_command}}#{?pane_dead,[dead],}",
+	},
+	{Name: "clock-mode-colour", Scope: WindowScope, Type: ColourOption, Default: "blue"},
+	{
+		Name: "clock-mode-style", Scope: WindowScope, Type: ChoiceOption,
+		Default: "24", Choices: []string{"12", "24"},
+	},
+	{Name: "copy-mode-match-style", Scope: WindowScope, Type: StyleOption, Default: "bg=cyan,fg=black"},
+	{Name: "copy-mode-current-match-style", Scope: WindowScope, Type: StyleOption, Default: "bg=magenta,fg=black"},
+	{Name: "main-pane-height", Scope: WindowScope, Type: NumberOption, Default: "24"},
+	{Name: "main-pane-width", Scope: WindowScope, Type: NumberOption, Default: "80"},
+	{
+		Name: "mode-keys", Scope: WindowScope, Type: ChoiceOption,
+		Default: "emacs", Choices: []string{"emacs", "vi"},
+	},
+	{Name: "mode-style", Scope: WindowScope, Type: StyleOption, Default: "bg=yellow,fg=black"},
+	{Name: "monitor-activity", Scope: WindowScope, Type: FlagOption, Default: "off"},
+	{Name: "monitor-bell", Scope: WindowScope, Type: FlagOption, Default: "on"},
+	{Name: "monitor-silence", Scope: WindowScope, Type: NumberOption, Default: "0"},
+	{Name: "other-pane-height", Scope: WindowScope, Type: NumberOption, Default: "0"},
+	{Name: "other-pane-width", Scope: WindowScope, Type: NumberOption, Default: "0"},
+	{Name: "pane-active-border-style", Scope: WindowScope, Type: StyleOption, Default: "fg=green"},
+	{Name: "pane-base-index", Scope: WindowScope, Type: NumberOption, Default: "0"},
+	{
+		Name: "pane-border-format", Scope: WindowScope, Type: StringOption,
+		Default: "#{?pane_active,#[reverse],}#{pane_index}#[default] \"#{pane_title}\"",
+	},
+	{
+		Name: "pane-border-lines", Scope: WindowScope, Type: ChoiceOption,
+		Default: "single", Choices: []string{"single", "double", "heavy", "simple", "number"},
+	},
+	{
+		Name: "pane-border-status", Scope: WindowScope, Type: ChoiceOption,
+		Default: "off", Choices: []string{"off", "top", "bottom"},
+	},
+	{Name: "pane-border-style", Scope: WindowScope, Type: StyleOption, Default: "default"},
+	{
+		Name: "remain-on-exit", Scope: WindowScope, Type: ChoiceOption,
+		Default: "off", Choices: []string{"off", "on", "failed"},
+	},
+	{Name: "synchronize-panes", Scope: WindowScope, Type: FlagOption, Default: "off"},
+	{Name: "window-active-style", Scope: WindowScope, Type: StyleOption, Default: "default"},
+	{
+		Name: "window-size", Scope: WindowScope, Type: ChoiceOption,
+		Default: "latest", Choices: []string{"largest", "smallest", "manual", "latest"},
+	},
+	{Name: "window-style", Scope: WindowScope, Type: StyleOption, Default: "default"},
+	{Name: "window-status-activity-style", Scope: WindowScope, Type: StyleOption, Default: "reverse"},
+	{Name: "window-status-bell-style", Scope: WindowScope, Type: StyleOption, Default: "reverse"},
+	{
+		Name: "window-status-current-format", Scope: WindowScope, Type: StringOption,
+		Default: "#I:#W#{?window_flags,#{window_flags}, }",
+	},
+	{Name: "window-status-current-style", Scope: WindowScope, Type: StyleOption, Default: "default"},
+	{
+		Name: "window-status-format", Scope: WindowScope, Type: StringOption,
+		Default: "#I:#W#{?window_flags,#{window_flags}, }",
+	},
+	{Name: "window-status-last-style", Scope: WindowScope, Type: StyleOption, Default: "default"},
+	{Name: "window-status-separator", Scope: WindowScope, Type: StringOption, Default: " "},
+	{Name: "window-status-style", Scope: WindowScope, Type: StyleOption, Default: "default"},
+	{Name: "wrap-search", Scope: WindowScope, Type: FlagOption, Default: "on"},
+}
+
+var optionDefinitionsByName = func() map[string]*OptionDefinition {
+	m := map[string]*OptionDefinition{}
+	for _, d := range optionDefinitions {
+		m[d.Name] = d
+	}
+	return m
+}()
+
+func LookupOptionDefinition(name string) (*OptionDefinition, bool) {
+	d, ok := optionDefinitionsByName[name]
+	return d, ok
+}
+
+func OptionDefinitions() []*OptionDefinition {
+	return append([]*OptionDefinition{}, optionDefinitions...)
+}
@@ -0,0 +1,59 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionTypeString(t *testing.T) {
+	tests := []struct {
+		t    OptionType
+		want string
+	}{
+		{StringOption, "string"},
+		{NumberOption, "number"},
+		{FlagOption, "flag"},
+		{ChoiceOption, "choice"},
+		{ColourOption, "colour"},
+		{StyleOption, "style"},
+		{KeyOption, "key"},
+		{OptionType(99), "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.t.String())
+		})
+	}
+}
+
+func TestLookupOptionDefinition(t *testing.T) {
+	def, ok := LookupOptionDefinition("status-style")
+	assert.True(t, ok)
+	assert.Equal(t, SessionScope, def.Scope)
+	assert.Equal(t, StyleOption, def.Type)
+	assert.Equal(t, "bg=green,fg=black", def.Default)
+
+	def, ok = LookupOptionDefinition("pane-border-lines")
+	assert.True(t, ok)
+	assert.Equal(t, WindowScope, def.Scope)
+	assert.Contains(t, def.Choices, "double")
+
+	_, ok = LookupOptionDefinition("@theme-status-bg")
+	assert.False(t, ok)
+}
+
+func TestOptionDefinitionDefaultsAreValid(t *testing.T) {
+	for _, def := range OptionDefinitions() {
+		switch def.Type {
+		case ColourOption:
+			_, err := ParseColour(def.Default)
+			assert.NoError(t, err, def.Name)
+		case StyleOption:
+			_, err := ParseStyle(def.Default)
+			assert.NoError(t, err, def.Name)
+		case ChoiceOption:
+			assert.Contains(t, def.Choices, def.Default, def.Name)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package theme
+
+import "fmt"
+
+type OptionNotFoundError struct {
+	Scope  Scope
+	Target string
+	Name   string
+}
+
+func (s *OptionNotFoundError) Error() string {
+	if s.Target != "" {
+		return fmt.Sprintf(
+			"Option not found: %s (%s scope, target %s)",
+			s.Name, s.Scope, s.Target,
+		)
+	}
+
+	return fmt.Sprintf("Option not found: %s (%s scope)", s.Name, s.Scope)
+}
@@ -0,0 +1,37 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionNotFoundErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &OptionNotFoundError{})
+}
+
+func TestOptionNotFoundError(t *testing.T) {
+	var tests = []struct {
+		scope  Scope
+		target string
+		name   string
+		msg    string
+	}{
+		{
+			GlobalSessionScope, "", "@foo",
+			"Option not found: @foo (global-session scope)",
+		},
+		{
+			WindowScope, "dev:1", "@foo",
+			"Option not found: @foo (window scope, target dev:1)",
+		},
+	}
+
+	for _, tt := range tests {
+		err := &OptionNotFoundError{
+			Scope: tt.scope, Target: tt.target, Name: tt.name,
+		}
+
+		assert.Equal(t, tt.msg, err.Error())
+	}
+}
@@ -0,0 +1,59 @@
+package theme
+
+import (
+	"strconv"
+)
+
+type OptionValue struct {
+	Name    string
+	Value   string
+	Scope   Scope
+	Target  string
+	Default bool
+}
+
+func (s *OptionValue) String() string {
+	return s.Value
+}
+
+func (s *OptionValue) Int() (int, error) {
+	n, err := strconv.Atoi(s.Value)
+	if err != nil {
+		return 0, s.invalid("number")
+	}
+
+	return n, nil
+}
+
+func (s *OptionValue) Bool() (bool, error) {
+	switch s.Value {
+	case "on", "yes", "1":
+		return true, nil
+	case "off", "no", "0":
+		return false, nil
+	}
+
+	return false, s.invalid("flag")
+}
+
+func (s *OptionValue) Colour() (Colour, error) {
+	c, err := ParseColour(s.Value)
+	if err != nil {
+		return c, s.invalid("colour")
+	}
+
+	return c, nil
+}
+
+func (s *OptionValue) Style() (Style, error) {
+	st, err := ParseStyle(s.Value)
+	if err != nil {
+		return st, s.invalid("style")
+	}
+
+	return st, nil
+}
+
+func (s *OptionValue) invalid(kind string) error {
+	return &InvalidOptionValueError{Name: s.Name, Value: s.Value, Type: kind}
+}
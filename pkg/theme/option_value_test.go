@@ -0,0 +1,77 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionValueInt(t *testing.T) {
+	v := &OptionValue{Name: "status-interval", Value: "5"}
+	n, err := v.Int()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	v = &OptionValue{Name: "status-interval", Value: "often"}
+	_, err = v.Int()
+	assert.Equal(t, &InvalidOptionValueError{
+		Name: "status-interval", Value: "often", Type: "number",
+	}, err)
+}
+
+func TestOptionValueBool(t *testing.T) {
+	var tests = []struct {
+		value string
+		bool  bool
+		error bool
+	}{
+		{value: "on", bool: true},
+		{value: "yes", bool: true},
+		{value: "1", bool: true},
+		{value: "off", bool: false},
+		{value: "no", bool: false},
+		{value: "0", bool: false},
+		{value: "", error: true},
+		{value: "maybe", error: true},
+	}
+
+	for _, tt := range tests {
+		v := &OptionValue{Name: "mouse", Value: tt.value}
+		b, err := v.Bool()
+
+		if tt.error {
+			assert.Equal(t, &InvalidOptionValueError{
+				Name: "mouse", Value: tt.value, Type: "flag",
+			}, err)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, tt.bool, b)
+		}
+	}
+}
+
+func TestOptionValueColour(t *testing.T) {
+	v := &OptionValue{Name: "clock-mode-colour", Value: "colour160"}
+	c, err := v.Colour()
+	assert.NoError(t, err)
+	assert.Equal(t, Colour{Type: PaletteColour, Index: 160}, c)
+
+	v = &OptionValue{Name: "clock-mode-colour", Value: "bg=red"}
+	_, err = v.Colour()
+	assert.Equal(t, &InvalidOptionValueError{
+		Name: "clock-mode-colour", Value: "bg=red", Type: "colour",
+	}, err)
+}
+
+func TestOptionValueStyle(t *testing.T) {
+	v := &OptionValue{Name: "status-style", Value: "bg=black,fg=cyan"}
+	st, err := v.Style()
+	assert.NoError(t, err)
+	assert.Equal(t, "fg=cyan,bg=black", st.String())
+
+	v = &OptionValue{Name: "status-style", Value: "fg=nope"}
+	_, err = v.Style()
+	assert.Equal(t, &InvalidOptionValueError{
+		Name: "status-style", Value: "fg=nope", Type: "style",
+	}, err)
+}
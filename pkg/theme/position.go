@@ -0,0 +1,33 @@
+package theme
+
+import "fmt"
+
+// Position is the location of a statement in a theme file. Statements
+// continued with trailing backslashes span from Line to EndLine.
+type Position struct {
+	Filename string
+	Line     int
+	EndLine  int
+}
+
+func (s Position) IsValid() bool {
+	return s.Line > 0
+}
+
+func (s Position) String() string {
+	pos := s.Filename
+	if s.IsValid() {
+		if pos != "" {
+			pos += ":"
+		}
+		pos += fmt.Sprintf("%d", s.Line)
+		if s.EndLine > s.Line {
+			pos += fmt.Sprintf("-%d", s.EndLine)
+		}
+	}
+	if pos == "" {
+		pos = "-"
+	}
+
+	return pos
+}
@@ -0,0 +1,25 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPositionString(t *testing.T) {
+	var tests = []struct {
+		pos  Position
+		want string
+	}{
+		{Position{}, "-"},
+		{Position{Filename: "a.tmuxtheme"}, "a.tmuxtheme"},
+		{Position{Line: 3, EndLine: 3}, "3"},
+		{Position{Filename: "a.tmuxtheme", Line: 3, EndLine: 3}, "a.tmuxtheme:3"},
+		{Position{Filename: "a.tmuxtheme", Line: 3, EndLine: 5}, "a.tmuxtheme:3-5"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.pos.String())
+		assert.Equal(t, tt.pos.Line > 0, tt.pos.IsValid())
+	}
+}
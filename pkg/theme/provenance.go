@@ -0,0 +1,113 @@
+package theme
+
+import (
+	"fmt"
+	"strings"
+)
+
+type AssignmentAction int
+
+const (
+	SetAction AssignmentAction = iota
+	AppendAction
+	UnsetAction
+	SkippedAction
+)
+
+var assignmentActionNames = map[AssignmentAction]string{
+	SetAction:     "set",
+	AppendAction:  "append",
+	UnsetAction:   "unset",
+	SkippedAction: "skipped (-o)",
+}
+
+func (s AssignmentAction) String() string {
+	if name, ok := assignmentActionNames[s]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// Assignment records one executed statement which set, appended to or
+// unset an option, or which was skipped by -o because the option was
+// already set.
+type Assignment struct {
+	Action    AssignmentAction
+	Statement *SetOptionStatement
+	Position  Position
+	Layer     int
+
+	// Previous is the value the statement overwrote, if HadPrevious is set.
+	Previous    string
+	HadPrevious bool
+
+	// Value is the option's value after the statement, empty when unset.
+	Value string
+
+	// References holds the lookups made while expanding a -F value.
+	References []*LookupTrace
+}
+
+// Provenance is the chain of statements which touched an option in one
+// scope and target, in execution order.
+type Provenance struct {
+	Scope       Scope
+	Target      string
+	Name        string
+	Assignments []*Assignment
+}
+
+// Provenance returns the statements executed for the option in scope and
+// target, or nil if no statement set it.
+func (s *Theme) Provenance(scope Scope, target, name string) *Provenance {
+	return s.provenance[optionKey{scope, target, name}]
+}
+
+func (s *Theme) record(scope Scope, target, name string, a *Assignment) {
+	if s.provenance == nil {
+		s.provenance = map[optionKey]*Provenance{}
+	}
+
+	a.Position = s.Position(s.current - 1)
+	a.Layer = s.layer
+
+	key := optionKey{scope, target, name}
+	p, ok := s.provenance[key]
+	if !ok {
+		p = &Provenance{Scope: scope, Target: target, Name: name}
+		s.provenance[key] = p
+	}
+	p.Assignments = append(p.Assignments, a)
+}
+
+func (s *Provenance) String() string {
+	var b strings.Builder
+
+	where := s.Scope.String()
+	if s.Target != "" {
+		where += " " + s.Target
+	}
+	fmt.Fprintf(&b, "%s (%s)\n", s.Name, where)
+
+	for _, a := range s.Assignments {
+		fmt.Fprintf(&b, "  %s: %s", a.Position, a.Action)
+		switch a.Action {
+		case UnsetAction:
+		case SkippedAction:
+			fmt.Fprintf(&b, ", kept %q", a.Value)
+		default:
+			fmt.Fprintf(&b, " %q", a.Value)
+		}
+		if a.HadPrevious && a.Action != SkippedAction {
+			fmt.Fprintf(&b, ", overwrote %q", a.Previous)
+		}
+		b.WriteString("\n")
+
+		for _, ref := range a.References {
+			fmt.Fprintf(&b, "    #{%s} = %q\n", ref.Name, ref.Value())
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,90 @@
+package theme
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignmentActionString(t *testing.T) {
+	assert.Equal(t, "set", SetAction.String())
+	assert.Equal(t, "append", AppendAction.String())
+	assert.Equal(t, "unset", UnsetAction.String())
+	assert.Equal(t, "skipped (-o)", SkippedAction.String())
+	assert.Equal(t, "unknown", AssignmentAction(42).String())
+}
+
+func TestThemeProvenance(t *testing.T) {
+	th := New()
+	th.Filename = "test.tmuxtheme"
+	require.NoError(t, th.Parse(strings.NewReader(`set -g @accent blue
+set -g status-style "fg=white"
+set -gF status-style "bg=#{@accent}"
+set -go status-style "bg=red"
+set -ga status-style ",bold"
+set -gu @accent
+`)))
+	require.NoError(t, th.Execute())
+
+	p := th.Provenance(GlobalSessionScope, "", "status-style")
+	require.NotNil(t, p)
+	require.Len(t, p.Assignments, 4)
+
+	a := p.Assignments[1]
+	assert.Equal(t, SetAction, a.Action)
+	assert.Equal(t, Position{"test.tmuxtheme", 3, 3}, a.Position)
+	assert.Equal(t, "bg=blue", a.Value)
+	assert.Equal(t, "fg=white", a.Previous)
+	assert.True(t, a.HadPrevious)
+	require.Len(t, a.References, 1)
+	assert.Equal(t, "@accent", a.References[0].Name)
+	assert.Equal(t, "blue", a.References[0].Value())
+	assert.Equal(t, th.Statements[2], a.Statement)
+
+	assert.Equal(t, `status-style (global-session)
+  test.tmuxtheme:2: set "fg=white"
+  test.tmuxtheme:3: set "bg=blue", overwrote "fg=white"
+    #{@accent} = "blue"
+  test.tmuxtheme:4: skipped (-o), kept "bg=blue"
+  test.tmuxtheme:5: append "bg=blue,bold", overwrote "bg=blue"
+`, p.String())
+
+	p = th.Provenance(GlobalSessionScope, "", "@accent")
+	require.NotNil(t, p)
+	assert.Equal(t, `@accent (global-session)
+  test.tmuxtheme:1: set "blue"
+  test.tmuxtheme:6: unset, overwrote "blue"
+`, p.String())
+
+	assert.Nil(t, th.Provenance(GlobalSessionScope, "", "status-left"))
+}
+
+func TestThemeProvenanceLayers(t *testing.T) {
+	base := New()
+	base.Filename = "base.tmuxtheme"
+	require.NoError(t, base.Parse(strings.NewReader(`set -g @accent blue`)))
+
+	overlay := New()
+	overlay.Filename = "accent.tmuxtheme"
+	require.NoError(t, overlay.Parse(strings.NewReader(`set -w -t main:1 @accent red
+set -g @accent green
+`)))
+
+	th, err := Compose(base, overlay)
+	require.NoError(t, err)
+
+	p := th.Provenance(GlobalSessionScope, "", "@accent")
+	require.NotNil(t, p)
+	require.Len(t, p.Assignments, 2)
+	assert.Equal(t, 0, p.Assignments[0].Layer)
+	assert.Equal(t, Position{"base.tmuxtheme", 1, 1}, p.Assignments[0].Position)
+	assert.Equal(t, 1, p.Assignments[1].Layer)
+	assert.Equal(t, Position{"accent.tmuxtheme", 2, 2},
+		p.Assignments[1].Position)
+
+	p = th.Provenance(WindowScope, "main:1", "@accent")
+	require.NotNil(t, p)
+	assert.Equal(t, "red", p.Assignments[0].Value)
+}
@@ -0,0 +1,26 @@
+package theme
+
+import "strings"
+
+// Quote returns value quoted for use as an argument in a theme file, so it
+// parses back to the same string. Plain words are left bare, single quotes
+// are preferred when the value contains $ or \ which tmux would otherwise
+// interpret inside double quotes.
+func Quote(value string) string {
+	if value == "" {
+		return `""`
+	}
+	if !strings.ContainsAny(value, " \t\n#%\"'\\$;~{}") {
+		return value
+	}
+	if !strings.ContainsAny(value, `"$\`) {
+		return `"` + value + `"`
+	}
+	if !strings.Contains(value, "'") {
+		return "'" + value + "'"
+	}
+
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`)
+
+	return `"` + r.Replace(value) + `"`
+}
@@ -0,0 +1,37 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/kballard/go-shellquote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuote(t *testing.T) {
+	var tests = []struct {
+		value  string
+		quoted string
+	}{
+		{"", `""`},
+		{"black", "black"},
+		{"bg=black,fg=cyan", "bg=black,fg=cyan"},
+		{"#S", `"#S"`},
+		{"%H:%M", `"%H:%M"`},
+		{" #{@name} ", `" #{@name} "`},
+		{"it's", `"it's"`},
+		{`say "hi"`, `'say "hi"'`},
+		{"$HOME", "'$HOME'"},
+		{`it's "$HOME"`, `"it's \"\$HOME\""`},
+		{`a\b`, `'a\b'`},
+	}
+
+	for _, tt := range tests {
+		quoted := Quote(tt.value)
+		assert.Equal(t, tt.quoted, quoted)
+
+		args, err := shellquote.Split("set @x " + quoted)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"set", "@x", tt.value}, args, tt.value)
+	}
+}
@@ -0,0 +1,44 @@
+package theme
+
+type Scope int
+
+const (
+	ServerScope Scope = iota
+	GlobalSessionScope
+	SessionScope
+	GlobalWindowScope
+	WindowScope
+)
+
+var scopeNames = map[Scope]string{
+	ServerScope:        "server",
+	GlobalSessionScope: "global-session",
+	SessionScope:       "session",
+	GlobalWindowScope:  "global-window",
+	WindowScope:        "window",
+}
+
+func (s Scope) String() string {
+	if name, ok := scopeNames[s]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+func (s Scope) IsGlobal() bool {
+	return s == ServerScope || s == GlobalSessionScope || s == GlobalWindowScope
+}
+
+// Parent returns the scope options are inherited from when they are not set
+// in s, and false when s is a top-level scope.
+func (s Scope) Parent() (Scope, bool) {
+	switch s {
+	case SessionScope:
+		return GlobalSessionScope, true
+	case WindowScope:
+		return GlobalWindowScope, true
+	}
+
+	return s, false
+}
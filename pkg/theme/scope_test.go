@@ -0,0 +1,46 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopeString(t *testing.T) {
+	var tests = []struct {
+		scope Scope
+		name  string
+	}{
+		{ServerScope, "server"},
+		{GlobalSessionScope, "global-session"},
+		{SessionScope, "session"},
+		{GlobalWindowScope, "global-window"},
+		{WindowScope, "window"},
+		{Scope(99), "unknown"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.name, tt.scope.String())
+	}
+}
+
+func TestScopeParent(t *testing.T) {
+	var tests = []struct {
+		scope  Scope
+		parent Scope
+		ok     bool
+	}{
+		{ServerScope, ServerScope, false},
+		{GlobalSessionScope, GlobalSessionScope, false},
+		{SessionScope, GlobalSessionScope, true},
+		{GlobalWindowScope, GlobalWindowScope, false},
+		{WindowScope, GlobalWindowScope, true},
+	}
+
+	for _, tt := range tests {
+		parent, ok := tt.scope.Parent()
+
+		assert.Equal(t, tt.parent, parent)
+		assert.Equal(t, tt.ok, ok)
+	}
+}
@@ -1,7 +1,6 @@
 package theme
 
 import (
-	"regexp"
 	"strings"
 
 	"github.com/jessevdk/go-flags"
@@ -11,9 +10,6 @@ import (
 var setOptionStatementCommands = []string{
 	"set", "set-option", "set-window-option",
 }
-var setOptionStatementFormatPattern = regexp.MustCompile(
-	"#{(@?[a-zA-Z0-9-_]+?)}",
-)
 
 type SetOptionFlags struct {
 	Append      bool   `short:"a"`
@@ -27,6 +23,39 @@ type SetOptionFlags struct {
 	Window      bool   `short:"w"`
 }
 
+// String returns the flags other than -t as a single argument, like -goqF,
+// or "" if none are set. Format comes last as in the theme files, the rest
+// are in alphabetical order.
+func (s *SetOptionFlags) String() string {
+	if s == nil {
+		return ""
+	}
+
+	flags := ""
+	for _, f := range []struct {
+		set  bool
+		name string
+	}{
+		{s.Append, "a"},
+		{s.Global, "g"},
+		{s.OnlyIfUnset, "o"},
+		{s.Quiet, "q"},
+		{s.Server, "s"},
+		{s.Unset, "u"},
+		{s.Window, "w"},
+		{s.Format, "F"},
+	} {
+		if f.set {
+			flags += f.name
+		}
+	}
+	if flags == "" {
+		return ""
+	}
+
+	return "-" + flags
+}
+
 type SetOptionStatement struct {
 	Flags  *SetOptionFlags
 	Option string
@@ -55,20 +84,83 @@ func (s *SetOptionStatement) Parse(body string) error {
 	return s.parseArguments(args)
 }
 
+// String returns the statement as a set command which parses back to the
+// same statement, with its flags in a fixed order and its arguments quoted
+// as needed.
+func (s *SetOptionStatement) String() string {
+	args := []string{"set"}
+
+	if flags := s.Flags.String(); flags != "" {
+		args = append(args, flags)
+	}
+	if s.Flags != nil && s.Flags.Target != "" {
+		// A target like -1 would be read as a flag unless attached to -t.
+		if strings.HasPrefix(s.Flags.Target, "-") {
+			args = append(args, "-t"+Quote(s.Flags.Target))
+		} else {
+			args = append(args, "-t", Quote(s.Flags.Target))
+		}
+	}
+
+	if strings.HasPrefix(s.Option, "-") || strings.HasPrefix(s.Value, "-") {
+		args = append(args, "--")
+	}
+	args = append(args, Quote(s.Option))
+	// tmux rejects a missing value as empty unless unsetting, so values set
+	// to "" have to stay quoted.
+	if s.Flags == nil || !s.Flags.Unset || s.Value != "" {
+		args = append(args, Quote(s.Value))
+	}
+
+	return strings.Join(args, " ")
+}
+
 func (s *SetOptionStatement) Execute(theme *Theme) error {
+	return s.applyValue(theme, theme.writableOptions(s.Scope(), s.Target()))
+}
+
+// Scope returns the scope the statement sets its option in. Like tmux, the
+// declared scope of built-in options takes precedence over the -s and -w
+// flags, which only decide the scope of user options.
+func (s *SetOptionStatement) Scope() Scope {
+	if def, ok := LookupOptionDefinition(s.Option); ok {
+		switch def.Scope {
+		case ServerScope:
+			return ServerScope
+		case WindowScope:
+			if s.Flags.Global {
+				return GlobalWindowScope
+			}
+			return WindowScope
+		default:
+			if s.Flags.Global {
+				return GlobalSessionScope
+			}
+			return SessionScope
+		}
+	}
+
 	if s.Flags.Server {
-		return s.applyValue(theme, theme.ServerOptions)
+		return ServerScope
 	} else if s.Flags.Global && s.Flags.Window {
-		return s.applyValue(theme, theme.GlobalWindowOptions)
+		return GlobalWindowScope
 	} else if s.Flags.Window {
-		return s.applyValue(theme, theme.WindowOptions)
+		return WindowScope
 	} else if s.Flags.Global {
-		return s.applyValue(theme, theme.GlobalSessionOptions)
+		return GlobalSessionScope
 	} else {
-		return s.applyValue(theme, theme.SessionOptions)
+		return SessionScope
 	}
 }
 
+func (s *SetOptionStatement) Target() string {
+	if s.Scope().IsGlobal() {
+		return ""
+	}
+
+	return s.Flags.Target
+}
+
 func (s *SetOptionStatement) parseCommand(args []string) ([]string, error) {
 	cmd := ""
 
@@ -95,7 +187,10 @@ func (s *SetOptionStatement) parseCommand(args []string) ([]string, error) {
 
 func (s *SetOptionStatement) parseFlags(args []string) ([]string, error) {
 	s.Flags = &SetOptionFlags{}
-	args, err := flags.ParseArgs(s.Flags, args)
+	// Unlike flags.Default, this never prints errors or help, as stdout may
+	// be a protocol stream like the language server's.
+	parser := flags.NewParser(s.Flags, flags.PassDoubleDash)
+	args, err := parser.ParseArgs(args)
 	if err != nil {
 		return nil, err
 	}
@@ -118,55 +213,69 @@ func (s *SetOptionStatement) parseArguments(args []string) error {
 func (s *SetOptionStatement) applyValue(theme *Theme, options map[string]string) error {
 	option := s.Option
 	value := s.Value
+	previous, set := options[option]
+	a := &Assignment{Statement: s, Previous: previous, HadPrevious: set}
 
-	if s.Flags.OnlyIfUnset {
-		if _, ok := options[option]; ok {
-			return nil
-		}
+	if s.Flags.OnlyIfUnset && set {
+		a.Action = SkippedAction
+		a.Value = previous
+		theme.record(s.Scope(), s.Target(), option, a)
+		return nil
 	}
 
 	if s.Flags.Unset {
 		delete(options, option)
+		theme.setLayer(s.Scope(), s.Target(), option, false)
+		theme.setDeferred(s.Scope(), s.Target(), option, false, false)
+		a.Action = UnsetAction
+		theme.record(s.Scope(), s.Target(), option, a)
 		return nil
 	}
 
-	if s.Flags.Format {
-		value = s.formatValue(theme, value)
+	deferred := s.Flags.Format && theme.Deferred
+	if s.Flags.Format && !deferred {
+		value, a.References = s.formatValue(theme, value)
 	}
 
 	if s.Flags.Append {
-		options[option] = options[option] + value
+		a.Action = AppendAction
+		options[option] = previous + value
 	} else {
 		options[option] = value
 	}
+	a.Value = options[option]
+	theme.setLayer(s.Scope(), s.Target(), option, true)
+	theme.setDeferred(s.Scope(), s.Target(), option, deferred, s.Flags.Append)
+	theme.record(s.Scope(), s.Target(), option, a)
 
 	return nil
 }
 
-func (s *SetOptionStatement) formatValue(theme *Theme, value string) string {
-	return setOptionStatementFormatPattern.ReplaceAllStringFunc(
-		value,
-		func(match string) string {
-			name := setOptionStatementFormatPattern.ReplaceAllString(
-				match, `$1`,
-			)
-			return s.lookupOptionValue(theme, name)
-		},
-	)
+// formatValue expands value, returning the lookups made along the way.
+func (s *SetOptionStatement) formatValue(
+	theme *Theme,
+	value string,
+) (string, []*LookupTrace) {
+	refs := []*LookupTrace{}
+	e := theme.newFormatExpander(s.formatTargets())
+	e.onLookup = func(trace *LookupTrace) {
+		refs = append(refs, trace)
+	}
+
+	return e.expand(value), refs
 }
 
-func (s *SetOptionStatement) lookupOptionValue(theme *Theme, name string) string {
-	if val, ok := theme.WindowOptions[name]; ok {
-		return val
-	} else if val, ok := theme.GlobalWindowOptions[name]; ok {
-		return val
-	} else if val, ok := theme.SessionOptions[name]; ok {
-		return val
-	} else if val, ok := theme.GlobalSessionOptions[name]; ok {
-		return val
-	} else if val, ok := theme.ServerOptions[name]; ok {
-		return val
+// formatTargets returns the session and window a targeted statement's format
+// is expanded for, deriving the session from window targets like "dev:1".
+func (s *SetOptionStatement) formatTargets() (string, string) {
+	target := s.Target()
+
+	switch s.Scope() {
+	case SessionScope:
+		return target, ""
+	case WindowScope:
+		return strings.SplitN(target, ":", 2)[0], target
 	}
 
-	return ""
+	return "", ""
 }
@@ -1,9 +1,12 @@
 package theme
 
 import (
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSetOptionStatementInterfaceCompliance(t *testing.T) {
@@ -156,6 +159,29 @@ func TestSetOptionStatementParse(t *testing.T) {
 	}
 }
 
+func TestSetOptionStatementParseInvalidFlags(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = w, w
+
+	errs := []error{
+		(&SetOptionStatement{}).Parse("set -h @a x"),
+		(&SetOptionStatement{}).Parse("set -x @a x"),
+		(&SetOptionStatement{}).Parse("set -t"),
+	}
+
+	os.Stdout, os.Stderr = stdout, stderr
+	require.NoError(t, w.Close())
+	out, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+
+	for _, err := range errs {
+		assert.Error(t, err)
+	}
+	assert.Empty(t, string(out))
+}
+
 func TestSetOptionStatementExecute(t *testing.T) {
 	var tests = []struct {
 		body               string
@@ -169,6 +195,8 @@ func TestSetOptionStatementExecute(t *testing.T) {
 		sessionSetup       map[string]string
 		globalWindowSetup  map[string]string
 		windowSetup        map[string]string
+		targetSession      map[string]map[string]string
+		targetWindow       map[string]map[string]string
 	}{
 		//
 		// Session Options
@@ -369,6 +397,24 @@ func TestSetOptionStatementExecute(t *testing.T) {
 			},
 		},
 		//
+		// Targeted Options
+		//
+		{
+			body:          `set -t dev @name "John"`,
+			session:       map[string]string{},
+			targetSession: map[string]map[string]string{"dev": {"@name": "John"}},
+		},
+		{
+			body:         `set -wt dev:1 @name "John"`,
+			window:       map[string]string{},
+			targetWindow: map[string]map[string]string{"dev:1": {"@name": "John"}},
+		},
+		{
+			body:          `set -gt dev @name "John"`,
+			globalSession: map[string]string{"@name": "John"},
+			targetSession: map[string]map[string]string{},
+		},
+		//
 		// Formatting
 		//
 		{
@@ -443,6 +489,52 @@ func TestSetOptionStatementExecute(t *testing.T) {
 			server:        map[string]string{"@name": "John"},
 			globalSession: map[string]string{"@message": "Hi John"},
 		},
+		{
+			body:          `set -gF @message "Hi #{@name}"`,
+			serverSetup:   map[string]string{"@name": "Server"},
+			windowSetup:   map[string]string{"@name": "Window"},
+			server:        map[string]string{"@name": "Server"},
+			globalSession: map[string]string{"@message": "Hi Server"},
+		},
+		{
+			body:          `set -gF @message "Hi #{@name}"`,
+			sessionSetup:  map[string]string{"@name": "Session"},
+			windowSetup:   map[string]string{"@name": "Window"},
+			globalSession: map[string]string{"@message": "Hi Window"},
+		},
+		{
+			body:          `set -gF @message "#{status-interval}"`,
+			windowSetup:   map[string]string{"status-interval": "1"},
+			globalSession: map[string]string{"@message": "15"},
+		},
+		{
+			body:               `set -gF @message "#{status-interval}"`,
+			globalSessionSetup: map[string]string{"status-interval": "5"},
+			globalSession: map[string]string{
+				"status-interval": "5",
+				"@message":        "5",
+			},
+		},
+		{
+			body:          `set -gF @message "#{pane-border-style}"`,
+			sessionSetup:  map[string]string{"pane-border-style": "fg=red"},
+			globalSession: map[string]string{"@message": "default"},
+		},
+		//
+		// Built-in Option Scopes
+		//
+		{
+			body:         `set -g pane-border-style "fg=red"`,
+			globalWindow: map[string]string{"pane-border-style": "fg=red"},
+		},
+		{
+			body:          `set -gw status-style "fg=red"`,
+			globalSession: map[string]string{"status-style": "fg=red"},
+		},
+		{
+			body:   `set -g escape-time 0`,
+			server: map[string]string{"escape-time": "0"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -486,5 +578,102 @@ func TestSetOptionStatementExecute(t *testing.T) {
 		if tt.window != nil {
 			assert.Equal(t, tt.window, theme.WindowOptions)
 		}
+		if tt.targetSession != nil {
+			assert.Equal(t, tt.targetSession, theme.TargetSessionOptions)
+		}
+		if tt.targetWindow != nil {
+			assert.Equal(t, tt.targetWindow, theme.TargetWindowOptions)
+		}
+	}
+}
+
+func TestSetOptionStatementScope(t *testing.T) {
+	var tests = []struct {
+		body   string
+		scope  Scope
+		target string
+	}{
+		{body: `set @foo bar`, scope: SessionScope},
+		{body: `set -g @foo bar`, scope: GlobalSessionScope},
+		{body: `set -s @foo bar`, scope: ServerScope},
+		{body: `set -w @foo bar`, scope: WindowScope},
+		{body: `set -gw @foo bar`, scope: GlobalWindowScope},
+		{body: `set -t dev @foo bar`, scope: SessionScope, target: "dev"},
+		{body: `set -gt dev @foo bar`, scope: GlobalSessionScope},
+		{body: `set -g status-style default`, scope: GlobalSessionScope},
+		{body: `set -gw status-style default`, scope: GlobalSessionScope},
+		{body: `set -g pane-border-style default`, scope: GlobalWindowScope},
+		{body: `set pane-border-style default`, scope: WindowScope},
+		{body: `set-window-option -g mode-style default`, scope: GlobalWindowScope},
+		{body: `set -g escape-time 0`, scope: ServerScope},
+		{body: `set -w escape-time 0`, scope: ServerScope},
+		{body: `set -s status-interval 1`, scope: SessionScope},
+		{body: `set -g unknown-option 1`, scope: GlobalSessionScope},
+	}
+
+	for _, tt := range tests {
+		s := &SetOptionStatement{}
+
+		err := s.Parse(tt.body)
+		require.NoError(t, err)
+
+		assert.Equal(t, tt.scope, s.Scope(), tt.body)
+		assert.Equal(t, tt.target, s.Target(), tt.body)
+	}
+}
+
+func TestSetOptionFlagsString(t *testing.T) {
+	var tests = []struct {
+		flags  *SetOptionFlags
+		result string
+	}{
+		{nil, ""},
+		{&SetOptionFlags{}, ""},
+		{&SetOptionFlags{Target: "dev"}, ""},
+		{&SetOptionFlags{Global: true, Format: true}, "-gF"},
+		{
+			&SetOptionFlags{
+				Append: true, Format: true, Global: true, OnlyIfUnset: true,
+				Quiet: true, Server: true, Unset: true, Window: true,
+			},
+			"-agoqsuwF",
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.result, tt.flags.String())
+	}
+}
+
+func TestSetOptionStatementString(t *testing.T) {
+	var tests = []struct {
+		body   string
+		result string
+	}{
+		{`set -g @name "John Smith"`, `set -g @name "John Smith"`},
+		{`set-option -qgo @a b`, `set -goq @a b`},
+		{`set-window-option -g mode-style fg=red`, `set -gw mode-style fg=red`},
+		{`set -uw -t dev:1 @a`, `set -uw -t dev:1 @a`},
+		{`set -t "my session" @a b`, `set -t "my session" @a b`},
+		{`set -gF @m '#{@name}'`, `set -gF @m "#{@name}"`},
+		{`set -Fqgo @m x`, `set -goqF @m x`},
+		{`set -Fagw @m x`, `set -agwF @m x`},
+		{`set -g @a ""`, `set -g @a ""`},
+		{`set -goq @a ''`, `set -goq @a ""`},
+		{`set -gu @a`, `set -gu @a`},
+		{`set -g -- @a -1`, `set -g -- @a -1`},
+		{`set -t-1 @a x`, `set -t-1 @a x`},
+		{`set -g @a 'it'"'"'s $x'`, `set -g @a "it's \$x"`},
+	}
+
+	for _, tt := range tests {
+		s := &SetOptionStatement{}
+		require.NoError(t, s.Parse(tt.body))
+
+		assert.Equal(t, tt.result, s.String())
+
+		parsed := &SetOptionStatement{}
+		require.NoError(t, parsed.Parse(s.String()))
+		assert.Equal(t, s, parsed)
 	}
 }
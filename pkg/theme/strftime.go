@@ -0,0 +1,172 @@
+package theme
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+var strftimeComposites = map[byte]string{
+	'c': "%a %b %e %H:%M:%S %Y",
+	'D': "%m/%d/%y",
+	'F': "%Y-%m-%d",
+	'r': "%I:%M:%S %p",
+	'R': "%H:%M",
+	'T': "%H:%M:%S",
+	'x': "%m/%d/%y",
+	'X': "%H:%M:%S",
+}
+
+// Strftime formats t like the C library's strftime(3) does in the C locale,
+// including the GNU "-", "_", "0" and "^" flags. Unknown conversions are
+// left as they are.
+func Strftime(format string, t time.Time) string {
+	var b strings.Builder
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 >= len(format) {
+			b.WriteByte(format[i])
+			continue
+		}
+
+		start := i
+		i++
+
+		flag := byte(0)
+		if strings.IndexByte("-_0^", format[i]) >= 0 && i+1 < len(format) {
+			flag = format[i]
+			i++
+		}
+		if (format[i] == 'E' || format[i] == 'O') && i+1 < len(format) {
+			i++
+		}
+
+		value, ok := strftimeConversion(format[i], flag, t)
+		if !ok {
+			b.WriteString(format[start : i+1])
+			continue
+		}
+		b.WriteString(value)
+	}
+
+	return b.String()
+}
+
+func strftimeConversion(c byte, flag byte, t time.Time) (string, bool) {
+	if composite, ok := strftimeComposites[c]; ok {
+		return Strftime(composite, t), true
+	}
+
+	switch c {
+	case '%':
+		return "%", true
+	case 'n':
+		return "\n", true
+	case 't':
+		return "\t", true
+	case 'a':
+		return strftimeCase(t.Format("Mon"), flag), true
+	case 'A':
+		return strftimeCase(t.Format("Monday"), flag), true
+	case 'b', 'h':
+		return strftimeCase(t.Format("Jan"), flag), true
+	case 'B':
+		return strftimeCase(t.Format("January"), flag), true
+	case 'p':
+		return strftimeCase(t.Format("PM"), flag), true
+	case 'P':
+		return t.Format("pm"), true
+	case 'Z':
+		return strftimeCase(t.Format("MST"), flag), true
+	case 'z':
+		return t.Format("-0700"), true
+	case 's':
+		return strconv.FormatInt(t.Unix(), 10), true
+	case 'C':
+		return strftimePad(t.Year()/100, 2, '0', flag), true
+	case 'd':
+		return strftimePad(t.Day(), 2, '0', flag), true
+	case 'e':
+		return strftimePad(t.Day(), 2, ' ', flag), true
+	case 'H':
+		return strftimePad(t.Hour(), 2, '0', flag), true
+	case 'k':
+		return strftimePad(t.Hour(), 2, ' ', flag), true
+	case 'I':
+		return strftimePad(strftimeHour12(t), 2, '0', flag), true
+	case 'l':
+		return strftimePad(strftimeHour12(t), 2, ' ', flag), true
+	case 'j':
+		return strftimePad(t.YearDay(), 3, '0', flag), true
+	case 'm':
+		return strftimePad(int(t.Month()), 2, '0', flag), true
+	case 'M':
+		return strftimePad(t.Minute(), 2, '0', flag), true
+	case 'S':
+		return strftimePad(t.Second(), 2, '0', flag), true
+	case 'u':
+		wd := int(t.Weekday())
+		if wd == 0 {
+			wd = 7
+		}
+		return strconv.Itoa(wd), true
+	case 'w':
+		return strconv.Itoa(int(t.Weekday())), true
+	case 'U':
+		week := (t.YearDay() + 6 - int(t.Weekday())) / 7
+		return strftimePad(week, 2, '0', flag), true
+	case 'W':
+		week := (t.YearDay() + 6 - (int(t.Weekday())+6)%7) / 7
+		return strftimePad(week, 2, '0', flag), true
+	case 'V':
+		_, week := t.ISOWeek()
+		return strftimePad(week, 2, '0', flag), true
+	case 'G':
+		year, _ := t.ISOWeek()
+		return strconv.Itoa(year), true
+	case 'g':
+		year, _ := t.ISOWeek()
+		return strftimePad(year%100, 2, '0', flag), true
+	case 'y':
+		return strftimePad(t.Year()%100, 2, '0', flag), true
+	case 'Y':
+		return strconv.Itoa(t.Year()), true
+	}
+
+	return "", false
+}
+
+func strftimeHour12(t time.Time) int {
+	h := t.Hour() % 12
+	if h == 0 {
+		h = 12
+	}
+
+	return h
+}
+
+func strftimePad(n, width int, pad byte, flag byte) string {
+	switch flag {
+	case '-':
+		return strconv.Itoa(n)
+	case '_':
+		pad = ' '
+	case '0':
+		pad = '0'
+	}
+
+	s := strconv.Itoa(n)
+	if len(s) < width {
+		s = strings.Repeat(string(pad), width-len(s)) + s
+	}
+
+	return s
+}
+
+func strftimeCase(s string, flag byte) string {
+	if flag == '^' {
+		return strings.ToUpper(s)
+	}
+
+	return s
+}
@@ -0,0 +1,38 @@
+package theme
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrftime(t *testing.T) {
+	evening := time.Date(2019, 3, 7, 21, 5, 3, 0, time.UTC)
+	newYear := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		time   time.Time
+		format string
+		result string
+	}{
+		{evening, "%a %A %b %B %h", "Thu Thursday Mar March Mar"},
+		{evening, "%c", "Thu Mar  7 21:05:03 2019"},
+		{evening, "%C %d %D %e %F", "20 07 03/07/19  7 2019-03-07"},
+		{evening, "%g %G %H %I %j %k %l", "19 2019 21 09 066 21  9"},
+		{evening, "%m %M %p %P %r %R %s %S", "03 05 PM pm 09:05:03 PM 21:05 1551992703 03"},
+		{evening, "%T %u %U %V %w %W", "21:05:03 4 09 10 4 09"},
+		{evening, "%x %X %y %Y %z %Z %%", "03/07/19 21:05:03 19 2019 +0000 UTC %"},
+		{evening, "%-d %_m %^a %0e %-I", "7  3 THU 07 9"},
+		{evening, "%Ey %OH", "19 21"},
+		{newYear, "%U %W %V %G %g %j %I %l %p", "00 00 53 2020 20 001 12 12 AM"},
+		{evening, "%H:%M:%S %d-%b-%y", "21:05:03 07-Mar-19"},
+		{evening, "100%", "100%"},
+		{evening, "%Q %-Q", "%Q %-Q"},
+		{evening, "#{session_name} %H", "#{session_name} 21"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.result, Strftime(tt.format, tt.time), tt.format)
+	}
+}
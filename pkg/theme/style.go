@@ -0,0 +1,200 @@
+package theme
+
+import (
+	"strings"
+)
+
+type Attributes int
+
+const (
+	BrightAttr Attributes = 1 << iota
+	DimAttr
+	UnderscoreAttr
+	BlinkAttr
+	ReverseAttr
+	HiddenAttr
+	ItalicsAttr
+	StrikethroughAttr
+	DoubleUnderscoreAttr
+	CurlyUnderscoreAttr
+	DottedUnderscoreAttr
+	DashedUnderscoreAttr
+	OverlineAttr
+)
+
+var attributeNames = []struct {
+	attr  Attributes
+	names []string
+}{
+	{BrightAttr, []string{"bright", "bold"}},
+	{DimAttr, []string{"dim"}},
+	{UnderscoreAttr, []string{"underscore"}},
+	{BlinkAttr, []string{"blink"}},
+	{ReverseAttr, []string{"reverse"}},
+	{HiddenAttr, []string{"hidden"}},
+	{ItalicsAttr, []string{"italics"}},
+	{StrikethroughAttr, []string{"strikethrough"}},
+	{DoubleUnderscoreAttr, []string{"double-underscore"}},
+	{CurlyUnderscoreAttr, []string{"curly-underscore"}},
+	{DottedUnderscoreAttr, []string{"dotted-underscore"}},
+	{DashedUnderscoreAttr, []string{"dashed-underscore"}},
+	{OverlineAttr, []string{"overline"}},
+}
+
+// Names returns the canonical names of the set attributes, in the order
+// tmux lists them.
+func (s Attributes) Names() []string {
+	names := []string{}
+	for _, a := range attributeNames {
+		if s&a.attr != 0 {
+			names = append(names, a.names[0])
+		}
+	}
+
+	return names
+}
+
+var styleAligns = []string{"left", "centre", "right", "absolute-centre"}
+
+type Style struct {
+	Fg    Colour
+	Bg    Colour
+	Us    Colour
+	Fill  Colour
+	Attrs Attributes
+	Align string
+	List  string
+	Range string
+}
+
+func ParseStyle(value string) (Style, error) {
+	return Style{}.Update(value, Style{})
+}
+
+// Update applies the comma or space separated style value on top of s, the
+// same way tmux applies #[...] blocks. The "default" keyword resets colours
+// to those of base.
+func (s Style) Update(value string, base Style) (Style, error) {
+	tokens := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n'
+	})
+
+	for _, token := range tokens {
+		err := s.apply(strings.ToLower(token), base)
+		if err != nil {
+			return s, &InvalidStyleError{Value: value, Token: token}
+		}
+	}
+
+	return s, nil
+}
+
+func (s Style) String() string {
+	parts := []string{}
+
+	if s.List != "" {
+		parts = append(parts, "list="+s.List)
+	}
+	if s.Range != "" {
+		parts = append(parts, "range="+s.Range)
+	}
+	if s.Align != "" {
+		parts = append(parts, "align="+s.Align)
+	}
+	if s.Fill.IsSet() {
+		parts = append(parts, "fill="+s.Fill.String())
+	}
+	if s.Fg.IsSet() {
+		parts = append(parts, "fg="+s.Fg.String())
+	}
+	if s.Bg.IsSet() {
+		parts = append(parts, "bg="+s.Bg.String())
+	}
+	if s.Us.IsSet() {
+		parts = append(parts, "us="+s.Us.String())
+	}
+	parts = append(parts, s.Attrs.Names()...)
+
+	if len(parts) == 0 {
+		return "default"
+	}
+
+	return strings.Join(parts, ",")
+}
+
+func (s *Style) apply(token string, base Style) error {
+	name, value := token, ""
+	if i := strings.Index(token, "="); i >= 0 {
+		name, value = token[:i], token[i+1:]
+	}
+
+	switch name {
+	case "default":
+		s.Fg, s.Bg, s.Us, s.Attrs = base.Fg, base.Bg, base.Us, base.Attrs
+		return nil
+	case "push-default", "pop-default", "ignore", "noignore":
+		return nil
+	case "none":
+		s.Attrs = 0
+		return nil
+	case "nolist":
+		s.List = ""
+		return nil
+	case "norange":
+		s.Range = ""
+		return nil
+	case "noalign":
+		s.Align = ""
+		return nil
+	case "list":
+		s.List = value
+		return nil
+	case "range":
+		s.Range = value
+		return nil
+	case "align":
+		for _, a := range styleAligns {
+			if value == a {
+				s.Align = value
+				return nil
+			}
+		}
+		return &InvalidStyleError{Token: token}
+	case "fg", "bg", "us", "fill":
+		c, err := ParseColour(value)
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "fg":
+			s.Fg = c
+		case "bg":
+			s.Bg = c
+		case "us":
+			s.Us = c
+		case "fill":
+			s.Fill = c
+		}
+		return nil
+	}
+
+	off := strings.HasPrefix(name, "no")
+	if off {
+		name = name[2:]
+	}
+
+	for _, a := range attributeNames {
+		for _, n := range a.names {
+			if name == n {
+				if off {
+					s.Attrs &^= a.attr
+				} else {
+					s.Attrs |= a.attr
+				}
+				return nil
+			}
+		}
+	}
+
+	return &InvalidStyleError{Token: token}
+}
@@ -0,0 +1,118 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStyle(t *testing.T) {
+	var tests = []struct {
+		value string
+		style Style
+		str   string
+		error error
+	}{
+		{value: "", style: Style{}, str: "default"},
+		{value: "default", style: Style{}, str: "default"},
+		{
+			value: "bg=black,fg=cyan",
+			style: Style{
+				Fg: Colour{Type: ANSIColour, Index: 6},
+				Bg: Colour{Type: ANSIColour, Index: 0},
+			},
+			str: "fg=cyan,bg=black",
+		},
+		{
+			value: "fg=colour160 bold,italics",
+			style: Style{
+				Fg:    Colour{Type: PaletteColour, Index: 160},
+				Attrs: BrightAttr | ItalicsAttr,
+			},
+			str: "fg=colour160,bright,italics",
+		},
+		{
+			value: "bold,nobold,reverse",
+			style: Style{Attrs: ReverseAttr},
+			str:   "reverse",
+		},
+		{
+			value: "bold,none",
+			style: Style{},
+			str:   "default",
+		},
+		{
+			value: "align=centre,fill=#101010,us=red,dotted-underscore",
+			style: Style{
+				Align: "centre",
+				Fill:  Colour{Type: RGBColour, R: 16, G: 16, B: 16},
+				Us:    Colour{Type: ANSIColour, Index: 1},
+				Attrs: DottedUnderscoreAttr,
+			},
+			str: "align=centre,fill=#101010,us=red,dotted-underscore",
+		},
+		{
+			value: "fg=purplish",
+			error: &InvalidStyleError{Value: "fg=purplish", Token: "fg=purplish"},
+		},
+		{
+			value: "fg=red,wobbly",
+			error: &InvalidStyleError{Value: "fg=red,wobbly", Token: "wobbly"},
+		},
+		{
+			value: "align=middle",
+			error: &InvalidStyleError{Value: "align=middle", Token: "align=middle"},
+		},
+	}
+
+	for _, tt := range tests {
+		style, err := ParseStyle(tt.value)
+
+		if tt.error != nil {
+			assert.Error(t, err)
+			assert.Equal(t, tt.error, err)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, tt.style, style)
+			assert.Equal(t, tt.str, style.String())
+		}
+	}
+}
+
+func TestStyleUpdate(t *testing.T) {
+	base, _ := ParseStyle("bg=black,fg=cyan")
+	current, _ := ParseStyle("bg=red,fg=white,bold")
+
+	var tests = []struct {
+		value string
+		str   string
+	}{
+		{value: "fg=yellow", str: "fg=yellow,bg=red,bright"},
+		{value: "default", str: "fg=cyan,bg=black"},
+		{value: "nobold", str: "fg=white,bg=red"},
+		{value: "default,fg=green", str: "fg=green,bg=black"},
+	}
+
+	for _, tt := range tests {
+		style, err := current.Update(tt.value, base)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tt.str, style.String())
+	}
+}
+
+func TestAttributesNames(t *testing.T) {
+	var tests = []struct {
+		attrs Attributes
+		names []string
+	}{
+		{0, []string{}},
+		{BrightAttr, []string{"bright"}},
+		{ItalicsAttr | DimAttr, []string{"dim", "italics"}},
+		{OverlineAttr | UnderscoreAttr, []string{"underscore", "overline"}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.names, tt.attrs.Names())
+	}
+}
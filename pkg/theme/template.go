@@ -0,0 +1,123 @@
+package theme
+
+import "strings"
+
+// Template is an option value kept as a tmux format, to be expanded each
+// time it is evaluated rather than once when it was set.
+type Template struct {
+	Source string
+
+	// References holds the options the template looks up.
+	References []string
+
+	// Deferred is set for values of -F statements executed with
+	// Theme.Deferred, which tmux would have expanded when they were set.
+	Deferred bool
+}
+
+func ParseTemplate(source string) *Template {
+	return &Template{Source: source, References: FormatReferences(source)}
+}
+
+// IsStatic reports whether the template expands to itself, apart from
+// strftime sequences.
+func (s *Template) IsStatic() bool {
+	return !strings.Contains(s.Source, "#")
+}
+
+// Evaluate expands the template against the options and Context of t.
+func (s *Template) Evaluate(t *Theme) string {
+	return t.Expand(s.Source)
+}
+
+// Template looks up the named option like Lookup, returning its value as a
+// format template.
+func (s *Theme) Template(scope Scope, target, name string) (*Template, error) {
+	value, err := s.Lookup(scope, target, name)
+	if err != nil {
+		return nil, err
+	}
+
+	t := ParseTemplate(value.Value)
+	t.Deferred = s.deferred[optionKey{value.Scope, value.Target, name}]
+
+	return t, nil
+}
+
+// Evaluate returns a new theme with the statements executed against ctx,
+// expanding -F values for that state the way tmux expands them when they
+// are set. Values set without -F stay formats, use Expand or
+// Template.Evaluate with the result to expand them at draw time.
+//
+// This lets a theme executed once with Deferred be resolved for any number
+// of simulated sessions and windows.
+//
+// Options set directly in the option maps rather than by statements, as in
+// captured themes, are kept, with the statements executed on top of them.
+func (s *Theme) Evaluate(ctx *FormatContext) (*Theme, error) {
+	t := New()
+	t.Filename = s.Filename
+	t.Statements = s.Statements
+	t.Positions = s.Positions
+	t.Lines = s.Lines
+	t.linesFrom = s.linesFrom
+	t.linesTo = s.linesTo
+	t.Context = ctx
+	t.Tracer = s.Tracer
+	t.Layers = s.Layers
+	t.layerStarts = s.layerStarts
+	if len(s.Layers) > 0 {
+		t.layerOf = map[optionKey]int{}
+	}
+
+	s.copyUnrecordedOptions(t)
+
+	if err := t.Execute(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// copyUnrecordedOptions copies the options no statement has set into t.
+// Options set by statements are left out, as executing the statements again
+// recreates them, and copying them would apply -a and -o twice.
+func (s *Theme) copyUnrecordedOptions(t *Theme) {
+	add := func(scope Scope, target string, options map[string]string) {
+		for name, value := range options {
+			if _, ok := s.provenance[optionKey{scope, target, name}]; ok {
+				continue
+			}
+			t.writableOptions(scope, target)[name] = value
+		}
+	}
+
+	add(ServerScope, "", s.ServerOptions)
+	add(GlobalSessionScope, "", s.GlobalSessionOptions)
+	add(SessionScope, "", s.SessionOptions)
+	add(GlobalWindowScope, "", s.GlobalWindowOptions)
+	add(WindowScope, "", s.WindowOptions)
+	for target, options := range s.TargetSessionOptions {
+		add(SessionScope, target, options)
+	}
+	for target, options := range s.TargetWindowOptions {
+		add(WindowScope, target, options)
+	}
+}
+
+func (s *Theme) setDeferred(
+	scope Scope,
+	target, name string,
+	deferred, appended bool,
+) {
+	key := optionKey{scope, target, name}
+
+	if deferred || (appended && s.deferred[key]) {
+		if s.deferred == nil {
+			s.deferred = map[optionKey]bool{}
+		}
+		s.deferred[key] = true
+	} else {
+		delete(s.deferred, key)
+	}
+}
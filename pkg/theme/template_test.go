@@ -0,0 +1,170 @@
+package theme
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTemplate(t *testing.T) {
+	var tests = []struct {
+		source string
+		refs   []string
+		static bool
+	}{
+		{"plain %H:%M", []string{}, true},
+		{"#S", []string{}, false},
+		{"#{@fg} #{session_name}", []string{"@fg"}, false},
+	}
+
+	for _, tt := range tests {
+		tmpl := ParseTemplate(tt.source)
+
+		assert.Equal(t, tt.source, tmpl.Source)
+		assert.Equal(t, tt.refs, tmpl.References, tt.source)
+		assert.Equal(t, tt.static, tmpl.IsStatic(), tt.source)
+		assert.False(t, tmpl.Deferred)
+	}
+}
+
+func sessionContext(name string) *FormatContext {
+	return &FormatContext{Session: &FormatSession{Name: name}}
+}
+
+func TestThemeDeferred(t *testing.T) {
+	th := New()
+	require.NoError(t, th.Parse(strings.NewReader(`set -g @fg white
+set -gF status-left "[#{session_name}:#{@fg}]"
+set -g status-right "#{session_name}"
+set -gaF status-right " #{@fg}"
+set -gF @plain "#{@fg}"
+set -g @plain "fixed"
+`)))
+	th.Deferred = true
+	require.NoError(t, th.Execute())
+
+	assert.Equal(t, "[#{session_name}:#{@fg}]",
+		th.GlobalSessionOptions["status-left"])
+	assert.Equal(t, "#{session_name} #{@fg}",
+		th.GlobalSessionOptions["status-right"])
+
+	var tests = []struct {
+		name     string
+		source   string
+		deferred bool
+	}{
+		{"status-left", "[#{session_name}:#{@fg}]", true},
+		{"status-right", "#{session_name} #{@fg}", true},
+		{"@plain", "fixed", false},
+	}
+
+	for _, tt := range tests {
+		tmpl, err := th.Template(SessionScope, "main", tt.name)
+		require.NoError(t, err, tt.name)
+
+		assert.Equal(t, tt.source, tmpl.Source, tt.name)
+		assert.Equal(t, tt.deferred, tmpl.Deferred, tt.name)
+	}
+
+	tmpl, err := th.Template(SessionScope, "", "status-left")
+	require.NoError(t, err)
+	assert.Equal(t, "[dev:white]",
+		tmpl.Evaluate(th.WithContext(sessionContext("dev"))))
+
+	_, err = th.Template(SessionScope, "", "@missing")
+	assert.Error(t, err)
+}
+
+func TestThemeEvaluate(t *testing.T) {
+	th := New()
+	require.NoError(t, th.Parse(strings.NewReader(`set -g @fg white
+set -gF status-left "[#{session_name}]"
+set -g status-right "#{session_name}"
+set -g @fg red
+`)))
+	th.Deferred = true
+	require.NoError(t, th.Execute())
+
+	for _, name := range []string{"main", "dev"} {
+		ctx := sessionContext(name)
+
+		evaluated, err := th.Evaluate(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, "["+name+"]",
+			evaluated.GlobalSessionOptions["status-left"])
+		assert.Equal(t, "#{session_name}",
+			evaluated.GlobalSessionOptions["status-right"])
+		tmpl, err := evaluated.Template(SessionScope, "", "status-right")
+		require.NoError(t, err)
+		assert.Equal(t, name, tmpl.Evaluate(evaluated))
+		assert.False(t, evaluated.Deferred)
+		assert.Equal(t, ctx, evaluated.Context)
+	}
+
+	assert.Equal(t, "[#{session_name}]", th.GlobalSessionOptions["status-left"])
+}
+
+func TestThemeEvaluateOptions(t *testing.T) {
+	th := New()
+	th.GlobalSessionOptions["status-left"] = "#S"
+	th.GlobalWindowOptions["mode-style"] = "bg=red"
+	th.TargetSessionOptions["main"] = map[string]string{"@project": "api"}
+
+	evaluated, err := th.Evaluate(sessionContext("main"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "#S", evaluated.GlobalSessionOptions["status-left"])
+	assert.Equal(t, "bg=red", evaluated.GlobalWindowOptions["mode-style"])
+	assert.Equal(t, map[string]map[string]string{"main": {"@project": "api"}},
+		evaluated.TargetSessionOptions)
+
+	th = New()
+	th.Filename = "basic.tmuxtheme"
+	require.NoError(t, th.Parse(strings.NewReader(`set -g status-left "#S"
+set -ga status-left " "
+set -go @accent blue
+`)))
+	require.NoError(t, th.Execute())
+	th.GlobalSessionOptions["@captured"] = "yes"
+
+	evaluated, err = th.Evaluate(sessionContext("main"))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"status-left": "#S ",
+		"@accent":     "blue",
+		"@captured":   "yes",
+	}, evaluated.GlobalSessionOptions)
+	assert.Equal(t, th.Lines, evaluated.Lines)
+	assert.Equal(t, []string{`set -ga status-left " "`}, evaluated.Text(1))
+}
+
+func TestThemeEvaluateLayers(t *testing.T) {
+	base := New()
+	base.Filename = "base.tmuxtheme"
+	require.NoError(t, base.Parse(strings.NewReader(`set -g @fg white`)))
+
+	overlay := New()
+	overlay.Filename = "accent.tmuxtheme"
+	require.NoError(t, overlay.Parse(strings.NewReader(`set -gF status-left "#{session_name}"
+`)))
+
+	th, err := Compose(base, overlay)
+	require.NoError(t, err)
+
+	evaluated, err := th.Evaluate(sessionContext("dev"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "dev", evaluated.GlobalSessionOptions["status-left"])
+	assert.Equal(t, th.Layers, evaluated.Layers)
+
+	layer, ok := evaluated.LayerOf(GlobalSessionScope, "", "status-left")
+	assert.True(t, ok)
+	assert.Equal(t, 1, layer)
+	layer, ok = evaluated.LayerOf(GlobalSessionScope, "", "@fg")
+	assert.True(t, ok)
+	assert.Equal(t, 0, layer)
+}
@@ -2,6 +2,7 @@ package theme
 
 import (
 	"bufio"
+	"fmt"
 	"io"
 	"os"
 )
@@ -12,7 +13,49 @@ type Theme struct {
 	SessionOptions       map[string]string
 	GlobalWindowOptions  map[string]string
 	WindowOptions        map[string]string
+	TargetSessionOptions map[string]map[string]string
+	TargetWindowOptions  map[string]map[string]string
 	Statements           []Statement
+
+	// Filename is the file the theme was loaded from, if any.
+	Filename string
+
+	// Positions holds the position of each statement in Statements.
+	Positions []Position
+
+	// Lines holds the source lines read by the last call to Parse.
+	Lines []string
+
+	// Context is the simulated tmux state used to expand format variables
+	// like #{session_name} in -F statements and Expand.
+	Context *FormatContext
+
+	// Tracer, when set, is called with the lookup trace of every #{name}
+	// reference expanded while executing -F statements.
+	Tracer func(*LookupTrace)
+
+	// Layers names the themes composed with Overlay, in the order they were
+	// applied.
+	Layers []string
+
+	// Deferred, when set, makes Execute keep -F values unexpanded as
+	// format templates, to be expanded later by Evaluate.
+	Deferred bool
+
+	// linesFrom and linesTo are the indexes of the first statement read by
+	// the last call to Parse, and of the one after the last. Lines holds the
+	// source of these statements only.
+	linesFrom int
+	linesTo   int
+
+	layer       int
+	layerOf     map[optionKey]int
+	layerStarts []int
+	deferred    map[optionKey]bool
+
+	// current is the index plus one of the statement being executed.
+	current    int
+	provenance map[optionKey]*Provenance
 }
 
 func New() *Theme {
@@ -22,15 +65,24 @@ func New() *Theme {
 		SessionOptions:       map[string]string{},
 		GlobalWindowOptions:  map[string]string{},
 		WindowOptions:        map[string]string{},
+		TargetSessionOptions: map[string]map[string]string{},
+		TargetWindowOptions:  map[string]map[string]string{},
 		Statements:           []Statement{},
+		Positions:            []Position{},
 	}
 }
 
 func (s *Theme) Parse(r io.Reader) error {
 	scanner := bufio.NewScanner(r)
 	line := []byte{}
+	lineNo, start := 0, 1
+	s.Lines = nil
+	s.linesFrom = len(s.Statements)
+	s.linesTo = s.linesFrom
 
 	for scanner.Scan() {
+		lineNo++
+		s.Lines = append(s.Lines, scanner.Text())
 		line = append(line, scanner.Bytes()...)
 		if len(line) > 0 && line[len(line)-1] == byte('\\') {
 			line = line[:len(line)-1]
@@ -41,16 +93,58 @@ func (s *Theme) Parse(r io.Reader) error {
 			}
 
 			s.Statements = append(s.Statements, statement)
+			s.Positions = append(s.Positions, Position{
+				Filename: s.Filename,
+				Line:     start,
+				EndLine:  lineNo,
+			})
+			s.linesTo = len(s.Statements)
 			line = []byte{}
+			start = lineNo + 1
 		}
 	}
 
-	return scanner.Err()
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	// Like tmux, reject a statement continued past the end of the input
+	// rather than dropping it.
+	if start <= lineNo {
+		return &UnfinishedContinuationError{Position: Position{
+			Filename: s.Filename,
+			Line:     start,
+			EndLine:  lineNo,
+		}}
+	}
+
+	return nil
+}
+
+// Write writes the theme's statements as source, one per line, in the
+// normalised form given by each statement's String method. Continued
+// statements are joined onto a single line.
+func (s *Theme) Write(w io.Writer) error {
+	for _, statement := range s.Statements {
+		st, ok := statement.(fmt.Stringer)
+		if !ok {
+			return &UnsupportedStatementError{
+				Body: fmt.Sprintf("%T", statement),
+			}
+		}
+
+		_, err := fmt.Fprintln(w, st.String())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (s *Theme) Execute() error {
-	for _, st := range s.Statements {
-		err := st.Execute(s)
+	for i := range s.Statements {
+		err := s.executeStatement(i)
 		if err != nil {
 			return err
 		}
@@ -58,6 +152,14 @@ func (s *Theme) Execute() error {
 	return nil
 }
 
+func (s *Theme) executeStatement(i int) error {
+	s.current = i + 1
+	s.layer = s.layerAt(i)
+	defer func() { s.current, s.layer = 0, 0 }()
+
+	return s.Statements[i].Execute(s)
+}
+
 func (s *Theme) Load(filename string) error {
 	r, err := os.Open(filename)
 	if err != nil {
@@ -65,5 +167,208 @@ func (s *Theme) Load(filename string) error {
 	}
 	defer r.Close()
 
+	s.Filename = filename
+
 	return s.Parse(r)
 }
+
+// Position returns the position of the statement at index i in Statements.
+// Only the filename is set when the line is unknown.
+func (s *Theme) Position(i int) Position {
+	if i < 0 || i >= len(s.Positions) {
+		return Position{Filename: s.Filename}
+	}
+
+	return s.Positions[i]
+}
+
+// Text returns the source lines of the statement at index i, or nil when
+// they are unknown, as for statements added by Overlay, even from the same
+// file, or read by an earlier call to Parse.
+func (s *Theme) Text(i int) []string {
+	pos := s.Position(i)
+	if !pos.IsValid() || pos.Filename != s.Filename ||
+		i < s.linesFrom || i >= s.linesTo || pos.EndLine > len(s.Lines) {
+		return nil
+	}
+
+	return s.Lines[pos.Line-1 : pos.EndLine]
+}
+
+// Options returns the option map for the given scope and target. Targets are
+// only meaningful for the session and window scopes, an empty target refers
+// to the untargeted maps. Nil is returned for targets which have no options.
+func (s *Theme) Options(scope Scope, target string) map[string]string {
+	switch scope {
+	case ServerScope:
+		return s.ServerOptions
+	case GlobalSessionScope:
+		return s.GlobalSessionOptions
+	case GlobalWindowScope:
+		return s.GlobalWindowOptions
+	case SessionScope:
+		if target != "" {
+			return s.TargetSessionOptions[target]
+		}
+		return s.SessionOptions
+	case WindowScope:
+		if target != "" {
+			return s.TargetWindowOptions[target]
+		}
+		return s.WindowOptions
+	}
+
+	return nil
+}
+
+func (s *Theme) writableOptions(scope Scope, target string) map[string]string {
+	if options := s.Options(scope, target); options != nil {
+		return options
+	}
+
+	options := map[string]string{}
+	switch scope {
+	case SessionScope:
+		s.TargetSessionOptions[target] = options
+	case WindowScope:
+		s.TargetWindowOptions[target] = options
+	}
+
+	return options
+}
+
+// Lookup resolves the named option in scope, following tmux's inheritance
+// from session and window options to their global counterparts, and finally
+// falling back to tmux's built-in default value.
+func (s *Theme) Lookup(scope Scope, target, name string) (*OptionValue, error) {
+	return s.lookup(scope, target, name, nil)
+}
+
+// TraceLookup resolves name the way tmux resolves #{name} within a format
+// expanded for the given session and window targets, recording each place
+// that was checked.
+//
+// Built-in options are only looked up within their declared scope. User
+// options are looked up the same way tmux's format_find() does: server
+// options first, then window and global window options, and finally session
+// and global session options.
+func (s *Theme) TraceLookup(name, session, window string) *LookupTrace {
+	trace := &LookupTrace{Name: name, Session: session, Window: window}
+
+	if def, ok := LookupOptionDefinition(name); ok {
+		trace.BuiltIn = true
+		target := formatTarget(def.Scope, session, window)
+		trace.Result, _ = s.lookup(def.Scope, target, name, trace)
+
+		return trace
+	}
+
+	for _, scope := range []Scope{ServerScope, WindowScope, SessionScope} {
+		target := formatTarget(scope, session, window)
+		value, err := s.lookup(scope, target, name, trace)
+		if err == nil {
+			trace.Result = value
+			break
+		}
+	}
+
+	return trace
+}
+
+func formatTarget(scope Scope, session, window string) string {
+	switch scope {
+	case SessionScope:
+		return session
+	case WindowScope:
+		return window
+	}
+
+	return ""
+}
+
+func (s *Theme) lookup(
+	scope Scope,
+	target, name string,
+	trace *LookupTrace,
+) (*OptionValue, error) {
+	current, t := scope, target
+	for {
+		value, ok := s.Options(current, t)[name]
+		trace.addStep(&LookupStep{
+			Scope: current, Target: t, Found: ok, Value: value,
+		})
+		if ok {
+			return &OptionValue{
+				Name:   name,
+				Value:  value,
+				Scope:  current,
+				Target: t,
+			}, nil
+		}
+
+		parent, ok := current.Parent()
+		if !ok {
+			break
+		}
+		current, t = parent, ""
+	}
+
+	if def, ok := LookupOptionDefinition(name); ok {
+		trace.addStep(&LookupStep{
+			Scope: current, Default: true, Found: true, Value: def.Default,
+		})
+		return &OptionValue{
+			Name:    name,
+			Value:   def.Default,
+			Scope:   current,
+			Default: true,
+		}, nil
+	}
+
+	return nil, &OptionNotFoundError{Scope: scope, Target: target, Name: name}
+}
+
+func (s *Theme) GetString(scope Scope, target, name string) (string, error) {
+	v, err := s.Lookup(scope, target, name)
+	if err != nil {
+		return "", err
+	}
+
+	return v.String(), nil
+}
+
+func (s *Theme) GetInt(scope Scope, target, name string) (int, error) {
+	v, err := s.Lookup(scope, target, name)
+	if err != nil {
+		return 0, err
+	}
+
+	return v.Int()
+}
+
+func (s *Theme) GetBool(scope Scope, target, name string) (bool, error) {
+	v, err := s.Lookup(scope, target, name)
+	if err != nil {
+		return false, err
+	}
+
+	return v.Bool()
+}
+
+func (s *Theme) GetColour(scope Scope, target, name string) (Colour, error) {
+	v, err := s.Lookup(scope, target, name)
+	if err != nil {
+		return Colour{}, err
+	}
+
+	return v.Colour()
+}
+
+func (s *Theme) GetStyle(scope Scope, target, name string) (Style, error) {
+	v, err := s.Lookup(scope, target, name)
+	if err != nil {
+		return Style{}, err
+	}
+
+	return v.Style()
+}
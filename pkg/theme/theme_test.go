@@ -1,6 +1,11 @@
 package theme
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -91,6 +96,143 @@ set -gF @message \
 	}
 }
 
+func TestThemeParsePositions(t *testing.T) {
+	theme := New()
+	theme.Filename = "basic.tmuxtheme"
+	r := strings.NewReader(`set -g @name "John Smith"
+
+# This is the message
+set -gF @message \
+  "Hi #{@name}"
+set -g @done yes
+`)
+
+	err := theme.Parse(r)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Position{
+		{Filename: "basic.tmuxtheme", Line: 1, EndLine: 1},
+		{Filename: "basic.tmuxtheme", Line: 2, EndLine: 2},
+		{Filename: "basic.tmuxtheme", Line: 3, EndLine: 3},
+		{Filename: "basic.tmuxtheme", Line: 4, EndLine: 5},
+		{Filename: "basic.tmuxtheme", Line: 6, EndLine: 6},
+	}, theme.Positions)
+	assert.Equal(t, "basic.tmuxtheme:4-5", theme.Position(3).String())
+	assert.Equal(t, Position{Filename: "basic.tmuxtheme"}, theme.Position(5))
+}
+
+func TestThemeParseUnfinishedContinuation(t *testing.T) {
+	theme := New()
+	theme.Filename = "basic.tmuxtheme"
+	r := strings.NewReader("set -g @a x\nset -g @b \\\n  y \\\n")
+
+	err := theme.Parse(r)
+
+	assert.Equal(t, &UnfinishedContinuationError{Position: Position{
+		Filename: "basic.tmuxtheme", Line: 2, EndLine: 3,
+	}}, err)
+	assert.Len(t, theme.Statements, 1)
+}
+
+func TestThemeWrite(t *testing.T) {
+	theme := New()
+	err := theme.Parse(strings.NewReader(`set -g @name "John Smith"
+
+#This is the message
+set-option -gF @message \
+  "Hi #{@name}"
+set-window-option -g -t dev:1 mode-style 'fg=red'
+`))
+	require.NoError(t, err)
+
+	var buf strings.Builder
+	require.NoError(t, theme.Write(&buf))
+
+	assert.Equal(t, `set -g @name "John Smith"
+
+# This is the message
+set -gF @message "Hi #{@name}"
+set -gw -t dev:1 mode-style fg=red
+`, buf.String())
+
+	theme.Statements = append(theme.Statements, &failingStatement{})
+	assert.Error(t, theme.Write(&buf))
+}
+
+func TestThemeWriteFile(t *testing.T) {
+	theme := New()
+	require.NoError(t, theme.Load("theme_test.tmuxtheme"))
+
+	var buf strings.Builder
+	require.NoError(t, theme.Write(&buf))
+
+	assert.Contains(t, buf.String(),
+		"\nset -goq @theme-window-status-separator \"\"\n")
+
+	written := New()
+	require.NoError(t, written.Parse(strings.NewReader(buf.String())))
+	assert.Equal(t, theme.Statements, written.Statements)
+
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+
+	dir, err := ioutil.TempDir("", "tmuxtheme")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "written.tmuxtheme")
+	require.NoError(t, ioutil.WriteFile(filename, []byte(buf.String()), 0644))
+
+	socket := fmt.Sprintf("go-tmuxtheme-write-%d", os.Getpid())
+	tmux := func(args ...string) (string, error) {
+		out, err := exec.Command(
+			"tmux", append([]string{"-L", socket}, args...)...,
+		).CombinedOutput()
+
+		return string(out), err
+	}
+
+	out, err := tmux("-f", "/dev/null", "new-session", "-d", "-x", "80", "-y", "24")
+	require.NoError(t, err, out)
+	defer func() { _, _ = tmux("kill-server") }()
+
+	out, err = tmux("source-file", filename)
+	assert.NoError(t, err, out)
+}
+
+func TestThemeText(t *testing.T) {
+	theme := New()
+	theme.Filename = "basic.tmuxtheme"
+	err := theme.Parse(strings.NewReader(`set -g @name "John Smith"
+set -gF @message \
+  "Hi #{@name}"
+`))
+	require.NoError(t, err)
+
+	assert.Len(t, theme.Lines, 3)
+	assert.Equal(t, []string{`set -g @name "John Smith"`}, theme.Text(0))
+	assert.Equal(t, []string{`set -gF @message \`, `  "Hi #{@name}"`},
+		theme.Text(1))
+	assert.Nil(t, theme.Text(2))
+
+	theme.Positions[0].Filename = "other.tmuxtheme"
+	assert.Nil(t, theme.Text(0))
+}
+
+func TestThemeTextParsedTwice(t *testing.T) {
+	theme := New()
+	theme.Filename = "basic.tmuxtheme"
+	require.NoError(t, theme.Parse(strings.NewReader("set -g @a 1\n")))
+	require.NoError(t, theme.Parse(strings.NewReader("# Two\nset -g @b 2\n")))
+
+	assert.Len(t, theme.Statements, 3)
+	assert.Equal(t, []string{"# Two", "set -g @b 2"}, theme.Lines)
+	assert.Nil(t, theme.Text(0))
+	assert.Equal(t, []string{"# Two"}, theme.Text(1))
+	assert.Equal(t, []string{"set -g @b 2"}, theme.Text(2))
+}
+
 func TestThemeExecute(t *testing.T) {
 	var tests = []struct {
 		body          string
@@ -479,6 +621,8 @@ func TestThemeLoadFileAndExecute(t *testing.T) {
 
 	err := theme.Load("theme_test.tmuxtheme")
 	require.NoError(t, err)
+	assert.Equal(t, "theme_test.tmuxtheme", theme.Filename)
+	assert.Equal(t, len(theme.Statements), len(theme.Positions))
 
 	err = theme.Execute()
 	require.NoError(t, err)
@@ -528,15 +672,10 @@ func TestThemeLoadFileAndExecute(t *testing.T) {
 			"@themepack-status-right-area-right-format":  "%d-%b-%y",
 			"@themepack-window-status-current-format":    "#I:#W#F",
 			"@themepack-window-status-format":            "#I:#W#F",
-			"clock-mode-colour":                          "red",
-			"clock-mode-style":                           "24",
 			"display-panes-active-colour":                "default",
 			"display-panes-colour":                       "default",
 			"message-command-style":                      "bg=default,fg=default",
 			"message-style":                              "bg=default,fg=default",
-			"mode-style":                                 "bg=red,fg=default",
-			"pane-active-border-style":                   "bg=default,fg=green",
-			"pane-border-style":                          "bg=default,fg=default",
 			"status-interval":                            "1",
 			"status-justify":                             "centre",
 			"status-left":                                "#S #[fg=white]» #[fg=yellow]#I #[fg=cyan]#P",
@@ -546,15 +685,231 @@ func TestThemeLoadFileAndExecute(t *testing.T) {
 			"status-right-length":                        "40",
 			"status-right-style":                         "bg=black,fg=cyan",
 			"status-style":                               "bg=black,fg=cyan",
-			"window-status-activity-style":               "bg=black,fg=yellow",
-			"window-status-current-format":               " #I:#W#F ",
-			"window-status-current-style":                "bg=red,fg=black",
-			"window-status-format":                       " #I:#W#F ",
-			"window-status-separator":                    "",
 		},
 		theme.GlobalSessionOptions,
 	)
 	assert.Equal(t, theme.SessionOptions, map[string]string{})
-	assert.Equal(t, theme.GlobalWindowOptions, map[string]string{})
+	assert.Equal(
+		t,
+		map[string]string{
+			"clock-mode-colour":            "red",
+			"clock-mode-style":             "24",
+			"mode-style":                   "bg=red,fg=default",
+			"pane-active-border-style":     "bg=default,fg=green",
+			"pane-border-style":            "bg=default,fg=default",
+			"window-status-activity-style": "bg=black,fg=yellow",
+			"window-status-current-format": " #I:#W#F ",
+			"window-status-current-style":  "bg=red,fg=black",
+			"window-status-format":         " #I:#W#F ",
+			"window-status-separator":      "",
+		},
+		theme.GlobalWindowOptions,
+	)
 	assert.Equal(t, theme.WindowOptions, map[string]string{})
 }
+
+func TestThemeLookup(t *testing.T) {
+	theme := New()
+	err := theme.Parse(strings.NewReader(strings.TrimLeft(`
+set -s @server-opt "server"
+set -g @name "global"
+set @name "session"
+set -t dev @name "dev"
+set -g @empty ""
+set -gw @wname "global window"
+set -t dev:1 -w @wname "dev window"
+set -g status-interval 5
+set -g status-style "bg=black,fg=cyan"
+`, "\n")))
+	require.NoError(t, err)
+	require.NoError(t, theme.Execute())
+
+	var tests = []struct {
+		scope  Scope
+		target string
+		name   string
+		value  *OptionValue
+		error  error
+	}{
+		{
+			scope: ServerScope, name: "@server-opt",
+			value: &OptionValue{
+				Name: "@server-opt", Value: "server", Scope: ServerScope,
+			},
+		},
+		{
+			scope: GlobalSessionScope, name: "@name",
+			value: &OptionValue{
+				Name: "@name", Value: "global", Scope: GlobalSessionScope,
+			},
+		},
+		{
+			scope: SessionScope, name: "@name",
+			value: &OptionValue{
+				Name: "@name", Value: "session", Scope: SessionScope,
+			},
+		},
+		{
+			scope: SessionScope, target: "dev", name: "@name",
+			value: &OptionValue{
+				Name: "@name", Value: "dev", Scope: SessionScope, Target: "dev",
+			},
+		},
+		{
+			scope: SessionScope, target: "other", name: "@name",
+			value: &OptionValue{
+				Name: "@name", Value: "global", Scope: GlobalSessionScope,
+			},
+		},
+		{
+			scope: SessionScope, name: "@empty",
+			value: &OptionValue{
+				Name: "@empty", Value: "", Scope: GlobalSessionScope,
+			},
+		},
+		{
+			scope: WindowScope, target: "dev:1", name: "@wname",
+			value: &OptionValue{
+				Name: "@wname", Value: "dev window", Scope: WindowScope,
+				Target: "dev:1",
+			},
+		},
+		{
+			scope: WindowScope, name: "@wname",
+			value: &OptionValue{
+				Name: "@wname", Value: "global window",
+				Scope: GlobalWindowScope,
+			},
+		},
+		{
+			scope: SessionScope, name: "status-left-length",
+			value: &OptionValue{
+				Name: "status-left-length", Value: "10",
+				Scope: GlobalSessionScope, Default: true,
+			},
+		},
+		{
+			scope: SessionScope, name: "@missing",
+			error: &OptionNotFoundError{Scope: SessionScope, Name: "@missing"},
+		},
+		{
+			scope: ServerScope, name: "@name",
+			error: &OptionNotFoundError{Scope: ServerScope, Name: "@name"},
+		},
+	}
+
+	for _, tt := range tests {
+		value, err := theme.Lookup(tt.scope, tt.target, tt.name)
+
+		if tt.error != nil {
+			assert.Error(t, err)
+			assert.Equal(t, tt.error, err)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, tt.value, value)
+		}
+	}
+}
+
+func TestThemeTypedGetters(t *testing.T) {
+	theme := New()
+	err := theme.Parse(strings.NewReader(strings.TrimLeft(`
+set -g status-interval 5
+set -g status-style "bg=black,fg=cyan"
+set -g mouse on
+set -gw clock-mode-colour colour160
+`, "\n")))
+	require.NoError(t, err)
+	require.NoError(t, theme.Execute())
+
+	s, err := theme.GetString(SessionScope, "", "status-style")
+	assert.NoError(t, err)
+	assert.Equal(t, "bg=black,fg=cyan", s)
+
+	n, err := theme.GetInt(SessionScope, "", "status-interval")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	n, err = theme.GetInt(SessionScope, "", "status-right-length")
+	assert.NoError(t, err)
+	assert.Equal(t, 40, n)
+
+	b, err := theme.GetBool(SessionScope, "", "mouse")
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	c, err := theme.GetColour(WindowScope, "", "clock-mode-colour")
+	assert.NoError(t, err)
+	assert.Equal(t, Colour{Type: PaletteColour, Index: 160}, c)
+
+	st, err := theme.GetStyle(SessionScope, "", "status-style")
+	assert.NoError(t, err)
+	assert.Equal(t, Colour{Type: ANSIColour, Index: 0}, st.Bg)
+	assert.Equal(t, Colour{Type: ANSIColour, Index: 6}, st.Fg)
+
+	_, err = theme.GetInt(SessionScope, "", "status-style")
+	assert.Equal(t, &InvalidOptionValueError{
+		Name: "status-style", Value: "bg=black,fg=cyan", Type: "number",
+	}, err)
+
+	_, err = theme.GetStyle(SessionScope, "", "@nope")
+	assert.Equal(t, &OptionNotFoundError{Scope: SessionScope, Name: "@nope"}, err)
+}
+
+func TestThemeTraceLookup(t *testing.T) {
+	theme := New()
+	theme.GlobalWindowOptions["@name"] = "global window"
+	theme.TargetSessionOptions["dev"] = map[string]string{"@name": "dev"}
+	theme.GlobalSessionOptions["status-interval"] = "5"
+
+	trace := theme.TraceLookup("@name", "dev", "dev:1")
+	assert.False(t, trace.BuiltIn)
+	assert.Equal(t, "global window", trace.Value())
+	assert.Equal(
+		t,
+		[]*LookupStep{
+			{Scope: ServerScope},
+			{Scope: WindowScope, Target: "dev:1"},
+			{Scope: GlobalWindowScope, Found: true, Value: "global window"},
+		},
+		trace.Steps,
+	)
+
+	trace = theme.TraceLookup("status-interval", "dev", "dev:1")
+	assert.True(t, trace.BuiltIn)
+	assert.Equal(t, "5", trace.Value())
+	assert.Equal(
+		t,
+		[]*LookupStep{
+			{Scope: SessionScope, Target: "dev"},
+			{Scope: GlobalSessionScope, Found: true, Value: "5"},
+		},
+		trace.Steps,
+	)
+
+	trace = theme.TraceLookup("@missing", "", "")
+	assert.Nil(t, trace.Result)
+	assert.Equal(t, "", trace.Value())
+	assert.Len(t, trace.Steps, 5)
+}
+
+func TestThemeTracer(t *testing.T) {
+	theme := New()
+	traces := []*LookupTrace{}
+	theme.Tracer = func(trace *LookupTrace) {
+		traces = append(traces, trace)
+	}
+
+	err := theme.Parse(strings.NewReader(strings.TrimLeft(`
+set -g @name "John"
+set -gF @message "Hi #{@name}#{@missing}"
+`, "\n")))
+	require.NoError(t, err)
+	require.NoError(t, theme.Execute())
+
+	require.Len(t, traces, 2)
+	assert.Equal(t, "@name", traces[0].Name)
+	assert.Equal(t, "John", traces[0].Value())
+	assert.Equal(t, "@missing", traces[1].Name)
+	assert.Nil(t, traces[1].Result)
+}
@@ -0,0 +1,53 @@
+package theme
+
+type TokenKind int
+
+const (
+	CommandToken TokenKind = iota
+	FlagToken
+	OptionToken
+	UserOptionToken
+	StringToken
+	TextToken
+	FormatToken
+	StyleToken
+	ColourToken
+	CommentToken
+	ContinuationToken
+)
+
+var tokenKindNames = map[TokenKind]string{
+	CommandToken:      "command",
+	FlagToken:         "flag",
+	OptionToken:       "option",
+	UserOptionToken:   "user-option",
+	StringToken:       "string",
+	TextToken:         "text",
+	FormatToken:       "format",
+	StyleToken:        "style",
+	ColourToken:       "colour",
+	CommentToken:      "comment",
+	ContinuationToken: "continuation",
+}
+
+func (s TokenKind) String() string {
+	if name, ok := tokenKindNames[s]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// MarshalText writes token kinds by name in JSON output.
+func (s TokenKind) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Token is a classified span of theme source, from byte offset Start up to
+// End.
+type Token struct {
+	Kind  TokenKind `json:"kind"`
+	Start int       `json:"start"`
+	End   int       `json:"end"`
+	Text  string    `json:"text"`
+}
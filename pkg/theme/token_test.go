@@ -0,0 +1,43 @@
+package theme
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenKindString(t *testing.T) {
+	tests := []struct {
+		kind TokenKind
+		want string
+	}{
+		{CommandToken, "command"},
+		{FlagToken, "flag"},
+		{OptionToken, "option"},
+		{UserOptionToken, "user-option"},
+		{StringToken, "string"},
+		{TextToken, "text"},
+		{FormatToken, "format"},
+		{StyleToken, "style"},
+		{ColourToken, "colour"},
+		{CommentToken, "comment"},
+		{ContinuationToken, "continuation"},
+		{TokenKind(99), "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.kind.String())
+		})
+	}
+}
+
+func TestTokenMarshalJSON(t *testing.T) {
+	got, err := json.Marshal(&Token{
+		Kind: UserOptionToken, Start: 7, End: 9, Text: "@a",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t,
+		`{"kind":"user-option","start":7,"end":9,"text":"@a"}`, string(got))
+}
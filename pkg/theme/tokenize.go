@@ -0,0 +1,308 @@
+package theme
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var styleColourTokenMatcher = regexp.MustCompile(
+	`(?:^|[\[,\s"'])(?:fg|bg|us|fill)=(#[0-9a-fA-F]{6}|[^,\]\s"'#]+)`,
+)
+
+// Tokenize splits theme source into classified tokens for syntax
+// highlighting, ordered by offset. Whitespace and the newlines between
+// statements are left out. As in tmux, an unquoted word starting with #
+// comments out the rest of the statement.
+//
+// Statements are split the same way Parse splits them, so a statement
+// continued with a trailing backslash is classified as a whole, with the
+// backslash itself as a ContinuationToken. Tokens never span lines: one
+// broken up by a continuation is returned as a token on each line.
+//
+// Within set-option statements, the option is an OptionToken if it is
+// built-in and a UserOptionToken if it starts with @. Values are split into
+// StringToken or TextToken runs, depending on whether the value is quoted,
+// around #{...} FormatTokens and #[...] StyleTokens. Colours in style
+// blocks, the values of style options and the values of colour options are
+// ColourTokens.
+func Tokenize(src string) []*Token {
+	t := &tokenizer{src: src}
+
+	for offset := 0; offset < len(src); {
+		end := strings.IndexByte(src[offset:], '\n')
+		if end < 0 {
+			end = len(src) - offset
+		}
+		line := strings.TrimSuffix(src[offset:offset+end], "\r")
+
+		if strings.HasSuffix(line, "\\") {
+			t.add(line[:len(line)-1], offset)
+			t.tokens = append(t.tokens, &Token{
+				Kind:  ContinuationToken,
+				Start: offset + len(line) - 1,
+				End:   offset + len(line),
+				Text:  "\\",
+			})
+		} else {
+			t.add(line, offset)
+			t.statement()
+		}
+		offset += end + 1
+	}
+	// A continuation on the last line leaves its statement unfinished.
+	t.statement()
+
+	sort.SliceStable(t.tokens, func(i, j int) bool {
+		return t.tokens[i].Start < t.tokens[j].Start
+	})
+
+	return t.tokens
+}
+
+// tokenizer classifies one statement body at a time. The body is the
+// statement's lines joined without their continuation backslashes, with
+// offsets holding the source offset of each of its bytes.
+type tokenizer struct {
+	src     string
+	tokens  []*Token
+	body    string
+	offsets []int
+}
+
+func (s *tokenizer) add(line string, offset int) {
+	s.body += line
+	for i := 0; i < len(line); i++ {
+		s.offsets = append(s.offsets, offset+i)
+	}
+}
+
+// emit adds a token for bytes start to end of the body, split wherever the
+// body skips over source, like at the end of a continued line.
+func (s *tokenizer) emit(kind TokenKind, start, end int) {
+	for start < end {
+		split := start + 1
+		for split < end && s.offsets[split] == s.offsets[split-1]+1 {
+			split++
+		}
+
+		from, to := s.offsets[start], s.offsets[split-1]+1
+		s.tokens = append(s.tokens, &Token{
+			Kind: kind, Start: from, End: to, Text: s.src[from:to],
+		})
+		start = split
+	}
+}
+
+func (s *tokenizer) statement() {
+	defer func() { s.body, s.offsets = "", nil }()
+
+	words := shellWords(s.body)
+	if len(words) == 0 {
+		return
+	}
+	if strings.HasPrefix(words[0].text(s.body), "#") {
+		s.emit(CommentToken, words[0].start, len(s.body))
+		return
+	}
+
+	command := words[0].text(s.body)
+	s.emit(CommandToken, words[0].start, words[0].end)
+
+	set := false
+	for _, c := range setOptionStatementCommands {
+		set = set || command == c
+	}
+
+	option, positional := "", 0
+	flags, target := true, false
+	for _, w := range words[1:] {
+		text := w.text(s.body)
+
+		switch {
+		case strings.HasPrefix(text, "#"):
+			s.emit(CommentToken, w.start, len(s.body))
+			return
+		case target:
+			s.value(w, "")
+			target = false
+		case flags && text == "--":
+			s.emit(FlagToken, w.start, w.end)
+			flags = false
+		case flags && len(text) > 1 && text[0] == '-':
+			s.emit(FlagToken, w.start, w.end)
+			target = set && strings.HasSuffix(text, "t")
+		case set && positional == 0:
+			option = text
+			s.option(w)
+			positional++
+		case set && positional == 1:
+			s.value(w, option)
+			positional++
+		default:
+			s.value(w, "")
+		}
+	}
+}
+
+func (s *tokenizer) option(w shellWord) {
+	name := w.text(s.body)
+
+	switch _, builtIn := LookupOptionDefinition(name); {
+	case strings.HasPrefix(name, "@"):
+		s.emit(UserOptionToken, w.start, w.end)
+	case builtIn:
+		s.emit(OptionToken, w.start, w.end)
+	default:
+		s.emit(TextToken, w.start, w.end)
+	}
+}
+
+// value classifies a word, which is the value of option if that is set.
+func (s *tokenizer) value(w shellWord, option string) {
+	kind := TextToken
+	start, end := w.start, w.end
+	if q := s.body[start]; q == '"' || q == '\'' {
+		kind = StringToken
+		if end-start >= 2 && s.body[end-1] == q {
+			s.emit(kind, start, start+1)
+			defer s.emit(kind, end-1, end)
+			start, end = start+1, end-1
+		} else {
+			s.emit(kind, start, start+1)
+			start++
+		}
+	}
+
+	valueType := optionValueType(option)
+	if valueType == ColourOption {
+		if _, err := ParseColour(s.body[start:end]); err == nil {
+			s.emit(ColourToken, start, end)
+			return
+		}
+	}
+
+	plain := start
+	for i := start; i+1 < end; i++ {
+		if s.body[i] != '#' {
+			continue
+		}
+
+		switch s.body[i+1] {
+		case '{':
+			n := formatSkip(s.body[i+2:end], "}")
+			if n < 0 {
+				break
+			}
+			s.styled(kind, plain, i, valueType == StyleOption)
+			s.emit(FormatToken, i, i+n+3)
+			i += n + 2
+			plain = i + 1
+		case '[':
+			n := strings.IndexByte(s.body[i+2:end], ']')
+			if n < 0 {
+				break
+			}
+			s.styled(kind, plain, i, valueType == StyleOption)
+			s.styled(StyleToken, i, i+n+3, true)
+			i += n + 2
+			plain = i + 1
+		case '#', ',', '}':
+			i++
+		}
+	}
+	s.styled(kind, plain, end, valueType == StyleOption)
+}
+
+// styled emits bytes start to end as kind, picking out the fg, bg, us and
+// fill colours if style is set.
+func (s *tokenizer) styled(kind TokenKind, start, end int, style bool) {
+	if !style {
+		s.emit(kind, start, end)
+		return
+	}
+
+	text, pos := s.body[start:end], start
+	for _, m := range styleColourTokenMatcher.FindAllStringSubmatchIndex(
+		text, -1) {
+		if _, err := ParseColour(text[m[2]:m[3]]); err != nil {
+			continue
+		}
+		s.emit(kind, pos, start+m[2])
+		s.emit(ColourToken, start+m[2], start+m[3])
+		pos = start + m[3]
+	}
+	s.emit(kind, pos, end)
+}
+
+// optionValueType returns the type of value an option holds. User options
+// are taken to hold a style or colour if their names end in -style, or -fg,
+// -bg, -colour or -color.
+func optionValueType(name string) OptionType {
+	if def, ok := LookupOptionDefinition(name); ok {
+		return def.Type
+	}
+	if !strings.HasPrefix(name, "@") {
+		return StringOption
+	}
+
+	if strings.HasSuffix(name, "-style") {
+		return StyleOption
+	}
+	for _, suffix := range []string{"-fg", "-bg", "-colour", "-color"} {
+		if strings.HasSuffix(name, suffix) {
+			return ColourOption
+		}
+	}
+
+	return StringOption
+}
+
+// shellWord is a whitespace separated word of a statement, with its quotes.
+type shellWord struct {
+	start int
+	end   int
+}
+
+func (s shellWord) text(body string) string {
+	return body[s.start:s.end]
+}
+
+// shellWords splits body on whitespace outside quotes, the way
+// shellquote.Split does.
+func shellWords(body string) []shellWord {
+	words := []shellWord{}
+
+	start, quote := -1, byte(0)
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' {
+				i++
+			}
+		case c == ' ' || c == '\t' || c == '\n':
+			if start >= 0 {
+				words = append(words, shellWord{start, i})
+				start = -1
+			}
+		default:
+			if start < 0 {
+				start = i
+			}
+			if c == '"' || c == '\'' {
+				quote = c
+			} else if c == '\\' {
+				i++
+			}
+		}
+	}
+	if start >= 0 {
+		words = append(words, shellWord{start, len(body)})
+	}
+
+	return words
+}
@@ -0,0 +1,209 @@
+package theme
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// describeTokens returns "kind text" for each token, checking Text matches
+// the offsets.
+func describeTokens(t *testing.T, src string, tokens []*Token) []string {
+	out := []string{}
+	for _, tok := range tokens {
+		assert.Equal(t, src[tok.Start:tok.End], tok.Text)
+		out = append(out, fmt.Sprintf("%s %s", tok.Kind, tok.Text))
+	}
+
+	return out
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "comment",
+			src:  "  # Colours \n\n",
+			want: []string{"comment # Colours "},
+		},
+		{
+			name: "user option",
+			src:  `set -g @theme-name "Nord"`,
+			want: []string{
+				"command set", "flag -g", "user-option @theme-name",
+				`string "`, "string Nord", `string "`,
+			},
+		},
+		{
+			name: "built-in option",
+			src:  "set-option -gq status-position top",
+			want: []string{
+				"command set-option", "flag -gq", "option status-position",
+				"text top",
+			},
+		},
+		{
+			name: "unknown option",
+			src:  "set -g nope x",
+			want: []string{"command set", "flag -g", "text nope", "text x"},
+		},
+		{
+			name: "target",
+			src:  "set -t main:1 -w @a -x",
+			want: []string{
+				"command set", "flag -t", "text main:1", "flag -w",
+				"user-option @a", "flag -x",
+			},
+		},
+		{
+			name: "double dash",
+			src:  "set -g -- @a -x",
+			want: []string{
+				"command set", "flag -g", "flag --", "user-option @a",
+				"text -x",
+			},
+		},
+		{
+			name: "colour option",
+			src:  "set -g @theme-fg '#eceff4'\nset -g status-bg brightred",
+			want: []string{
+				"command set", "flag -g", "user-option @theme-fg",
+				"string '", "colour #eceff4", "string '",
+				"command set", "flag -g", "option status-bg",
+				"colour brightred",
+			},
+		},
+		{
+			name: "style option",
+			src:  `set -gF status-style "fg=#{@theme-fg},bg=colour4,bold"`,
+			want: []string{
+				"command set", "flag -gF", "option status-style",
+				`string "`, "string fg=", "format #{@theme-fg}",
+				"string ,bg=", "colour colour4", "string ,bold",
+				`string "`,
+			},
+		},
+		{
+			name: "format",
+			src: `set -g status-left ` +
+				`"#[fg=red,bg=default]#{?client_prefix,#[bold],} #S ##{x}"`,
+			want: []string{
+				"command set", "flag -g", "option status-left",
+				`string "`, "style #[fg=", "colour red", "style ,bg=",
+				"colour default", "style ]",
+				"format #{?client_prefix,#[bold],}", "string  #S ##{x}",
+				`string "`,
+			},
+		},
+		{
+			name: "unterminated blocks",
+			src:  `set -g @a "#{x #[y"`,
+			want: []string{
+				"command set", "flag -g", "user-option @a",
+				`string "`, "string #{x #[y", `string "`,
+			},
+		},
+		{
+			name: "trailing comment",
+			src:  "set -g @a x # comment\nset -g @b #{x}",
+			want: []string{
+				"command set", "flag -g", "user-option @a", "text x",
+				"comment # comment",
+				"command set", "flag -g", "user-option @b",
+				"comment #{x}",
+			},
+		},
+		{
+			name: "continuation",
+			src:  "set -g \\\r\n  status-style \"fg=red,\\\n  bold\"\r\n",
+			want: []string{
+				"command set", "flag -g", "continuation \\",
+				"option status-style", `string "`, "string fg=",
+				"colour red", "string ,", "continuation \\",
+				"string   bold", `string "`,
+			},
+		},
+		{
+			name: "unfinished continuation",
+			src:  "set -g @a \\",
+			want: []string{
+				"command set", "flag -g", "user-option @a",
+				"continuation \\",
+			},
+		},
+		{
+			name: "other command",
+			src:  `bind-key -T prefix x "kill-pane"`,
+			want: []string{
+				"command bind-key", "flag -T", "text prefix", "text x",
+				`string "`, "string kill-pane", `string "`,
+			},
+		},
+		{
+			name: "unterminated quote",
+			src:  `set -g @a "open`,
+			want: []string{
+				"command set", "flag -g", "user-option @a",
+				`string "`, "string open",
+			},
+		},
+		{
+			name: "multibyte",
+			src:  "# é\nset -g @é \"€\"",
+			want: []string{
+				"comment # é", "command set", "flag -g", "user-option @é",
+				`string "`, "string €", `string "`,
+			},
+		},
+		{
+			name: "empty",
+			src:  "",
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := describeTokens(t, tt.src, Tokenize(tt.src))
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTokenizeMatchesParse(t *testing.T) {
+	src := "# Theme\nset -g @a \\\n  x\n\nset -gF status-left \"#{@a}\"\n"
+
+	th := New()
+	assert.NoError(t, th.Parse(strings.NewReader(src)))
+
+	commands := []int{}
+	for _, tok := range Tokenize(src) {
+		if tok.Kind == CommandToken || tok.Kind == CommentToken {
+			commands = append(commands, strings.Count(src[:tok.Start], "\n")+1)
+		}
+	}
+
+	starts := []int{}
+	for i, st := range th.Statements {
+		if _, ok := st.(*EmptyStatement); !ok {
+			starts = append(starts, th.Position(i).Line)
+		}
+	}
+	assert.Equal(t, starts, commands)
+}
+
+func TestOptionValueType(t *testing.T) {
+	assert.Equal(t, StyleOption, optionValueType("status-style"))
+	assert.Equal(t, ColourOption, optionValueType("status-fg"))
+	assert.Equal(t, StyleOption, optionValueType("@theme-status-style"))
+	assert.Equal(t, ColourOption, optionValueType("@theme-fg"))
+	assert.Equal(t, ColourOption, optionValueType("@accent-color"))
+	assert.Equal(t, StringOption, optionValueType("@theme-name"))
+	assert.Equal(t, StringOption, optionValueType("nope-fg"))
+	assert.Equal(t, StringOption, optionValueType(""))
+}
@@ -0,0 +1,15 @@
+package theme
+
+import "fmt"
+
+// UnfinishedContinuationError is returned by Parse when the last line ends
+// with a backslash, continuing the statement past the end of the input.
+type UnfinishedContinuationError struct {
+	Position Position
+}
+
+func (s *UnfinishedContinuationError) Error() string {
+	return fmt.Sprintf(
+		"Unfinished line continuation at end of input: %s", s.Position,
+	)
+}
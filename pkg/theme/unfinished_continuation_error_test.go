@@ -0,0 +1,33 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnfinishedContinuationErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &UnfinishedContinuationError{})
+}
+
+var unfinishedContinuationErrorTests = []struct {
+	pos Position
+	err string
+}{
+	{
+		Position{Line: 3, EndLine: 3},
+		"Unfinished line continuation at end of input: 3",
+	},
+	{
+		Position{Filename: "basic.tmuxtheme", Line: 2, EndLine: 4},
+		"Unfinished line continuation at end of input: basic.tmuxtheme:2-4",
+	},
+}
+
+func TestUnfinishedContinuationError(t *testing.T) {
+	for _, tt := range unfinishedContinuationErrorTests {
+		err := UnfinishedContinuationError{Position: tt.pos}
+
+		assert.Equal(t, tt.err, err.Error())
+	}
+}
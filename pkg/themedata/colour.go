@@ -0,0 +1,37 @@
+package themedata
+
+import (
+	"fmt"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+var colourTypes = map[theme.ColourType]string{
+	theme.DefaultColour:  "default",
+	theme.TerminalColour: "terminal",
+	theme.ANSIColour:     "ansi",
+	theme.PaletteColour:  "palette",
+	theme.RGBColour:      "rgb",
+}
+
+// Colour is a parsed colour: its canonical tmux name, type and, when it
+// does not depend on the terminal, its RGB value as a hex string.
+type Colour struct {
+	Value string `json:"value" yaml:"value" toml:"value"`
+	Type  string `json:"type" yaml:"type" toml:"type"`
+	Hex   string `json:"hex,omitempty" yaml:"hex,omitempty" toml:"hex,omitempty"`
+}
+
+// NewColour returns the data for c, or nil if c is not set.
+func NewColour(c theme.Colour) *Colour {
+	if !c.IsSet() {
+		return nil
+	}
+
+	data := &Colour{Value: c.String(), Type: colourTypes[c.Type]}
+	if r, g, b, ok := c.RGB(); ok {
+		data.Hex = fmt.Sprintf("#%02x%02x%02x", r, g, b)
+	}
+
+	return data
+}
@@ -0,0 +1,31 @@
+package themedata
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewColour(t *testing.T) {
+	var tests = []struct {
+		value string
+		data  *Colour
+	}{
+		{"", nil},
+		{"default", &Colour{Value: "default", Type: "default"}},
+		{"terminal", &Colour{Value: "terminal", Type: "terminal"}},
+		{"brightred", &Colour{Value: "brightred", Type: "ansi", Hex: "#ff0000"}},
+		{"colour39", &Colour{Value: "colour39", Type: "palette", Hex: "#00afff"}},
+		{"#A0B1C2", &Colour{Value: "#a0b1c2", Type: "rgb", Hex: "#a0b1c2"}},
+	}
+
+	for _, tt := range tests {
+		var c theme.Colour
+		if tt.value != "" {
+			c, _ = theme.ParseColour(tt.value)
+		}
+
+		assert.Equal(t, tt.data, NewColour(c), tt.value)
+	}
+}
@@ -0,0 +1,107 @@
+// Package themedata converts executed themes to and from structured data,
+// and encodes that data as JSON, YAML or TOML.
+package themedata
+
+import (
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// Options control what is included when building a Document.
+type Options struct {
+	// Statements includes the theme's statement list with positions.
+	Statements bool
+}
+
+// Document is the structured form of an executed theme.
+type Document struct {
+	Filename   string       `json:"filename,omitempty" yaml:"filename,omitempty" toml:"filename,omitempty"`
+	Scopes     []*Scope     `json:"scopes" yaml:"scopes" toml:"scopes"`
+	Statements []*Statement `json:"statements,omitempty" yaml:"statements,omitempty" toml:"statements,omitempty"`
+}
+
+// New builds a Document from the option maps of an executed theme. Empty
+// scopes are left out.
+func New(t *theme.Theme, opts *Options) (*Document, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	d := &Document{Filename: t.Filename, Scopes: []*Scope{}}
+
+	d.addScope(theme.ServerScope, "", t.ServerOptions)
+	d.addScope(theme.GlobalSessionScope, "", t.GlobalSessionOptions)
+	d.addScope(theme.SessionScope, "", t.SessionOptions)
+	for _, target := range sortedTargets(t.TargetSessionOptions) {
+		d.addScope(theme.SessionScope, target, t.TargetSessionOptions[target])
+	}
+	d.addScope(theme.GlobalWindowScope, "", t.GlobalWindowOptions)
+	d.addScope(theme.WindowScope, "", t.WindowOptions)
+	for _, target := range sortedTargets(t.TargetWindowOptions) {
+		d.addScope(theme.WindowScope, target, t.TargetWindowOptions[target])
+	}
+
+	if opts.Statements {
+		for i, st := range t.Statements {
+			data, err := NewStatement(st, t.Position(i))
+			if err != nil {
+				return nil, err
+			}
+			d.Statements = append(d.Statements, data)
+		}
+	}
+
+	return d, nil
+}
+
+func (s *Document) addScope(scope theme.Scope, target string, options map[string]string) {
+	if len(options) == 0 {
+		return
+	}
+
+	s.Scopes = append(s.Scopes, NewScope(scope, target, options))
+}
+
+// Theme builds a theme from the document. Option maps are restored as they
+// were resolved, so the returned theme does not need to be executed.
+// Statements and their positions are restored when present.
+func (s *Document) Theme() (*theme.Theme, error) {
+	t := theme.New()
+	t.Filename = s.Filename
+
+	for _, data := range s.Scopes {
+		scope, ok := parseScope(data.Scope)
+		if !ok {
+			return nil, &UnknownScopeError{Scope: data.Scope}
+		}
+
+		options := t.Options(scope, data.Target)
+		if options == nil {
+			options = map[string]string{}
+			if scope == theme.SessionScope {
+				t.TargetSessionOptions[data.Target] = options
+			} else {
+				t.TargetWindowOptions[data.Target] = options
+			}
+		}
+
+		for name, value := range data.Values() {
+			options[name] = value
+		}
+	}
+
+	for _, data := range s.Statements {
+		st, err := data.Statement()
+		if err != nil {
+			return nil, err
+		}
+
+		t.Statements = append(t.Statements, st)
+		t.Positions = append(t.Positions, theme.Position{
+			Filename: s.Filename,
+			Line:     data.Line,
+			EndLine:  data.EndLine,
+		})
+	}
+
+	return t, nil
+}
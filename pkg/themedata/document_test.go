@@ -0,0 +1,70 @@
+package themedata
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	th := themetest.Execute(t, "accent.tmuxtheme", `
+set -t work @project api
+set -t home @project dotfiles
+set -w -t work:2 @editor vim
+`[1:])
+
+	d, err := New(th, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, &Document{
+		Filename: "accent.tmuxtheme",
+		Scopes: []*Scope{
+			{
+				Scope:       "session",
+				Target:      "home",
+				UserOptions: map[string]string{"@project": "dotfiles"},
+			},
+			{
+				Scope:       "session",
+				Target:      "work",
+				UserOptions: map[string]string{"@project": "api"},
+			},
+			{
+				Scope:       "window",
+				Target:      "work:2",
+				UserOptions: map[string]string{"@editor": "vim"},
+			},
+		},
+	}, d)
+}
+
+func TestDocumentTheme(t *testing.T) {
+	d := &Document{
+		Scopes: []*Scope{
+			{
+				Scope:   "global-session",
+				Options: map[string]string{"status": "off"},
+				Styles:  map[string]*Style{"status-style": {}},
+			},
+			{
+				Scope:       "session",
+				Target:      "work",
+				UserOptions: map[string]string{"@project": "api"},
+			},
+		},
+	}
+
+	th, err := d.Theme()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"status": "off"}, th.GlobalSessionOptions)
+	assert.Equal(
+		t,
+		map[string]map[string]string{"work": {"@project": "api"}},
+		th.TargetSessionOptions,
+	)
+	assert.Equal(t, []theme.Statement{}, th.Statements)
+}
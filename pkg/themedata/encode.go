@@ -0,0 +1,82 @@
+package themedata
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/BurntSushi/toml"
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"gopkg.in/yaml.v2"
+)
+
+func EncodeJSON(w io.Writer, t *theme.Theme, opts *Options) error {
+	d, err := New(t, opts)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(d)
+}
+
+func EncodeYAML(w io.Writer, t *theme.Theme, opts *Options) error {
+	d, err := New(t, opts)
+	if err != nil {
+		return err
+	}
+
+	enc := yaml.NewEncoder(w)
+	err = enc.Encode(d)
+	if err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+func EncodeTOML(w io.Writer, t *theme.Theme, opts *Options) error {
+	d, err := New(t, opts)
+	if err != nil {
+		return err
+	}
+
+	return toml.NewEncoder(w).Encode(d)
+}
+
+func DecodeJSON(r io.Reader) (*theme.Theme, error) {
+	d := &Document{}
+	err := json.NewDecoder(r).Decode(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Theme()
+}
+
+func DecodeYAML(r io.Reader) (*theme.Theme, error) {
+	d := &Document{}
+	err := yaml.NewDecoder(r).Decode(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Theme()
+}
+
+func DecodeTOML(r io.Reader) (*theme.Theme, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Document{}
+	_, err = toml.Decode(string(b), d)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Theme()
+}
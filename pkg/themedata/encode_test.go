@@ -0,0 +1,153 @@
+package themedata
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const encodeTestTheme = `# Accent theme
+set -g status-style bg=red,fg=#ffffff,bold
+set -g @accent blue
+set -gw clock-mode-colour colour39
+set -w -t main:1 @editor \
+  vim
+`
+
+func TestEncodeDecode(t *testing.T) {
+	var tests = []struct {
+		name   string
+		encode func(io.Writer, *theme.Theme, *Options) error
+		decode func(io.Reader) (*theme.Theme, error)
+	}{
+		{"json", EncodeJSON, DecodeJSON},
+		{"yaml", EncodeYAML, DecodeYAML},
+		{"toml", EncodeTOML, DecodeTOML},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := themetest.Execute(t, "accent.tmuxtheme", encodeTestTheme)
+
+			var buf bytes.Buffer
+			err := tt.encode(&buf, th, &Options{Statements: true})
+			require.NoError(t, err)
+
+			decoded, err := tt.decode(&buf)
+			require.NoError(t, err)
+
+			assert.Equal(t, th.ServerOptions, decoded.ServerOptions)
+			assert.Equal(t, th.GlobalSessionOptions, decoded.GlobalSessionOptions)
+			assert.Equal(t, th.SessionOptions, decoded.SessionOptions)
+			assert.Equal(t, th.GlobalWindowOptions, decoded.GlobalWindowOptions)
+			assert.Equal(t, th.WindowOptions, decoded.WindowOptions)
+			assert.Equal(t, th.TargetSessionOptions, decoded.TargetSessionOptions)
+			assert.Equal(t, th.TargetWindowOptions, decoded.TargetWindowOptions)
+			assert.Equal(t, th.Statements, decoded.Statements)
+			assert.Equal(t, th.Positions, decoded.Positions)
+		})
+	}
+}
+
+func TestEncodeJSON(t *testing.T) {
+	th := themetest.Execute(t, "accent.tmuxtheme", "set -gw clock-mode-colour colour39\n")
+
+	var buf bytes.Buffer
+	err := EncodeJSON(&buf, th, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{
+  "filename": "accent.tmuxtheme",
+  "scopes": [
+    {
+      "scope": "global-window",
+      "options": {
+        "clock-mode-colour": "colour39"
+      },
+      "colours": {
+        "clock-mode-colour": {
+          "value": "colour39",
+          "type": "palette",
+          "hex": "#00afff"
+        }
+      }
+    }
+  ]
+}
+`, buf.String())
+}
+
+func TestEncodeYAML(t *testing.T) {
+	th := themetest.Execute(t, "accent.tmuxtheme", "set -g @accent blue\n")
+
+	var buf bytes.Buffer
+	err := EncodeYAML(&buf, th, &Options{Statements: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, `filename: accent.tmuxtheme
+scopes:
+- scope: global-session
+  user_options:
+    '@accent': blue
+statements:
+- line: 1
+  end_line: 1
+  type: set-option
+  flags: g
+  option: '@accent'
+  value: blue
+`, buf.String())
+}
+
+func TestEncodeTOML(t *testing.T) {
+	th := themetest.Execute(t, "accent.tmuxtheme", "set -s @accent blue\n")
+
+	var buf bytes.Buffer
+	err := EncodeTOML(&buf, th, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, `filename = "accent.tmuxtheme"
+
+[[scopes]]
+  scope = "server"
+  [scopes.user_options]
+    "@accent" = "blue"
+`, buf.String())
+}
+
+func TestDecodeErrors(t *testing.T) {
+	var tests = []struct {
+		body string
+		err  error
+	}{
+		{
+			body: `{"scopes": [{"scope": "pane"}]}`,
+			err:  &UnknownScopeError{Scope: "pane"},
+		},
+		{
+			body: `{"statements": [{"type": "set-hook"}]}`,
+			err:  &UnknownStatementTypeError{Type: "set-hook"},
+		},
+		{
+			body: `{"statements": [{"type": "set-option", "flags": "gx", "option": "@a"}]}`,
+			err:  &UnknownFlagError{Flag: "x"},
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := DecodeJSON(strings.NewReader(tt.body))
+
+		assert.Equal(t, tt.err, err)
+	}
+
+	_, err := DecodeYAML(strings.NewReader("scopes: {"))
+	assert.Error(t, err)
+	_, err = DecodeTOML(strings.NewReader("scopes = "))
+	assert.Error(t, err)
+}
@@ -0,0 +1,105 @@
+package themedata
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// Scope holds the options set in one scope and target of a theme. Built-in
+// options are kept apart from user @options, and the values of built-in
+// style and colour options are also included in parsed form.
+type Scope struct {
+	Scope       string             `json:"scope" yaml:"scope" toml:"scope"`
+	Target      string             `json:"target,omitempty" yaml:"target,omitempty" toml:"target,omitempty"`
+	Options     map[string]string  `json:"options,omitempty" yaml:"options,omitempty" toml:"options,omitempty"`
+	UserOptions map[string]string  `json:"user_options,omitempty" yaml:"user_options,omitempty" toml:"user_options,omitempty"`
+	Styles      map[string]*Style  `json:"styles,omitempty" yaml:"styles,omitempty" toml:"styles,omitempty"`
+	Colours     map[string]*Colour `json:"colours,omitempty" yaml:"colours,omitempty" toml:"colours,omitempty"`
+}
+
+func NewScope(scope theme.Scope, target string, options map[string]string) *Scope {
+	s := &Scope{Scope: scope.String(), Target: target}
+
+	for name, value := range options {
+		if strings.HasPrefix(name, "@") {
+			if s.UserOptions == nil {
+				s.UserOptions = map[string]string{}
+			}
+			s.UserOptions[name] = value
+			continue
+		}
+
+		if s.Options == nil {
+			s.Options = map[string]string{}
+		}
+		s.Options[name] = value
+		s.addParsed(name, value)
+	}
+
+	return s
+}
+
+// addParsed adds the parsed form of built-in style and colour options.
+// Values which fail to parse are only kept in their raw form.
+func (s *Scope) addParsed(name, value string) {
+	def, ok := theme.LookupOptionDefinition(name)
+	if !ok {
+		return
+	}
+
+	switch def.Type {
+	case theme.StyleOption:
+		style, err := theme.ParseStyle(value)
+		if err != nil {
+			return
+		}
+		if s.Styles == nil {
+			s.Styles = map[string]*Style{}
+		}
+		s.Styles[name] = NewStyle(style)
+	case theme.ColourOption:
+		colour, err := theme.ParseColour(value)
+		if err != nil || !colour.IsSet() {
+			return
+		}
+		if s.Colours == nil {
+			s.Colours = map[string]*Colour{}
+		}
+		s.Colours[name] = NewColour(colour)
+	}
+}
+
+// Values returns all options of the scope, built-in and user options alike.
+func (s *Scope) Values() map[string]string {
+	values := map[string]string{}
+	for name, value := range s.Options {
+		values[name] = value
+	}
+	for name, value := range s.UserOptions {
+		values[name] = value
+	}
+
+	return values
+}
+
+func parseScope(name string) (theme.Scope, bool) {
+	for scope := theme.ServerScope; scope <= theme.WindowScope; scope++ {
+		if scope.String() == name {
+			return scope, true
+		}
+	}
+
+	return 0, false
+}
+
+func sortedTargets(m map[string]map[string]string) []string {
+	targets := make([]string, 0, len(m))
+	for target := range m {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	return targets
+}
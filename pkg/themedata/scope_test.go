@@ -0,0 +1,53 @@
+package themedata
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScope(t *testing.T) {
+	scope := NewScope(theme.GlobalSessionScope, "", map[string]string{
+		"@accent":              "blue",
+		"status-style":         "bg=blue,underscore",
+		"message-style":        "fg=bogus",
+		"status-left":          "#S",
+		"display-panes-colour": "colour4",
+	})
+
+	assert.Equal(t, &Scope{
+		Scope: "global-session",
+		Options: map[string]string{
+			"status-style":         "bg=blue,underscore",
+			"message-style":        "fg=bogus",
+			"status-left":          "#S",
+			"display-panes-colour": "colour4",
+		},
+		UserOptions: map[string]string{"@accent": "blue"},
+		Styles: map[string]*Style{
+			"status-style": {
+				Bg:    &Colour{Value: "blue", Type: "ansi", Hex: "#0000ee"},
+				Attrs: []string{"underscore"},
+			},
+		},
+		Colours: map[string]*Colour{
+			"display-panes-colour": {
+				Value: "colour4", Type: "palette", Hex: "#0000ee",
+			},
+		},
+	}, scope)
+}
+
+func TestScopeValues(t *testing.T) {
+	scope := &Scope{
+		Options:     map[string]string{"status": "on"},
+		UserOptions: map[string]string{"@accent": "blue"},
+	}
+
+	assert.Equal(
+		t,
+		map[string]string{"status": "on", "@accent": "blue"},
+		scope.Values(),
+	)
+}
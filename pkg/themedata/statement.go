@@ -0,0 +1,106 @@
+package themedata
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// Statement is a parsed theme statement and the lines it spans.
+type Statement struct {
+	Line    int    `json:"line,omitempty" yaml:"line,omitempty" toml:"line,omitempty"`
+	EndLine int    `json:"end_line,omitempty" yaml:"end_line,omitempty" toml:"end_line,omitempty"`
+	Type    string `json:"type" yaml:"type" toml:"type"`
+	Flags   string `json:"flags,omitempty" yaml:"flags,omitempty" toml:"flags,omitempty"`
+	Target  string `json:"target,omitempty" yaml:"target,omitempty" toml:"target,omitempty"`
+	Option  string `json:"option,omitempty" yaml:"option,omitempty" toml:"option,omitempty"`
+	Value   string `json:"value,omitempty" yaml:"value,omitempty" toml:"value,omitempty"`
+	Message string `json:"message,omitempty" yaml:"message,omitempty" toml:"message,omitempty"`
+}
+
+const (
+	emptyStatement     = "empty"
+	commentStatement   = "comment"
+	setOptionStatement = "set-option"
+)
+
+// setOptionFlags maps set-option flag letters to their fields, in the
+// order they are written.
+var setOptionFlags = []struct {
+	flag  byte
+	field func(*theme.SetOptionFlags) *bool
+}{
+	{'a', func(f *theme.SetOptionFlags) *bool { return &f.Append }},
+	{'F', func(f *theme.SetOptionFlags) *bool { return &f.Format }},
+	{'g', func(f *theme.SetOptionFlags) *bool { return &f.Global }},
+	{'o', func(f *theme.SetOptionFlags) *bool { return &f.OnlyIfUnset }},
+	{'q', func(f *theme.SetOptionFlags) *bool { return &f.Quiet }},
+	{'s', func(f *theme.SetOptionFlags) *bool { return &f.Server }},
+	{'u', func(f *theme.SetOptionFlags) *bool { return &f.Unset }},
+	{'w', func(f *theme.SetOptionFlags) *bool { return &f.Window }},
+}
+
+func NewStatement(st theme.Statement, pos theme.Position) (*Statement, error) {
+	data := &Statement{Line: pos.Line, EndLine: pos.EndLine}
+
+	switch st := st.(type) {
+	case *theme.EmptyStatement:
+		data.Type = emptyStatement
+	case *theme.CommentStatement:
+		data.Type = commentStatement
+		data.Message = st.Msg
+	case *theme.SetOptionStatement:
+		data.Type = setOptionStatement
+		data.Option = st.Option
+		data.Value = st.Value
+		if st.Flags != nil {
+			data.Target = st.Flags.Target
+			for _, f := range setOptionFlags {
+				if *f.field(st.Flags) {
+					data.Flags += string(f.flag)
+				}
+			}
+		}
+	default:
+		return nil, &UnknownStatementTypeError{Type: fmt.Sprintf("%T", st)}
+	}
+
+	return data, nil
+}
+
+// Statement builds the theme statement the data describes.
+func (s *Statement) Statement() (theme.Statement, error) {
+	switch s.Type {
+	case emptyStatement:
+		return &theme.EmptyStatement{}, nil
+	case commentStatement:
+		return &theme.CommentStatement{Msg: s.Message}, nil
+	case setOptionStatement:
+		if s.Option == "" {
+			return nil, &theme.NoOptionArgumentError{}
+		}
+
+		flags := &theme.SetOptionFlags{Target: s.Target}
+		for _, c := range []byte(strings.TrimPrefix(s.Flags, "-")) {
+			found := false
+			for _, f := range setOptionFlags {
+				if f.flag == c {
+					*f.field(flags) = true
+					found = true
+				}
+			}
+			if !found {
+				return nil, &UnknownFlagError{Flag: string(c)}
+			}
+		}
+
+		return &theme.SetOptionStatement{
+			Flags:  flags,
+			Option: s.Option,
+			Value:  s.Value,
+		}, nil
+	}
+
+	return nil, &UnknownStatementTypeError{Type: s.Type}
+}
@@ -0,0 +1,74 @@
+package themedata
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStatement(t *testing.T) {
+	var tests = []struct {
+		body string
+		data *Statement
+	}{
+		{
+			body: "",
+			data: &Statement{Line: 2, EndLine: 2, Type: "empty"},
+		},
+		{
+			body: "# Colours",
+			data: &Statement{
+				Line: 2, EndLine: 2, Type: "comment", Message: "Colours",
+			},
+		},
+		{
+			body: `set -gqoF @accent "#{@blue}"`,
+			data: &Statement{
+				Line: 2, EndLine: 2, Type: "set-option", Flags: "Fgoq",
+				Option: "@accent", Value: "#{@blue}",
+			},
+		},
+		{
+			body: `set-window-option -t main:1 -u @editor`,
+			data: &Statement{
+				Line: 2, EndLine: 2, Type: "set-option", Flags: "uw",
+				Target: "main:1", Option: "@editor",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		st, err := theme.NewStatement(tt.body)
+		require.NoError(t, err)
+
+		data, err := NewStatement(st, theme.Position{Line: 2, EndLine: 2})
+		require.NoError(t, err)
+		assert.Equal(t, tt.data, data)
+
+		restored, err := data.Statement()
+		require.NoError(t, err)
+		assert.Equal(t, st, restored)
+	}
+}
+
+func TestStatementFlagsWithDash(t *testing.T) {
+	data := &Statement{Type: "set-option", Flags: "-ga", Option: "@a"}
+
+	st, err := data.Statement()
+	require.NoError(t, err)
+
+	assert.Equal(t, &theme.SetOptionStatement{
+		Flags:  &theme.SetOptionFlags{Global: true, Append: true},
+		Option: "@a",
+	}, st)
+}
+
+func TestStatementNoOption(t *testing.T) {
+	data := &Statement{Type: "set-option", Flags: "g"}
+
+	_, err := data.Statement()
+
+	assert.Equal(t, &theme.NoOptionArgumentError{}, err)
+}
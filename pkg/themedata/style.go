@@ -0,0 +1,34 @@
+package themedata
+
+import (
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// Style is a parsed style with its colours and attributes broken out.
+type Style struct {
+	Fg    *Colour  `json:"fg,omitempty" yaml:"fg,omitempty" toml:"fg,omitempty"`
+	Bg    *Colour  `json:"bg,omitempty" yaml:"bg,omitempty" toml:"bg,omitempty"`
+	Us    *Colour  `json:"us,omitempty" yaml:"us,omitempty" toml:"us,omitempty"`
+	Fill  *Colour  `json:"fill,omitempty" yaml:"fill,omitempty" toml:"fill,omitempty"`
+	Attrs []string `json:"attrs,omitempty" yaml:"attrs,omitempty" toml:"attrs,omitempty"`
+	Align string   `json:"align,omitempty" yaml:"align,omitempty" toml:"align,omitempty"`
+	List  string   `json:"list,omitempty" yaml:"list,omitempty" toml:"list,omitempty"`
+	Range string   `json:"range,omitempty" yaml:"range,omitempty" toml:"range,omitempty"`
+}
+
+func NewStyle(s theme.Style) *Style {
+	data := &Style{
+		Fg:    NewColour(s.Fg),
+		Bg:    NewColour(s.Bg),
+		Us:    NewColour(s.Us),
+		Fill:  NewColour(s.Fill),
+		Align: s.Align,
+		List:  s.List,
+		Range: s.Range,
+	}
+	if names := s.Attrs.Names(); len(names) > 0 {
+		data.Attrs = names
+	}
+
+	return data
+}
@@ -0,0 +1,27 @@
+package themedata
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStyle(t *testing.T) {
+	style, _ := theme.ParseStyle(
+		"fg=default,bg=#000000,us=red,fill=colour0,italics,dim,align=right,list=on,range=left",
+	)
+
+	assert.Equal(t, &Style{
+		Fg:    &Colour{Value: "default", Type: "default"},
+		Bg:    &Colour{Value: "#000000", Type: "rgb", Hex: "#000000"},
+		Us:    &Colour{Value: "red", Type: "ansi", Hex: "#cd0000"},
+		Fill:  &Colour{Value: "colour0", Type: "palette", Hex: "#000000"},
+		Attrs: []string{"dim", "italics"},
+		Align: "right",
+		List:  "on",
+		Range: "left",
+	}, NewStyle(style))
+
+	assert.Equal(t, &Style{}, NewStyle(theme.Style{}))
+}
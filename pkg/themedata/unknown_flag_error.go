@@ -0,0 +1,11 @@
+package themedata
+
+import "fmt"
+
+type UnknownFlagError struct {
+	Flag string
+}
+
+func (s *UnknownFlagError) Error() string {
+	return fmt.Sprintf("Unknown set-option flag: %s", s.Flag)
+}
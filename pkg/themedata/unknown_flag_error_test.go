@@ -0,0 +1,17 @@
+package themedata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnknownFlagErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &UnknownFlagError{})
+}
+
+func TestUnknownFlagError(t *testing.T) {
+	err := &UnknownFlagError{Flag: "x"}
+
+	assert.Equal(t, "Unknown set-option flag: x", err.Error())
+}
@@ -0,0 +1,11 @@
+package themedata
+
+import "fmt"
+
+type UnknownScopeError struct {
+	Scope string
+}
+
+func (s *UnknownScopeError) Error() string {
+	return fmt.Sprintf("Unknown scope: %s", s.Scope)
+}
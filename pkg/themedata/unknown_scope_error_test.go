@@ -0,0 +1,17 @@
+package themedata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnknownScopeErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &UnknownScopeError{})
+}
+
+func TestUnknownScopeError(t *testing.T) {
+	err := &UnknownScopeError{Scope: "pane"}
+
+	assert.Equal(t, "Unknown scope: pane", err.Error())
+}
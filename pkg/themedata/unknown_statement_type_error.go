@@ -0,0 +1,11 @@
+package themedata
+
+import "fmt"
+
+type UnknownStatementTypeError struct {
+	Type string
+}
+
+func (s *UnknownStatementTypeError) Error() string {
+	return fmt.Sprintf("Unknown statement type: %s", s.Type)
+}
@@ -0,0 +1,17 @@
+package themedata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnknownStatementTypeErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &UnknownStatementTypeError{})
+}
+
+func TestUnknownStatementTypeError(t *testing.T) {
+	err := &UnknownStatementTypeError{Type: "set-hook"}
+
+	assert.Equal(t, "Unknown statement type: set-hook", err.Error())
+}
@@ -0,0 +1,82 @@
+// Package themetest provides golden-file snapshot testing for tmux themes.
+//
+// A theme is executed under a fixed format context and clock, and its
+// resolved options and rendered status line are compared against a golden
+// file next to it. Run tests with UPDATE_GOLDEN=1 set in the environment to
+// regenerate golden files.
+package themetest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// UpdateEnv names the environment variable which, when set to a non-empty
+// value, makes AssertGolden write golden files instead of comparing them.
+// An environment variable is used rather than a flag, as a flag registered
+// here would clash with test packages defining their own.
+const UpdateEnv = "UPDATE_GOLDEN"
+
+// GoldenFile returns the golden file path for a theme file, replacing its
+// .tmuxtheme extension with .golden.
+func GoldenFile(filename string) string {
+	return strings.TrimSuffix(filename, filepath.Ext(filename)) + ".golden"
+}
+
+// AssertTheme loads and executes the theme in filename and compares its
+// snapshot against the theme's golden file.
+func AssertTheme(t *testing.T, filename string) {
+	t.Helper()
+
+	state := State()
+	th, err := Load(filename, state)
+	require.NoError(t, err)
+
+	snapshot, err := Snapshot(th, state)
+	require.NoError(t, err)
+
+	AssertGolden(t, GoldenFile(filename), snapshot)
+}
+
+// AssertThemes runs AssertTheme as a subtest for every theme file matching
+// pattern, failing if nothing matches.
+func AssertThemes(t *testing.T, pattern string) {
+	t.Helper()
+
+	files, err := filepath.Glob(pattern)
+	require.NoError(t, err)
+	require.NotEmpty(t, files, "no themes match %s", pattern)
+
+	for _, filename := range files {
+		filename := filename
+		t.Run(filepath.Base(filename), func(t *testing.T) {
+			AssertTheme(t, filename)
+		})
+	}
+}
+
+// AssertGolden compares got against the contents of the golden file, or
+// writes got to it when UpdateEnv is set.
+func AssertGolden(t *testing.T, golden string, got string) {
+	t.Helper()
+
+	if os.Getenv(UpdateEnv) != "" {
+		err := os.MkdirAll(filepath.Dir(golden), 0755)
+		require.NoError(t, err)
+		err = ioutil.WriteFile(golden, []byte(got), 0644)
+		require.NoError(t, err)
+
+		return
+	}
+
+	want, err := ioutil.ReadFile(golden)
+	require.NoError(t, err, "run tests with %s=1 to create golden files", UpdateEnv)
+
+	assert.Equal(t, string(want), got, "golden file %s", golden)
+}
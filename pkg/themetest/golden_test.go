@@ -0,0 +1,49 @@
+package themetest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoldenFile(t *testing.T) {
+	var tests = []struct {
+		filename string
+		golden   string
+	}{
+		{"basic.tmuxtheme", "basic.golden"},
+		{"testdata/dark.theme.tmuxtheme", "testdata/dark.theme.golden"},
+		{"themes/light", "themes/light.golden"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.golden, GoldenFile(tt.filename))
+	}
+}
+
+func TestAssertThemes(t *testing.T) {
+	AssertThemes(t, "testdata/*.tmuxtheme")
+}
+
+func TestAssertGoldenUpdate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "themetest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	golden := filepath.Join(dir, "nested", "theme.golden")
+
+	require.NoError(t, os.Setenv(UpdateEnv, "1"))
+	defer os.Unsetenv(UpdateEnv)
+	AssertGolden(t, golden, "snapshot\n")
+
+	content, err := ioutil.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, "snapshot\n", string(content))
+
+	require.NoError(t, os.Unsetenv(UpdateEnv))
+	AssertGolden(t, golden, "snapshot\n")
+}
@@ -0,0 +1,33 @@
+package themetest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/require"
+)
+
+// Parse parses body as the source of the theme file filename, failing the
+// test on errors. A leading newline is trimmed, so bodies can start on the
+// line after an opening backquote.
+func Parse(t *testing.T, filename, body string) *theme.Theme {
+	t.Helper()
+
+	th := theme.New()
+	th.Filename = filename
+	err := th.Parse(strings.NewReader(strings.TrimLeft(body, "\n")))
+	require.NoError(t, err)
+
+	return th
+}
+
+// Execute parses body like Parse and executes it.
+func Execute(t *testing.T, filename, body string) *theme.Theme {
+	t.Helper()
+
+	th := Parse(t, filename, body)
+	require.NoError(t, th.Execute())
+
+	return th
+}
@@ -0,0 +1,28 @@
+package themetest
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	th := Parse(t, "accent.tmuxtheme", `
+# Accent
+set -g @accent blue
+`)
+
+	assert.Equal(t, "accent.tmuxtheme", th.Filename)
+	assert.Len(t, th.Statements, 2)
+	assert.Equal(t, theme.Position{
+		Filename: "accent.tmuxtheme", Line: 2, EndLine: 2,
+	}, th.Position(1))
+	assert.Empty(t, th.GlobalSessionOptions)
+}
+
+func TestExecute(t *testing.T) {
+	th := Execute(t, "", `set -g @accent blue`)
+
+	assert.Equal(t, "blue", th.GlobalSessionOptions["@accent"])
+}
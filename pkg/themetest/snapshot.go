@@ -0,0 +1,112 @@
+package themetest
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/preview"
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// Time is the fixed time themes are executed and rendered at, so strftime
+// sequences in snapshots are stable.
+var Time = time.Date(2019, 3, 7, 21, 5, 3, 0, time.UTC)
+
+// State returns the simulated tmux state snapshots are taken with: the
+// preview package's default state with its clock fixed to Time.
+func State() *preview.State {
+	state := preview.DefaultState()
+	state.Clock = func() time.Time { return Time }
+
+	return state
+}
+
+// Load parses and executes the theme in filename with its format context
+// set to the current window of state.
+func Load(filename string, state *preview.State) (*theme.Theme, error) {
+	t := theme.New()
+	t.Context = state.Context(state.CurrentWindow())
+
+	err := t.Load(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Snapshot returns a stable text representation of an executed theme: the
+// options set in each scope and target, sorted by name, followed by the
+// status line rendered for state as plain text and with ANSI escapes.
+func Snapshot(t *theme.Theme, state *preview.State) (string, error) {
+	var b strings.Builder
+
+	writeOptions(&b, theme.ServerScope.String(), t.ServerOptions)
+	writeOptions(&b, theme.GlobalSessionScope.String(), t.GlobalSessionOptions)
+	writeOptions(&b, theme.SessionScope.String(), t.SessionOptions)
+	for _, target := range sortedKeys(t.TargetSessionOptions) {
+		writeOptions(
+			&b, theme.SessionScope.String()+" "+target,
+			t.TargetSessionOptions[target],
+		)
+	}
+	writeOptions(&b, theme.GlobalWindowScope.String(), t.GlobalWindowOptions)
+	writeOptions(&b, theme.WindowScope.String(), t.WindowOptions)
+	for _, target := range sortedKeys(t.TargetWindowOptions) {
+		writeOptions(
+			&b, theme.WindowScope.String()+" "+target,
+			t.TargetWindowOptions[target],
+		)
+	}
+
+	line, err := preview.StatusLine(t, state)
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Fprintf(&b, "# status\n")
+	fmt.Fprintf(&b, "text: %s\n", strconv.Quote(line.String()))
+	fmt.Fprintf(&b, "ansi: %s\n", strconv.Quote(line.ANSI()))
+
+	return b.String(), nil
+}
+
+func writeOptions(b *strings.Builder, title string, options map[string]string) {
+	if len(options) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "# %s\n", title)
+	for _, name := range sortedNames(options) {
+		fmt.Fprintf(b, "%s = %s\n", name, strconv.Quote(options[name]))
+	}
+	b.WriteString("\n")
+}
+
+func sortedNames(m map[string]string) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func sortedKeys(m map[string]map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
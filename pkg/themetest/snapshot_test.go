@@ -0,0 +1,56 @@
+package themetest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState(t *testing.T) {
+	state := State()
+
+	assert.Equal(t, Time, state.Clock())
+	assert.Equal(t, "main", state.Session.Name)
+}
+
+func TestLoad(t *testing.T) {
+	state := State()
+
+	th, err := Load("testdata/formats.tmuxtheme", state)
+	require.NoError(t, err)
+	assert.Equal(t, "main on localhost", th.GlobalSessionOptions["@title"])
+
+	_, err = Load("testdata/missing.tmuxtheme", state)
+	assert.Error(t, err)
+}
+
+func TestSnapshot(t *testing.T) {
+	th := theme.New()
+	err := th.Parse(strings.NewReader(
+		"set -g status-left \"#S \"\nset -g status-right \"\"\nset -s @b 2\nset -s @a \"1\\\"\"\n",
+	))
+	require.NoError(t, err)
+	require.NoError(t, th.Execute())
+
+	state := State()
+	state.Width = 20
+
+	snapshot, err := Snapshot(th, state)
+	require.NoError(t, err)
+
+	assert.Equal(t, `# server
+@a = "1\""
+@b = "2"
+
+# global-session
+status-left = "#S "
+status-right = ""
+
+# status
+text: "main 1:bash- 2:vim*>"
+ansi: "\x1b[0;30;42mmain 1:bash- 2:vim*>\x1b[0m"
+`, snapshot)
+}
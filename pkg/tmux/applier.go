@@ -0,0 +1,107 @@
+package tmux
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// Applier pushes the option state of an executed theme into a running tmux
+// server.
+type Applier struct {
+	// Runner runs the commands, a Client or ControlClient.
+	Runner Runner
+
+	// DryRun prints the commands to Output instead of running them.
+	DryRun bool
+	Output io.Writer
+}
+
+// Change is the outcome of applying one option.
+type Change struct {
+	Command *Command
+	// Old is the option's value before it was set, empty for dry runs.
+	Old string
+	Err error
+}
+
+// Changed reports whether the option was set to a value different from
+// what it had before.
+func (s *Change) Changed() bool {
+	return s.Err == nil && s.Old != s.Command.Value
+}
+
+// Result holds the changes made by Apply, in the order they were made.
+type Result struct {
+	Changes []*Change
+}
+
+// Changed returns the changes which set an option to a new value.
+func (s *Result) Changed() []*Change {
+	changes := []*Change{}
+	for _, c := range s.Changes {
+		if c.Changed() {
+			changes = append(changes, c)
+		}
+	}
+
+	return changes
+}
+
+// Failures returns the changes which failed to apply.
+func (s *Result) Failures() []*Change {
+	changes := []*Change{}
+	for _, c := range s.Changes {
+		if c.Err != nil {
+			changes = append(changes, c)
+		}
+	}
+
+	return changes
+}
+
+// Apply sets every option of t on the server. Options which fail to apply
+// are recorded in the result and do not stop the others from being applied;
+// an ApplyError is returned if any failed.
+func (s *Applier) Apply(t *theme.Theme) (*Result, error) {
+	result := &Result{}
+
+	for _, cmd := range Commands(t) {
+		change := &Change{Command: cmd}
+		result.Changes = append(result.Changes, change)
+
+		if s.DryRun {
+			if s.Output != nil {
+				_, err := fmt.Fprintln(s.Output, cmd.String())
+				if err != nil {
+					return result, err
+				}
+			}
+			continue
+		}
+
+		old, err := s.Runner.Run(cmd.ShowArgs()...)
+		if err == nil {
+			change.Old = trimNewline(old)
+		}
+
+		_, change.Err = s.Runner.Run(cmd.SetArgs()...)
+	}
+
+	if failures := result.Failures(); len(failures) > 0 {
+		return result, &ApplyError{
+			Failed: len(failures), Total: len(result.Changes),
+		}
+	}
+
+	return result, nil
+}
+
+func trimNewline(s string) string {
+	if len(s) > 0 && s[len(s)-1] == '\n' {
+		return s[:len(s)-1]
+	}
+
+	return s
+}
@@ -0,0 +1,133 @@
+package tmux
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const applierTestTheme = `
+set -g status-style "bg=#2e3440,fg=#d8dee9"
+set -g status-left "#S "
+set -g @accent "#88c0d0"
+set -gw mode-style bg=red
+set -t main @project "go tmuxtheme"
+`
+
+func TestApplierDryRun(t *testing.T) {
+	th := themetest.Execute(t, "", applierTestTheme)
+	var buf bytes.Buffer
+
+	a := &Applier{DryRun: true, Output: &buf}
+	result, err := a.Apply(th)
+	require.NoError(t, err)
+
+	assert.Equal(t, `set-option -g @accent "#88c0d0"
+set-option -g status-left "#S "
+set-option -g status-style "bg=#2e3440,fg=#d8dee9"
+set-option -t main @project "go tmuxtheme"
+set-option -gw mode-style bg=red
+`, buf.String())
+	assert.Len(t, result.Changes, 5)
+	assert.Empty(t, result.Failures())
+}
+
+func TestApplierApply(t *testing.T) {
+	c, stop := startServer(t)
+	defer stop()
+
+	cc, err := StartControl(c, "main")
+	require.NoError(t, err)
+	defer cc.Close()
+
+	for _, runner := range []Runner{c, cc} {
+		_, err := c.Run("set-option", "-g", "status-left", "#S ")
+		require.NoError(t, err)
+		_, err = c.Run("set-option", "-gu", "@accent")
+		require.NoError(t, err)
+
+		th := themetest.Execute(t, "", applierTestTheme)
+
+		a := &Applier{Runner: runner}
+		result, err := a.Apply(th)
+		require.NoError(t, err)
+
+		var changed []string
+		for _, change := range result.Changed() {
+			changed = append(changed, change.Command.Name+"="+change.Old)
+		}
+		assert.Equal(t, []string{
+			"@accent=",
+			"status-style=bg=green,fg=black",
+			"@project=",
+			"mode-style=bg=yellow,fg=black",
+		}, changed)
+
+		var tests = []struct {
+			args  []string
+			value string
+		}{
+			{[]string{"-gv", "status-style"}, "bg=#2e3440,fg=#d8dee9\n"},
+			{[]string{"-gv", "@accent"}, "#88c0d0\n"},
+			{[]string{"-gwv", "mode-style"}, "bg=red\n"},
+			{[]string{"-v", "-t", "main", "@project"}, "go tmuxtheme\n"},
+		}
+		for _, tt := range tests {
+			out, err := c.Run(append([]string{"show-options"}, tt.args...)...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, out, tt.args)
+		}
+
+		_, err = c.Run("set-option", "-g", "mode-style", "bg=yellow,fg=black")
+		require.NoError(t, err)
+		_, err = c.Run("set-option", "-g", "status-style", "bg=green,fg=black")
+		require.NoError(t, err)
+		_, err = c.Run("set-option", "-u", "-t", "main", "@project")
+		require.NoError(t, err)
+	}
+}
+
+func TestApplierApplyDashValue(t *testing.T) {
+	c, stop := startServer(t)
+	defer stop()
+
+	th := themetest.Execute(t, "", `set -g -- @sep "-#[fg=red]"`)
+
+	result, err := (&Applier{Runner: c}).Apply(th)
+	require.NoError(t, err)
+	assert.Empty(t, result.Failures())
+
+	out, err := c.Run("show-options", "-gv", "@sep")
+	require.NoError(t, err)
+	assert.Equal(t, "-#[fg=red]\n", out)
+}
+
+func TestApplierFailures(t *testing.T) {
+	c, stop := startServer(t)
+	defer stop()
+
+	th := themetest.Execute(t, "", "set -g @accent blue\nset -t missing @project api\n")
+	th.GlobalSessionOptions["status-style"] = "bogus=1"
+
+	a := &Applier{Runner: c}
+	result, err := a.Apply(th)
+
+	assert.Equal(t, &ApplyError{Failed: 2, Total: 3}, err)
+	failures := result.Failures()
+	require.Len(t, failures, 2)
+	assert.Equal(t, "status-style", failures[0].Command.Name)
+	assert.EqualError(
+		t, failures[0].Err,
+		"Command failed: set-option -g status-style bogus=1: "+
+			"invalid style: bogus=1",
+	)
+	assert.Equal(t, "@project", failures[1].Command.Name)
+	assert.Error(t, failures[1].Err)
+
+	out, err := c.Run("show-options", "-gv", "@accent")
+	require.NoError(t, err)
+	assert.Equal(t, "blue\n", out)
+}
@@ -0,0 +1,12 @@
+package tmux
+
+import "fmt"
+
+type ApplyError struct {
+	Failed int
+	Total  int
+}
+
+func (s *ApplyError) Error() string {
+	return fmt.Sprintf("Failed to apply %d of %d options", s.Failed, s.Total)
+}
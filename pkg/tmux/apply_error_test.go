@@ -0,0 +1,17 @@
+package tmux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &ApplyError{})
+}
+
+func TestApplyError(t *testing.T) {
+	err := &ApplyError{Failed: 2, Total: 40}
+
+	assert.Equal(t, "Failed to apply 2 of 40 options", err.Error())
+}
@@ -0,0 +1,186 @@
+package tmux
+
+import (
+	"io"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/kballard/go-shellquote"
+)
+
+// CaptureOptions control what Capture reads from the server.
+type CaptureOptions struct {
+	// Targets also captures the options set on each session and window,
+	// in addition to the server and global options.
+	Targets bool
+
+	// SkipDefaults leaves out built-in options which are set to their
+	// default value.
+	SkipDefaults bool
+}
+
+// Capture builds a theme from the options currently set on a tmux server.
+// Global pane options are captured with the global window options, as
+// they share the same option tree.
+func Capture(r Runner, opts *CaptureOptions) (*theme.Theme, error) {
+	if opts == nil {
+		opts = &CaptureOptions{}
+	}
+
+	c := &capturer{runner: r, opts: opts}
+	t := theme.New()
+
+	queries := []struct {
+		options map[string]string
+		args    []string
+	}{
+		{t.ServerOptions, []string{"-s"}},
+		{t.GlobalSessionOptions, []string{"-g"}},
+		{t.GlobalWindowOptions, []string{"-gw"}},
+		{t.GlobalWindowOptions, []string{"-gp"}},
+	}
+	for _, q := range queries {
+		err := c.show(q.options, q.args...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !opts.Targets {
+		return t, nil
+	}
+
+	sessions, err := c.list("list-sessions", "-F", "#{session_name}")
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		options := map[string]string{}
+		err := c.show(options, "-t", session)
+		if err != nil {
+			return nil, err
+		}
+		if len(options) > 0 {
+			t.TargetSessionOptions[session] = options
+		}
+	}
+
+	windows, err := c.list(
+		"list-windows", "-a", "-F", "#{session_name}:#{window_index}",
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, window := range windows {
+		options := map[string]string{}
+		err := c.show(options, "-w", "-t", window)
+		if err != nil {
+			return nil, err
+		}
+		if len(options) > 0 {
+			t.TargetWindowOptions[window] = options
+		}
+	}
+
+	return t, nil
+}
+
+// WriteTheme writes the options of t as set-option statements, so a
+// captured theme can be saved as a .tmuxtheme file.
+func WriteTheme(w io.Writer, t *theme.Theme) error {
+	for _, cmd := range Commands(t) {
+		_, err := io.WriteString(w, cmd.String()+"\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Unapplied returns the commands of want whose option does not have the
+// same value in live, typically a theme captured after applying want.
+func Unapplied(want, live *theme.Theme) []*Command {
+	cmds := []*Command{}
+	for _, cmd := range Commands(want) {
+		options := live.Options(cmd.Scope, cmd.Target)
+		if value, ok := options[cmd.Name]; !ok || value != cmd.Value {
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	return cmds
+}
+
+type capturer struct {
+	runner Runner
+	opts   *CaptureOptions
+}
+
+func (s *capturer) show(options map[string]string, args ...string) error {
+	out, err := s.runner.Run(append([]string{"show-options"}, args...)...)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := parseOptions(out)
+	if err != nil {
+		return err
+	}
+
+	for name, value := range parsed {
+		if s.opts.SkipDefaults && isDefault(name, value) {
+			continue
+		}
+		options[name] = value
+	}
+
+	return nil
+}
+
+func (s *capturer) list(args ...string) ([]string, error) {
+	out, err := s.runner.Run(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Names may contain spaces, so split on lines only.
+	lines := strings.Split(out, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines, nil
+}
+
+// parseOptions parses show-options output, one "name value" pair per line
+// with the value quoted the way tmux quotes arguments.
+func parseOptions(out string) (map[string]string, error) {
+	options := map[string]string{}
+
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		parts := strings.SplitN(line, " ", 2)
+		if len(parts) == 1 {
+			options[parts[0]] = ""
+			continue
+		}
+
+		words, err := shellquote.Split(parts[1])
+		if err != nil {
+			return nil, &InvalidOutputError{Line: line}
+		}
+		options[parts[0]] = strings.Join(words, " ")
+	}
+
+	return options, nil
+}
+
+func isDefault(name, value string) bool {
+	def, ok := theme.LookupOptionDefinition(name)
+
+	return ok && def.Default == value
+}
@@ -0,0 +1,147 @@
+package tmux
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapture(t *testing.T) {
+	c, stop := startServer(t)
+	defer stop()
+
+	setup := [][]string{
+		{"set-option", "-g", "@x", `a "b" $c #{d}`},
+		{"set-option", "-g", "status-left", "[#S] "},
+		{"set-option", "-s", "@server", "on"},
+		{"set-option", "-gw", "mode-style", "bg=red"},
+		{"set-option", "-t", "main", "@project", "go x"},
+		{"set-option", "-w", "-t", "main:0", "@editor", "vim"},
+	}
+	for _, args := range setup {
+		_, err := c.Run(args...)
+		require.NoError(t, err)
+	}
+
+	th, err := Capture(c, &CaptureOptions{Targets: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "on", th.ServerOptions["@server"])
+	assert.Equal(t, "50", th.ServerOptions["buffer-limit"])
+	assert.Equal(t, `a "b" $c #{d}`, th.GlobalSessionOptions["@x"])
+	assert.Equal(t, "[#S] ", th.GlobalSessionOptions["status-left"])
+	assert.Equal(t, "bg=green,fg=black", th.GlobalSessionOptions["status-style"])
+	assert.Equal(t, "bg=red", th.GlobalWindowOptions["mode-style"])
+	assert.Equal(
+		t,
+		map[string]map[string]string{
+			"main": {"@project": "go x", "default-size": "80x24"},
+		},
+		th.TargetSessionOptions,
+	)
+	assert.Equal(
+		t,
+		map[string]map[string]string{"main:0": {"@editor": "vim"}},
+		th.TargetWindowOptions,
+	)
+}
+
+func TestCaptureTargetsWithSpaces(t *testing.T) {
+	c, stop := startServer(t)
+	defer stop()
+
+	setup := [][]string{
+		{"new-session", "-d", "-s", "my work", "-n", "an editor"},
+		{"set-option", "-t", "my work", "@project", "api"},
+		{"set-option", "-w", "-t", "my work:0", "@editor", "vim"},
+	}
+	for _, args := range setup {
+		_, err := c.Run(args...)
+		require.NoError(t, err)
+	}
+
+	th, err := Capture(c, &CaptureOptions{Targets: true, SkipDefaults: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "api", th.TargetSessionOptions["my work"]["@project"])
+	assert.Equal(t, "vim", th.TargetWindowOptions["my work:0"]["@editor"])
+}
+
+func TestCaptureOptions(t *testing.T) {
+	c, stop := startServer(t)
+	defer stop()
+
+	_, err := c.Run("set-option", "-g", "status-left", "[#S] ")
+	require.NoError(t, err)
+	_, err = c.Run("set-option", "-t", "main", "@project", "api")
+	require.NoError(t, err)
+
+	th, err := Capture(c, &CaptureOptions{SkipDefaults: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "[#S] ", th.GlobalSessionOptions["status-left"])
+	assert.NotContains(t, th.GlobalSessionOptions, "status-style")
+	assert.NotContains(t, th.GlobalWindowOptions, "mode-style")
+	assert.Empty(t, th.TargetSessionOptions)
+}
+
+func TestCaptureAfterApply(t *testing.T) {
+	c, stop := startServer(t)
+	defer stop()
+
+	want := themetest.Execute(t, "", applierTestTheme)
+
+	assert.NotEmpty(t, Unapplied(want, captureAll(t, c)))
+
+	_, err := (&Applier{Runner: c}).Apply(want)
+	require.NoError(t, err)
+
+	assert.Empty(t, Unapplied(want, captureAll(t, c)))
+}
+
+func captureAll(t *testing.T, c *Client) *theme.Theme {
+	th, err := Capture(c, &CaptureOptions{Targets: true})
+	require.NoError(t, err)
+
+	return th
+}
+
+func TestWriteTheme(t *testing.T) {
+	th := themetest.Execute(t, "", applierTestTheme)
+
+	var b strings.Builder
+	err := WriteTheme(&b, th)
+	require.NoError(t, err)
+
+	restored := themetest.Execute(t, "", b.String())
+	assert.Equal(t, th.GlobalSessionOptions, restored.GlobalSessionOptions)
+	assert.Equal(t, th.GlobalWindowOptions, restored.GlobalWindowOptions)
+	assert.Equal(t, th.TargetSessionOptions, restored.TargetSessionOptions)
+}
+
+func TestParseOptions(t *testing.T) {
+	options, err := parseOptions(`backspace C-?
+command-alias[2] "server-info=show-messages -JT"
+@x "a \"b\" \$c #{d}"
+status-left "[#S] "
+@empty ""
+@bare
+`)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"backspace":        "C-?",
+		"command-alias[2]": "server-info=show-messages -JT",
+		"@x":               `a "b" $c #{d}`,
+		"status-left":      "[#S] ",
+		"@empty":           "",
+		"@bare":            "",
+	}, options)
+
+	_, err = parseOptions(`@x "unterminated`)
+	assert.Equal(t, &InvalidOutputError{Line: `@x "unterminated`}, err)
+}
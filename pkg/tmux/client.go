@@ -0,0 +1,71 @@
+// Package tmux talks to running tmux servers: applying themes to them and
+// capturing themes from their current options.
+package tmux
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+)
+
+// Runner runs tmux commands, returning their output.
+type Runner interface {
+	Run(args ...string) (string, error)
+}
+
+// Client runs tmux commands against a server by invoking the tmux binary
+// once per command.
+type Client struct {
+	// Binary is the tmux executable, "tmux" when empty.
+	Binary string
+	// Socket is the socket name passed with -L.
+	Socket string
+	// SocketPath is the socket path passed with -S, taking precedence over
+	// Socket.
+	SocketPath string
+}
+
+// NewClient returns a client for the server on the named socket, or the
+// default server if socket is empty.
+func NewClient(socket string) *Client {
+	return &Client{Socket: socket}
+}
+
+// Args returns the full command line used to run a tmux command, starting
+// with the binary.
+func (s *Client) Args(args ...string) []string {
+	binary := s.Binary
+	if binary == "" {
+		binary = "tmux"
+	}
+
+	argv := []string{binary}
+	if s.SocketPath != "" {
+		argv = append(argv, "-S", s.SocketPath)
+	} else if s.Socket != "" {
+		argv = append(argv, "-L", s.Socket)
+	}
+
+	return append(argv, args...)
+}
+
+func (s *Client) Run(args ...string) (string, error) {
+	argv := s.Args(args...)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+
+		return "", &CommandError{Args: args, Message: msg}
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,52 @@
+package tmux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*Runner)(nil), &Client{})
+}
+
+func TestClientArgs(t *testing.T) {
+	var tests = []struct {
+		client *Client
+		args   []string
+	}{
+		{&Client{}, []string{"tmux", "list-sessions"}},
+		{NewClient("work"), []string{"tmux", "-L", "work", "list-sessions"}},
+		{
+			&Client{Socket: "work", SocketPath: "/tmp/tmux.sock"},
+			[]string{"tmux", "-S", "/tmp/tmux.sock", "list-sessions"},
+		},
+		{
+			&Client{Binary: "/usr/local/bin/tmux"},
+			[]string{"/usr/local/bin/tmux", "list-sessions"},
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.args, tt.client.Args("list-sessions"))
+	}
+}
+
+func TestClientRun(t *testing.T) {
+	c, stop := startServer(t)
+	defer stop()
+
+	_, err := c.Run("set-option", "-g", "@greeting", "hello world")
+	require.NoError(t, err)
+
+	out, err := c.Run("show-options", "-gv", "@greeting")
+	require.NoError(t, err)
+	assert.Equal(t, "hello world\n", out)
+
+	_, err = c.Run("set-option", "-g", "status-style", "bogus=1")
+	assert.Equal(t, &CommandError{
+		Args:    []string{"set-option", "-g", "status-style", "bogus=1"},
+		Message: "invalid style: bogus=1",
+	}, err)
+}
@@ -0,0 +1,111 @@
+package tmux
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+// Command sets one option on a tmux server.
+type Command struct {
+	Scope  theme.Scope
+	Target string
+	Name   string
+	Value  string
+}
+
+// Commands returns the commands setting every option of an executed theme:
+// server options first, then session and window options, each group sorted
+// by target and name.
+func Commands(t *theme.Theme) []*Command {
+	cmds := []*Command{}
+
+	add := func(scope theme.Scope, target string, options map[string]string) {
+		names := make([]string, 0, len(options))
+		for name := range options {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			cmds = append(cmds, &Command{
+				Scope:  scope,
+				Target: target,
+				Name:   name,
+				Value:  options[name],
+			})
+		}
+	}
+
+	add(theme.ServerScope, "", t.ServerOptions)
+	add(theme.GlobalSessionScope, "", t.GlobalSessionOptions)
+	add(theme.SessionScope, "", t.SessionOptions)
+	for _, target := range sortedTargets(t.TargetSessionOptions) {
+		add(theme.SessionScope, target, t.TargetSessionOptions[target])
+	}
+	add(theme.GlobalWindowScope, "", t.GlobalWindowOptions)
+	add(theme.WindowScope, "", t.WindowOptions)
+	for _, target := range sortedTargets(t.TargetWindowOptions) {
+		add(theme.WindowScope, target, t.TargetWindowOptions[target])
+	}
+
+	return cmds
+}
+
+// SetArgs returns the set-option arguments applying the command.
+func (s *Command) SetArgs() []string {
+	args := append([]string{"set-option"}, s.flags()...)
+	if strings.HasPrefix(s.Name, "-") || strings.HasPrefix(s.Value, "-") {
+		args = append(args, "--")
+	}
+
+	return append(args, s.Name, s.Value)
+}
+
+// ShowArgs returns the show-options arguments querying the option's
+// current value.
+func (s *Command) ShowArgs() []string {
+	args := append([]string{"show-options", "-qv"}, s.flags()...)
+	if strings.HasPrefix(s.Name, "-") {
+		args = append(args, "--")
+	}
+
+	return append(args, s.Name)
+}
+
+// String returns the command as a line of tmux configuration.
+func (s *Command) String() string {
+	return commandLine(s.SetArgs())
+}
+
+func (s *Command) flags() []string {
+	flags := []string{}
+
+	switch s.Scope {
+	case theme.ServerScope:
+		flags = append(flags, "-s")
+	case theme.GlobalSessionScope:
+		flags = append(flags, "-g")
+	case theme.GlobalWindowScope:
+		flags = append(flags, "-gw")
+	case theme.WindowScope:
+		flags = append(flags, "-w")
+	}
+
+	if s.Target != "" && !s.Scope.IsGlobal() {
+		flags = append(flags, "-t", s.Target)
+	}
+
+	return flags
+}
+
+func sortedTargets(m map[string]map[string]string) []string {
+	targets := make([]string, 0, len(m))
+	for target := range m {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	return targets
+}
@@ -0,0 +1,27 @@
+package tmux
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+)
+
+type CommandError struct {
+	Args    []string
+	Message string
+}
+
+func (s *CommandError) Error() string {
+	return fmt.Sprintf("Command failed: %s: %s", commandLine(s.Args), s.Message)
+}
+
+// commandLine joins args into a tmux command line, quoting them as needed.
+func commandLine(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = theme.Quote(arg)
+	}
+
+	return strings.Join(quoted, " ")
+}
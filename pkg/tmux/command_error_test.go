@@ -0,0 +1,25 @@
+package tmux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &CommandError{})
+}
+
+func TestCommandError(t *testing.T) {
+	err := &CommandError{
+		Args:    []string{"set-option", "-g", "status-style", "bogus=1"},
+		Message: "invalid style: bogus=1",
+	}
+
+	assert.Equal(
+		t,
+		"Command failed: set-option -g status-style bogus=1: "+
+			"invalid style: bogus=1",
+		err.Error(),
+	)
+}
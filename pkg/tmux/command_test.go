@@ -0,0 +1,88 @@
+package tmux
+
+import (
+	"testing"
+
+	"github.com/jimeh/go-tmuxtheme/pkg/theme"
+	"github.com/jimeh/go-tmuxtheme/pkg/themetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommands(t *testing.T) {
+	th := themetest.Execute(t, "", `
+set -g status-style bg=blue
+set -s @server yes
+set -g @accent "#88c0d0"
+set @plain session
+set -t work @project api
+set -gw mode-style "bg=red"
+set -w @plain window
+set -w -t work:1 @editor vim
+`)
+
+	var lines []string
+	for _, cmd := range Commands(th) {
+		lines = append(lines, cmd.String())
+	}
+
+	assert.Equal(t, []string{
+		"set-option -s @server yes",
+		`set-option -g @accent "#88c0d0"`,
+		"set-option -g status-style bg=blue",
+		"set-option @plain session",
+		"set-option -t work @project api",
+		"set-option -gw mode-style bg=red",
+		"set-option -w @plain window",
+		"set-option -w -t work:1 @editor vim",
+	}, lines)
+}
+
+func TestCommandArgs(t *testing.T) {
+	cmd := &Command{
+		Scope:  theme.WindowScope,
+		Target: "work:1",
+		Name:   "window-status-format",
+		Value:  " #I:#W ",
+	}
+
+	assert.Equal(
+		t,
+		[]string{
+			"set-option", "-w", "-t", "work:1",
+			"window-status-format", " #I:#W ",
+		},
+		cmd.SetArgs(),
+	)
+	assert.Equal(
+		t,
+		[]string{
+			"show-options", "-qv", "-w", "-t", "work:1",
+			"window-status-format",
+		},
+		cmd.ShowArgs(),
+	)
+	assert.Equal(
+		t, `set-option -w -t work:1 window-status-format " #I:#W "`,
+		cmd.String(),
+	)
+}
+
+func TestCommandArgsDashValue(t *testing.T) {
+	cmd := &Command{
+		Scope: theme.GlobalSessionScope,
+		Name:  "@sep",
+		Value: "-#[fg=red]",
+	}
+
+	assert.Equal(
+		t,
+		[]string{"set-option", "-g", "--", "@sep", "-#[fg=red]"},
+		cmd.SetArgs(),
+	)
+	assert.Equal(
+		t,
+		[]string{"show-options", "-qv", "-g", "@sep"},
+		cmd.ShowArgs(),
+	)
+	assert.Equal(t, `set-option -g -- @sep "-#[fg=red]"`, cmd.String())
+}
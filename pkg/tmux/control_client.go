@@ -0,0 +1,123 @@
+package tmux
+
+import (
+	"bufio"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// ControlClient runs tmux commands through a single control mode (-C)
+// client attached to an existing session, avoiding a tmux process per
+// command.
+type ControlClient struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+}
+
+// StartControl attaches a control mode client to session on the server c
+// talks to, or to the most recently used session if session is empty.
+func StartControl(c *Client, session string) (*ControlClient, error) {
+	args := []string{"-C", "attach-session"}
+	if session != "" {
+		// A control client failing to attach may still run commands sent
+		// before it exits, so check the session exists first.
+		if _, err := c.Run("has-session", "-t", session); err != nil {
+			return nil, err
+		}
+		args = append(args, "-t", session)
+	}
+	argv := c.Args(args...)
+
+	cmd := exec.Command(argv[0], argv[1:]...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ControlClient{
+		cmd:    cmd,
+		stdin:  stdin,
+		stdout: bufio.NewReader(stdout),
+	}, nil
+}
+
+// Run sends a command and waits for its reply block. Notifications sent by
+// the server between blocks are skipped.
+func (s *ControlClient) Run(args ...string) (string, error) {
+	_, err := io.WriteString(s.stdin, commandLine(args)+"\n")
+	if err != nil {
+		return "", err
+	}
+
+	var output []string
+	inBlock := false
+
+	for {
+		line, err := s.stdout.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				return "", &CommandError{
+					Args: args, Message: "control mode client exited",
+				}
+			}
+			return "", err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if !inBlock {
+			inBlock = isControlGuard(line, "%begin")
+			continue
+		}
+
+		switch {
+		case isControlGuard(line, "%end"):
+			return strings.Join(output, "\n") + newline(output), nil
+		case isControlGuard(line, "%error"):
+			return "", &CommandError{
+				Args: args, Message: strings.Join(output, "\n"),
+			}
+		default:
+			output = append(output, line)
+		}
+	}
+}
+
+// Close detaches the control mode client.
+func (s *ControlClient) Close() error {
+	err := s.stdin.Close()
+	if err != nil {
+		return err
+	}
+
+	// tmux exits with a non-zero status when control mode input ends.
+	_ = s.cmd.Wait()
+
+	return nil
+}
+
+// isControlGuard reports whether line is a %begin, %end or %error guard
+// line for a command sent by this client.
+func isControlGuard(line, guard string) bool {
+	fields := strings.Fields(line)
+
+	return len(fields) == 4 && fields[0] == guard && fields[3] == "1"
+}
+
+func newline(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return "\n"
+}
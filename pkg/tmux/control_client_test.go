@@ -0,0 +1,56 @@
+package tmux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControlClientInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*Runner)(nil), &ControlClient{})
+}
+
+func TestControlClient(t *testing.T) {
+	c, stop := startServer(t)
+	defer stop()
+
+	cc, err := StartControl(c, "main")
+	require.NoError(t, err)
+	defer cc.Close()
+
+	out, err := cc.Run("set-option", "-g", "@greeting", `it's "$HOME"; ok`)
+	require.NoError(t, err)
+	assert.Equal(t, "", out)
+
+	out, err = cc.Run("show-options", "-gv", "@greeting")
+	require.NoError(t, err)
+	assert.Equal(t, "it's \"$HOME\"; ok\n", out)
+
+	_, err = cc.Run("set-option", "-g", "status-style", "bogus=1")
+	assert.Equal(t, &CommandError{
+		Args:    []string{"set-option", "-g", "status-style", "bogus=1"},
+		Message: "invalid style: bogus=1",
+	}, err)
+
+	out, err = cc.Run("display-message", "-p", "#{session_name}")
+	require.NoError(t, err)
+	assert.Equal(t, "main\n", out)
+}
+
+func TestControlClientNoSession(t *testing.T) {
+	c, stop := startServer(t)
+	defer stop()
+
+	_, err := StartControl(c, "missing")
+
+	assert.IsType(t, &CommandError{}, err)
+}
+
+func TestIsControlGuard(t *testing.T) {
+	assert.True(t, isControlGuard("%begin 1792403899 263 1", "%begin"))
+	assert.True(t, isControlGuard("%end 1792403899 263 1", "%end"))
+	assert.False(t, isControlGuard("%end 1792403899 263 0", "%end"))
+	assert.False(t, isControlGuard("%session-changed $0 main", "%begin"))
+	assert.False(t, isControlGuard("%begin", "%begin"))
+}
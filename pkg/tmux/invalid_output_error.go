@@ -0,0 +1,11 @@
+package tmux
+
+import "fmt"
+
+type InvalidOutputError struct {
+	Line string
+}
+
+func (s *InvalidOutputError) Error() string {
+	return fmt.Sprintf("Invalid tmux output: %s", s.Line)
+}
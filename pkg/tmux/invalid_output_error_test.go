@@ -0,0 +1,17 @@
+package tmux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidOutputErrorInterfaceCompliance(t *testing.T) {
+	assert.Implements(t, (*error)(nil), &InvalidOutputError{})
+}
+
+func TestInvalidOutputError(t *testing.T) {
+	err := &InvalidOutputError{Line: `@x "unterminated`}
+
+	assert.Equal(t, `Invalid tmux output: @x "unterminated`, err.Error())
+}
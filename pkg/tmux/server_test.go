@@ -0,0 +1,40 @@
+package tmux
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var serverCount int32
+
+// startServer starts a throwaway tmux server on a private socket with a
+// "main" session, skipping the test if tmux is not installed. The returned
+// function kills the server.
+func startServer(t *testing.T) (*Client, func()) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+
+	n := atomic.AddInt32(&serverCount, 1)
+	c := NewClient(fmt.Sprintf("go-tmuxtheme-test-%d-%d", os.Getpid(), n))
+
+	_, err := c.Run(
+		"-f", "/dev/null", "new-session", "-d", "-s", "main",
+		"-x", "80", "-y", "24",
+	)
+	require.NoError(t, err)
+
+	path, err := c.Run("display-message", "-p", "#{socket_path}")
+	require.NoError(t, err)
+
+	return c, func() {
+		_, _ = c.Run("kill-server")
+		_ = os.Remove(strings.TrimSpace(path))
+	}
+}